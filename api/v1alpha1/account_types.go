@@ -51,6 +51,260 @@ type AccountStatus struct {
 	RotateCredentials        bool               `json:"rotateCredentials,omitempty"`
 	RotateConsoleCredentials bool               `json:"rotateConsoleCredentials,omitempty"`
 	Reused                   bool               `json:"reused,omitempty"`
+	// CreateAccountRequestID is the ID of the Organizations request creating the AWS account
+	// +optional
+	CreateAccountRequestID string `json:"createAccountRequestID,omitempty"`
+	// CreateAccountRequestTime is when the AWS account creation was requested
+	// +optional
+	CreateAccountRequestTime *metav1.Time `json:"createAccountRequestTime,omitempty"`
+	// LastCredentialRotation is the last time the osdManagedAdmin access keys were rotated
+	// +optional
+	LastCredentialRotation *metav1.Time `json:"lastCredentialRotation,omitempty"`
+	// ReuseCleanup summarises the last cleanup run before the account was returned to the pool
+	// +optional
+	ReuseCleanup *ReuseCleanupReport `json:"reuseCleanup,omitempty"`
+	// Closure tracks the closure of the AWS account once the Account failed or was deleted
+	// +optional
+	Closure *AccountClosure `json:"closure,omitempty"`
+	// Retry is the retry budget spent on the recoverable failures of the account
+	// +optional
+	Retry *AccountRetryStatus `json:"retry,omitempty"`
+	// Regions tracks the initialization of every region of the account
+	// +optional
+	Regions []AccountRegionStatus `json:"regions,omitempty"`
+	// SupportCase tracks the support case opened to enable Enterprise Support on the account
+	// +optional
+	SupportCase *AccountSupportCase `json:"supportCase,omitempty"`
+}
+
+// AccountSupportCase is the lifecycle of the support case of the account
+// +k8s:openapi-gen=true
+type AccountSupportCase struct {
+	// Status is the last known status of the case: opened, unassigned, work-in-progress,
+	// pending-customer-action, customer-action-completed, reopened or resolved
+	// +optional
+	Status string `json:"status,omitempty"`
+	// CreationTime is when the case was opened
+	// +optional
+	CreationTime *metav1.Time `json:"creationTime,omitempty"`
+	// LastStatusChangeTime is when the status of the case last changed
+	// +optional
+	LastStatusChangeTime *metav1.Time `json:"lastStatusChangeTime,omitempty"`
+	// FollowUps is the number of follow-up communications added to the case
+	// +optional
+	FollowUps int `json:"followUps,omitempty"`
+	// LastFollowUpTime is when the last follow-up communication was added to the case
+	// +optional
+	LastFollowUpTime *metav1.Time `json:"lastFollowUpTime,omitempty"`
+	// BatchOwner is the Account that opened the case, when it was opened for a batch of accounts.
+	// Only the owner follows the case up.
+	// +optional
+	BatchOwner string `json:"batchOwner,omitempty"`
+	// BatchSize is the number of accounts the case was opened for, when it was opened for a batch
+	// +optional
+	BatchSize int `json:"batchSize,omitempty"`
+}
+
+// AccountRegionStatus is the initialization progress of a region of the account
+// +k8s:openapi-gen=true
+type AccountRegionStatus struct {
+	// Name is the name of the region
+	Name string `json:"name"`
+	// Phase is the last initialization step of the region that completed
+	Phase AccountRegionPhase `json:"phase"`
+	// InstanceID is the ID of the EC2 instance launched to initialize the region
+	// +optional
+	InstanceID string `json:"instanceID,omitempty"`
+	// Network is the VPC and subnet the EC2 instance of a fedramp account runs in
+	// +optional
+	Network *AccountRegionNetwork `json:"network,omitempty"`
+	// QuotaCaseID is the ID of the case of the vCPU quota increase requested in the region.
+	// Quotas holds the requests of every quota.
+	// +optional
+	QuotaCaseID string `json:"quotaCaseID,omitempty"`
+	// Quotas tracks the service quota increases of the region
+	// +optional
+	Quotas []AccountQuotaStatus `json:"quotas,omitempty"`
+	// Reason is why the region failed, the AWS error code when there is one
+	// +optional
+	Reason string `json:"reason,omitempty"`
+	// Message details the last step completed, or the error of the step that failed
+	// +optional
+	Message string `json:"message,omitempty"`
+	// LastTransitionTime is when the region last changed phase
+	// +optional
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+}
+
+// AccountRegionNetwork is the network created to initialize a region of a fedramp account
+// +k8s:openapi-gen=true
+type AccountRegionNetwork struct {
+	// VpcID is the ID of the VPC
+	// +optional
+	VpcID string `json:"vpcID,omitempty"`
+	// SubnetID is the ID of the subnet of the VPC
+	// +optional
+	SubnetID string `json:"subnetID,omitempty"`
+}
+
+// AccountQuotaStatus is the state of a service quota of a region of the account
+// +k8s:openapi-gen=true
+type AccountQuotaStatus struct {
+	// ServiceCode is the code of the service of the quota, e.g. ec2
+	ServiceCode string `json:"serviceCode"`
+	// QuotaCode is the code of the quota, e.g. L-1216C47A
+	QuotaCode string `json:"quotaCode"`
+	// DesiredValue is the value the quota is increased to
+	DesiredValue int64 `json:"desiredValue"`
+	// Phase is Sufficient when the quota already has the desired value, Requested while an
+	// increase request is pending, then Granted or Denied once AWS answered it, and Failed when the
+	// quota couldn't be checked or requested
+	Phase AccountQuotaPhase `json:"phase"`
+	// RequestID is the ID of the quota increase request, polled until AWS answers it
+	// +optional
+	RequestID string `json:"requestID,omitempty"`
+	// CaseID is the ID of the support case of the quota increase request
+	// +optional
+	CaseID string `json:"caseID,omitempty"`
+	// Message is a human readable description of the phase
+	// +optional
+	Message string `json:"message,omitempty"`
+	// LastTransitionTime is when the phase last changed
+	// +optional
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+}
+
+// AccountQuotaPhase is the state of a service quota increase
+type AccountQuotaPhase string
+
+const (
+	// QuotaSufficient means the quota already has the desired value
+	QuotaSufficient AccountQuotaPhase = "Sufficient"
+	// QuotaIncreaseRequested means an increase of the quota to the desired value was requested
+	QuotaIncreaseRequested AccountQuotaPhase = "Requested"
+	// QuotaIncreaseGranted means the requested increase of the quota was approved
+	QuotaIncreaseGranted AccountQuotaPhase = "Granted"
+	// QuotaIncreaseDenied means the requested increase of the quota was denied
+	QuotaIncreaseDenied AccountQuotaPhase = "Denied"
+	// QuotaFailed means the quota could not be checked or its increase requested
+	QuotaFailed AccountQuotaPhase = "Failed"
+)
+
+// AccountRegionPhase is a step of the initialization of a region
+type AccountRegionPhase string
+
+const (
+	// RegionPending means no EC2 instance was launched in the region yet
+	RegionPending AccountRegionPhase = "Pending"
+	// RegionInstanceLaunched means an EC2 instance was launched in the region and must be terminated
+	RegionInstanceLaunched AccountRegionPhase = "InstanceLaunched"
+	// RegionInstanceTerminating means the EC2 instance of a fedramp region is being terminated, its
+	// network is deleted once it is
+	RegionInstanceTerminating AccountRegionPhase = "InstanceTerminating"
+	// RegionInstanceTerminated means the EC2 instance was terminated, the region is initialized
+	RegionInstanceTerminated AccountRegionPhase = "InstanceTerminated"
+	// RegionFailed means the region could not be initialized
+	RegionFailed AccountRegionPhase = "Failed"
+)
+
+// IsDone returns true if the initialization of the region is over, whether it succeeded or not.
+// A failed region is only done once its network is deleted.
+func (s *AccountRegionStatus) IsDone() bool {
+	return s.Phase == RegionInstanceTerminated || (s.Phase == RegionFailed && s.Network == nil)
+}
+
+// AccountRetryStatus records the failures of an account and the retries made for the recoverable ones
+// +k8s:openapi-gen=true
+type AccountRetryStatus struct {
+	// Attempts is the number of retries made, reset when a retry is forced
+	Attempts int `json:"attempts"`
+	// LastFailureReason is the reason of the last failure
+	// +optional
+	LastFailureReason string `json:"lastFailureReason,omitempty"`
+	// LastFailureTime is when the account last failed
+	// +optional
+	LastFailureTime metav1.Time `json:"lastFailureTime,omitempty"`
+	// NextRetryTime is when the account is retried, unset when the failure is terminal
+	// +optional
+	NextRetryTime *metav1.Time `json:"nextRetryTime,omitempty"`
+	// ResumeState is the state the account is retried from
+	// +optional
+	ResumeState string `json:"resumeState,omitempty"`
+}
+
+// AccountClosure tracks the steps closing the AWS account of a failed or deleted Account
+// +k8s:openapi-gen=true
+type AccountClosure struct {
+	// Phase is the last closure step that completed
+	Phase AccountClosurePhase `json:"phase"`
+	// StartTime is when the closure started
+	// +optional
+	StartTime metav1.Time `json:"startTime,omitempty"`
+	// LastTransitionTime is when the last step completed
+	// +optional
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+	// Message details the last step completed, or the error of the step that failed
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// AccountClosurePhase is a step of the AWS account closure
+type AccountClosurePhase string
+
+const (
+	// ClosureStarted means the closure started, the account is still in its OU
+	ClosureStarted AccountClosurePhase = "Started"
+	// ClosureMovedToSuspendedOU means the account was moved to the suspended OU
+	ClosureMovedToSuspendedOU AccountClosurePhase = "MovedToSuspendedOU"
+	// ClosureTagsRemoved means the tags of the account were removed
+	ClosureTagsRemoved AccountClosurePhase = "TagsRemoved"
+	// ClosureClosed means the account was closed, AWS keeps it SUSPENDED for 90 days
+	ClosureClosed AccountClosurePhase = "Closed"
+	// ClosureRemovedFromOrganization means the account was removed from the organization
+	ClosureRemovedFromOrganization AccountClosurePhase = "RemovedFromOrganization"
+)
+
+// ReuseCleanupReport summarises a reuse cleanup run on an account
+// +k8s:openapi-gen=true
+type ReuseCleanupReport struct {
+	// CompletionTime is when the cleanup run finished
+	// +optional
+	CompletionTime metav1.Time `json:"completionTime,omitempty"`
+	// Succeeded is true if every cleaner finished without errors
+	Succeeded bool `json:"succeeded"`
+	// DryRun is true if the cleaners only listed the resources they would delete
+	// +optional
+	DryRun bool `json:"dryRun,omitempty"`
+	// Results holds the outcome of every cleaner that ran
+	// +optional
+	Results []CleanupResult `json:"results,omitempty"`
+}
+
+// CleanupResult is the outcome of one cleaner in one region
+// +k8s:openapi-gen=true
+type CleanupResult struct {
+	// Cleaner is the name of the cleaner that produced the result
+	Cleaner string `json:"cleaner"`
+	// Region is the region the cleaner ran in, empty for global services
+	// +optional
+	Region string `json:"region,omitempty"`
+	// Found is the number of resources found
+	Found int `json:"found"`
+	// Deleted is the number of resources deleted
+	Deleted int `json:"deleted"`
+	// Failed is the number of resources that could not be deleted
+	Failed int `json:"failed"`
+	// Pending is the number of resources that could not be deleted yet, because AWS is still
+	// deleting what they depend on. They are deleted by the next cleanup run.
+	// +optional
+	Pending int `json:"pending,omitempty"`
+	// Error is the error that stopped the cleaner, if any
+	// +optional
+	Error string `json:"error,omitempty"`
+	// Resources lists the resources found, only filled in by dry runs. The names listed across
+	// the report are capped, Found still counts every resource.
+	// +optional
+	Resources []string `json:"resources,omitempty"`
 }
 
 // AccountCondition contains details for the current condition of a AWS account
@@ -111,6 +365,10 @@ const (
 	AccountInitializingRegions = "InitializingRegions"
 	// AccountQuotaIncreaseRequested is set when a quota increase has been requested
 	AccountQuotaIncreaseRequested AccountConditionType = "QuotaIncreaseRequested"
+	// AccountReuseCleanupFailed is set when the cleanup of a reused account has failed
+	AccountReuseCleanupFailed AccountConditionType = "ReuseCleanupFailed"
+	// AccountRetrying is set when a recoverable failure is waiting to be retried
+	AccountRetrying AccountConditionType = "Retrying"
 )
 
 // +genclient
@@ -166,6 +424,14 @@ func (a *Account) IsFailed() bool {
 	return false
 }
 
+//IsClosed returns true if the AWS account of the account has been closed or removed from the organization
+func (a *Account) IsClosed() bool {
+	if a.Status.Closure == nil {
+		return false
+	}
+	return a.Status.Closure.Phase == ClosureClosed || a.Status.Closure.Phase == ClosureRemovedFromOrganization
+}
+
 //HasState returns true if an account has a state set at all
 func (a *Account) HasState() bool {
 	return a.Status.State != ""
@@ -191,6 +457,11 @@ func (a *Account) IsCreating() bool {
 	return a.Status.State == string(AccountCreating)
 }
 
+//NeedsCredentialRotation returns true if either of the credential rotation flags is set
+func (a *Account) NeedsCredentialRotation() bool {
+	return a.Status.RotateCredentials || a.Status.RotateConsoleCredentials
+}
+
 //HasClaimLink returns true if an accounts claim link is not empty
 func (a *Account) HasClaimLink() bool {
 	return a.Spec.ClaimLink != ""
@@ -280,16 +551,45 @@ func (a *Account) IsInitializingRegions() bool {
 	return a.Status.State == AccountInitializingRegions
 }
 
+//RegionsInitialized returns true if every region of the account is done initializing
+func (a *Account) RegionsInitialized() bool {
+	for i := range a.Status.Regions {
+		if !a.Status.Regions[i].IsDone() {
+			return false
+		}
+	}
+	return true
+}
+
+//HasPendingQuotaIncreases returns true if a quota increase requested in a region of the account
+//wasn't answered yet
+func (a *Account) HasPendingQuotaIncreases() bool {
+	for _, region := range a.Status.Regions {
+		for _, quota := range region.Quotas {
+			if quota.Phase == QuotaIncreaseRequested {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 //IsProgressing returns true if the account state is Creating, Pending Verification, or InitializingRegions
 func (a *Account) IsProgressing() bool {
 	if a.Status.State == string(AccountCreating) ||
 		a.Status.State == string(AccountPendingVerification) ||
-		a.Status.State == string(AccountInitializingRegions) {
+		a.Status.State == string(AccountInitializingRegions) ||
+		a.Status.State == string(AccountRetrying) {
 		return true
 	}
 	return false
 }
 
+//IsRetrying returns true if the account failed and is waiting to be retried
+func (a *Account) IsRetrying() bool {
+	return a.Status.State == string(AccountRetrying)
+}
+
 // HasBeenClaimed lets us know if an account has been claimed at some point and can only be reused by clusters in the same legal entity
 func (a *Account) HasBeenClaimedAtLeastOnce() bool {
 	return a.Spec.LegalEntity.ID != "" || a.Status.Reused
@@ -313,6 +613,20 @@ func (a *Account) IsOwnedByAccountPool() bool {
 	return false
 }
 
+// GetAccountPoolName returns the name of the AccountPool the account belongs to, taken from the
+// AccountPool label or, for accounts created before the label existed, from the owner reference
+func (a *Account) GetAccountPoolName() string {
+	if name, ok := a.Labels[AccountPoolLabel]; ok {
+		return name
+	}
+	for _, ref := range a.ObjectMeta.OwnerReferences {
+		if ref.Kind == "AccountPool" {
+			return ref.Name
+		}
+	}
+	return ""
+}
+
 // GetCondition finds the condition that has the
 // specified condition type in the given list. If none exists, then returns nil.
 func (a *Account) GetCondition(conditionType AccountConditionType) *AccountCondition {
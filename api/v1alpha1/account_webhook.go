@@ -0,0 +1,56 @@
+package v1alpha1
+
+import (
+	"reflect"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+// SetupWebhookWithManager registers the Account validating webhook with the manager
+func (a *Account) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(a).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/validate-aws-managed-openshift-io-v1alpha1-account,mutating=false,failurePolicy=fail,sideEffects=None,groups=aws.managed.openshift.io,resources=accounts,verbs=create;update,versions=v1alpha1,name=vaccount.managed.openshift.io,admissionReviewVersions=v1
+
+var _ webhook.Validator = &Account{}
+
+// ValidateCreate implements webhook.Validator
+func (a *Account) ValidateCreate() error {
+	return toInvalidError("Account", a.Name, a.validateSpec())
+}
+
+// ValidateUpdate implements webhook.Validator
+func (a *Account) ValidateUpdate(old runtime.Object) error {
+	oldAccount, ok := old.(*Account)
+	if !ok {
+		return nil
+	}
+	if a.DeletionTimestamp != nil || reflect.DeepEqual(a.Spec, oldAccount.Spec) {
+		return nil
+	}
+
+	allErrs := a.validateSpec()
+	allErrs = append(allErrs, validateImmutable(a.Spec.AwsAccountID, oldAccount.Spec.AwsAccountID, field.NewPath("spec", "awsAccountID"))...)
+	return toInvalidError("Account", a.Name, allErrs)
+}
+
+// ValidateDelete implements webhook.Validator
+func (a *Account) ValidateDelete() error {
+	return nil
+}
+
+// validateSpec runs the format checks on the Account spec
+func (a *Account) validateSpec() field.ErrorList {
+	allErrs := field.ErrorList{}
+	// The account ID is only known once the account has been created in AWS
+	if a.Spec.AwsAccountID != "" {
+		allErrs = append(allErrs, validateAWSAccountID(a.Spec.AwsAccountID, field.NewPath("spec", "awsAccountID"))...)
+	}
+	return allErrs
+}
@@ -27,6 +27,17 @@ type AccountClaimSpec struct {
 	SupportRoleARN      string      `json:"supportRoleARN,omitempty"`
 	CustomTags          string      `json:"customTags,omitempty"`
 	KmsKeyId            string      `json:"kmsKeyId,omitempty"`
+	// AccountPool is the name of the AccountPool the account is claimed from
+	// +optional
+	AccountPool string `json:"accountPool,omitempty"`
+	// AccountPoolSelector selects the AccountPools the account can be claimed from by their labels.
+	// Claims without AccountPool or AccountPoolSelector can be satisfied by any pool.
+	// +optional
+	AccountPoolSelector *metav1.LabelSelector `json:"accountPoolSelector,omitempty"`
+	// PriorityClassName is the priority class of the claim. Pending claims of a higher priority get
+	// an account first. The priority classes are defined in the operator configmap.
+	// +optional
+	PriorityClassName string `json:"priorityClassName,omitempty"`
 }
 
 // AccountClaimStatus defines the observed state of AccountClaim
@@ -37,6 +48,11 @@ type AccountClaimStatus struct {
 	Conditions []AccountClaimCondition `json:"conditions"`
 
 	State ClaimStatus `json:"state"`
+
+	// QueuePosition is the position of the pending claim in the queue of the claims waiting for an
+	// account, 1 being the next claim to get one
+	// +optional
+	QueuePosition int `json:"queuePosition,omitempty"`
 }
 
 // AccountClaimCondition contains details for the current condition of a AWS account claim
@@ -71,6 +87,8 @@ const (
 	AccountClaimFailed AccountClaimConditionType = "AccountClaimFailed"
 	// AccountUnclaimed is set when an Account is not claimed
 	AccountUnclaimed AccountClaimConditionType = "Unclaimed"
+	// AccountClaimPending is set while a claim waits for an Account, its reason tells why
+	AccountClaimPending AccountClaimConditionType = "Pending"
 	// ClientError is set when an Error regarding the client occurred
 	ClientError AccountClaimConditionType = "ClientError"
 	// AuthenticationFailed is set when we get an AWS error from STS role assumption
@@ -0,0 +1,117 @@
+package v1alpha1
+
+import (
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+// SetupWebhookWithManager registers the AccountClaim validating webhook with the manager
+func (a *AccountClaim) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(a).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/validate-aws-managed-openshift-io-v1alpha1-accountclaim,mutating=false,failurePolicy=fail,sideEffects=None,groups=aws.managed.openshift.io,resources=accountclaims,verbs=create;update,versions=v1alpha1,name=vaccountclaim.managed.openshift.io,admissionReviewVersions=v1
+
+var _ webhook.Validator = &AccountClaim{}
+
+// ValidateCreate implements webhook.Validator
+func (a *AccountClaim) ValidateCreate() error {
+	return toInvalidError("AccountClaim", a.Name, a.validateSpec())
+}
+
+// ValidateUpdate implements webhook.Validator
+func (a *AccountClaim) ValidateUpdate(old runtime.Object) error {
+	oldClaim, ok := old.(*AccountClaim)
+	if !ok {
+		return nil
+	}
+	// Don't block finalizers from being removed, or metadata-only updates to claims
+	// created before the webhook existed
+	if a.DeletionTimestamp != nil || reflect.DeepEqual(a.Spec, oldClaim.Spec) {
+		return nil
+	}
+
+	// Claims created before the webhook existed may not pass the create checks, e.g. claims
+	// without regions, so only the fields that changed are checked
+	specPath := field.NewPath("spec")
+	allErrs := a.validateSpecFormats(&oldClaim.Spec)
+	allErrs = append(allErrs, validateImmutable(a.Spec.AccountLink, oldClaim.Spec.AccountLink, specPath.Child("accountLink"))...)
+	allErrs = append(allErrs, validateImmutable(a.Spec.BYOCAWSAccountID, oldClaim.Spec.BYOCAWSAccountID, specPath.Child("byocAWSAccountID"))...)
+	return toInvalidError("AccountClaim", a.Name, allErrs)
+}
+
+// ValidateDelete implements webhook.Validator
+func (a *AccountClaim) ValidateDelete() error {
+	return nil
+}
+
+// validateSpec runs Validate and the format checks on the AccountClaim spec
+func (a *AccountClaim) validateSpec() field.ErrorList {
+	specPath := field.NewPath("spec")
+	allErrs := field.ErrorList{}
+
+	if err := a.Validate(); err != nil {
+		allErrs = append(allErrs, field.Required(validateErrorPath(err, specPath), err.Error()))
+	}
+	allErrs = append(allErrs, a.validateSpecFormats(nil)...)
+
+	return allErrs
+}
+
+// validateSpecFormats runs the format checks on the fields of the AccountClaim spec that differ
+// from oldSpec, or on every field when oldSpec is nil
+func (a *AccountClaim) validateSpecFormats(oldSpec *AccountClaimSpec) field.ErrorList {
+	specPath := field.NewPath("spec")
+	allErrs := field.ErrorList{}
+	create := oldSpec == nil
+
+	if create || !reflect.DeepEqual(a.Spec.Aws.Regions, oldSpec.Aws.Regions) {
+		allErrs = append(allErrs, validateRegions(a.Spec.Aws.Regions, specPath.Child("aws", "regions"))...)
+	}
+	if create || a.Spec.CustomTags != oldSpec.CustomTags {
+		allErrs = append(allErrs, validateTags(a.Spec.CustomTags, specPath.Child("customTags"))...)
+	}
+	if a.Spec.BYOCAWSAccountID != "" && (create || a.Spec.BYOCAWSAccountID != oldSpec.BYOCAWSAccountID) {
+		allErrs = append(allErrs, validateAWSAccountID(a.Spec.BYOCAWSAccountID, specPath.Child("byocAWSAccountID"))...)
+	}
+	if a.Spec.STSRoleARN != "" && (create || a.Spec.STSRoleARN != oldSpec.STSRoleARN) {
+		allErrs = append(allErrs, validateIAMARN(a.Spec.STSRoleARN, specPath.Child("stsRoleARN"))...)
+	}
+	if a.Spec.SupportRoleARN != "" && (create || a.Spec.SupportRoleARN != oldSpec.SupportRoleARN) {
+		allErrs = append(allErrs, validateIAMARN(a.Spec.SupportRoleARN, specPath.Child("supportRoleARN"))...)
+	}
+	if a.Spec.AccountPoolSelector != nil && (create || a.Spec.AccountPool != oldSpec.AccountPool ||
+		!reflect.DeepEqual(a.Spec.AccountPoolSelector, oldSpec.AccountPoolSelector)) {
+		if a.Spec.AccountPool != "" {
+			allErrs = append(allErrs, field.Forbidden(specPath.Child("accountPoolSelector"), "accountPool and accountPoolSelector can't both be set"))
+		}
+		if _, err := metav1.LabelSelectorAsSelector(a.Spec.AccountPoolSelector); err != nil {
+			allErrs = append(allErrs, field.Invalid(specPath.Child("accountPoolSelector"), a.Spec.AccountPoolSelector, err.Error()))
+		}
+	}
+
+	return allErrs
+}
+
+// validateErrorPath returns the field an error returned by Validate is about
+func validateErrorPath(err error, specPath *field.Path) *field.Path {
+	switch err {
+	case ErrSTSRoleARNMissing:
+		return specPath.Child("stsRoleARN")
+	case ErrBYOCAccountIDMissing:
+		return specPath.Child("byocAWSAccountID")
+	case ErrBYOCSecretRefMissing:
+		return specPath.Child("byocSecretRef")
+	case ErrAWSSecretRefMissing:
+		return specPath.Child("awsCredentialSecret")
+	default:
+		return specPath
+	}
+}
@@ -0,0 +1,145 @@
+package v1alpha1
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// weekdays maps the day names accepted in PoolSizeWindow.Days to their weekday
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+// Location returns the time zone of the schedule
+func (s *PoolSchedule) Location() (*time.Location, error) {
+	if s.TimeZone == "" {
+		return time.UTC, nil
+	}
+	return time.LoadLocation(s.TimeZone)
+}
+
+// ActiveWindow returns the first window of the schedule active at t, or nil when none is
+func (s *PoolSchedule) ActiveWindow(t time.Time) (*PoolSizeWindow, error) {
+	loc, err := s.Location()
+	if err != nil {
+		return nil, err
+	}
+	for i := range s.Windows {
+		active, err := s.Windows[i].IsActive(t.In(loc))
+		if err != nil {
+			return nil, err
+		}
+		if active {
+			return &s.Windows[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// NextTransition returns the first time after t at which a window of the schedule may start or end
+func (s *PoolSchedule) NextTransition(t time.Time) (time.Time, error) {
+	loc, err := s.Location()
+	if err != nil {
+		return time.Time{}, err
+	}
+	t = t.In(loc)
+
+	var next time.Time
+	for _, window := range s.Windows {
+		for _, clock := range []string{window.Start, window.End} {
+			minutes, err := parseClock(clock)
+			if err != nil {
+				return time.Time{}, err
+			}
+			candidate := time.Date(t.Year(), t.Month(), t.Day(), 0, minutes, 0, 0, loc)
+			if !candidate.After(t) {
+				candidate = candidate.AddDate(0, 0, 1)
+			}
+			if next.IsZero() || candidate.Before(next) {
+				next = candidate
+			}
+		}
+	}
+	return next, nil
+}
+
+// IsActive returns whether the window is active at t, t being in the time zone of its schedule.
+// A window ending before it starts runs overnight, and is active the morning after its days.
+func (w *PoolSizeWindow) IsActive(t time.Time) (bool, error) {
+	days, err := parseDays(w.Days)
+	if err != nil {
+		return false, err
+	}
+	start, err := parseClock(w.Start)
+	if err != nil {
+		return false, err
+	}
+	end, err := parseClock(w.End)
+	if err != nil {
+		return false, err
+	}
+
+	now := t.Hour()*60 + t.Minute()
+	today := t.Weekday()
+	yesterday := (today + 6) % 7
+	switch {
+	case start < end:
+		return days[today] && now >= start && now < end, nil
+	case start > end:
+		return (days[today] && now >= start) || (days[yesterday] && now < end), nil
+	default:
+		// A window starting and ending at the same time lasts the whole day
+		return days[today], nil
+	}
+}
+
+// parseClock returns the minutes since midnight of a HH:MM time of day
+func parseClock(clock string) (int, error) {
+	parsed, err := time.Parse("15:04", clock)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a HH:MM time of day", clock)
+	}
+	return parsed.Hour()*60 + parsed.Minute(), nil
+}
+
+// parseDays returns the weekdays of a list of days or day ranges such as Mon-Fri,Sun.
+// An empty list is every day of the week.
+func parseDays(days string) (map[time.Weekday]bool, error) {
+	result := map[time.Weekday]bool{}
+	if strings.TrimSpace(days) == "" {
+		for _, day := range weekdays {
+			result[day] = true
+		}
+		return result, nil
+	}
+
+	for _, item := range strings.Split(days, ",") {
+		bounds := strings.SplitN(strings.TrimSpace(item), "-", 2)
+		first, ok := weekdays[strings.ToLower(bounds[0])]
+		if !ok {
+			return nil, fmt.Errorf("%q is not a day of the week", bounds[0])
+		}
+		last := first
+		if len(bounds) == 2 {
+			last, ok = weekdays[strings.ToLower(bounds[1])]
+			if !ok {
+				return nil, fmt.Errorf("%q is not a day of the week", bounds[1])
+			}
+		}
+		// Ranges can wrap around the end of the week, e.g. Fri-Mon
+		for day := first; ; day = (day + 1) % 7 {
+			result[day] = true
+			if day == last {
+				break
+			}
+		}
+	}
+	return result, nil
+}
@@ -11,11 +11,141 @@ import (
 // +k8s:openapi-gen=true
 type AccountPoolSpec struct {
 	PoolSize int `json:"poolSize"`
+
+	// AccountTemplate holds the defaults of the Accounts created by this pool.
+	// Fields left empty fall back to the operator configmap.
+	// +optional
+	AccountTemplate AccountTemplate `json:"accountTemplate,omitempty"`
+
+	// MaxInFlight is the largest number of new accounts the pool has progressing towards Ready at
+	// the same time. When it's not set the pool creates one account per reconcile, without a limit.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MaxInFlight int `json:"maxInFlight,omitempty"`
+
+	// ScaleDown is what the pool does with its surplus never-claimed accounts when poolSize is lowered.
+	// The surplus accounts are kept when it's not set.
+	// +optional
+	ScaleDown *ScaleDownPolicy `json:"scaleDown,omitempty"`
+
+	// Schedule replaces poolSize with the pool size of its windows while they are active
+	// +optional
+	Schedule *PoolSchedule `json:"schedule,omitempty"`
+
+	// Demand grows the pool to match the rate at which accounts have recently been claimed
+	// +optional
+	Demand *DemandSizing `json:"demand,omitempty"`
+
+	// OnDemand grows the pool to the number of claims waiting for one of its accounts, so a claim
+	// finding the pool empty gets an account created for it
+	// +optional
+	OnDemand bool `json:"onDemand,omitempty"`
+}
+
+// PoolSchedule is a list of recurring time windows with their own pool size
+type PoolSchedule struct {
+	// TimeZone of the windows, an IANA name such as America/New_York. Defaults to UTC.
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+
+	// Windows with their own pool size. When several windows are active the first one is used.
+	Windows []PoolSizeWindow `json:"windows"`
+}
+
+// PoolSizeWindow is a recurring time window during which the pool has its own size
+type PoolSizeWindow struct {
+	// Name of the window, reported in the status while the window is active
+	Name string `json:"name"`
+
+	// Days of the week the window starts on, as a comma separated list of days or day
+	// ranges such as Mon-Fri or Sat,Sun. Every day when empty.
+	// +optional
+	Days string `json:"days,omitempty"`
+
+	// Start is the time of day, HH:MM, the window starts at
+	Start string `json:"start"`
+
+	// End is the time of day, HH:MM, the window ends at. Windows ending before they start run overnight.
+	End string `json:"end"`
+
+	// PoolSize of the pool while the window is active
+	PoolSize int `json:"poolSize"`
+}
+
+// DemandSizing sizes the pool from the rate at which accounts have recently been claimed.
+// The pool never gets smaller than the size from poolSize or the schedule.
+type DemandSizing struct {
+	// LookbackPeriod over which the claims are counted. Defaults to 1h.
+	// +optional
+	LookbackPeriod metav1.Duration `json:"lookbackPeriod,omitempty"`
+
+	// LeadTime is how long the pool must be able to serve claims at that rate, usually the
+	// time it takes for a new account to become Ready. Defaults to 1h.
+	// +optional
+	LeadTime metav1.Duration `json:"leadTime,omitempty"`
+
+	// MaxPoolSize caps the pool size demand sizing can ask for
+	// +optional
+	MaxPoolSize int `json:"maxPoolSize,omitempty"`
+}
+
+// ScaleDownAction is what happens to the AWS account of a surplus Account retired by its pool
+type ScaleDownAction string
+
+const (
+	// ScaleDownRetain keeps the surplus accounts in the pool
+	ScaleDownRetain ScaleDownAction = "Retain"
+	// ScaleDownClose closes the AWS accounts of the surplus accounts
+	ScaleDownClose ScaleDownAction = "Close"
+	// ScaleDownPark moves the AWS accounts of the surplus accounts to a holding OU
+	ScaleDownPark ScaleDownAction = "Park"
+)
+
+// ScaleDownPolicy defines how an AccountPool retires its surplus accounts
+type ScaleDownPolicy struct {
+	// Action is Retain, Close or Park
+	// +kubebuilder:validation:Enum=Retain;Close;Park
+	Action ScaleDownAction `json:"action"`
+
+	// HoldingOU is the ID of the OU parked accounts are moved to. Required by the Park action.
+	// +optional
+	HoldingOU string `json:"holdingOU,omitempty"`
+}
+
+// AccountTemplate defines the defaults of the Accounts of an AccountPool
+type AccountTemplate struct {
+	// OrganizationalUnit is the ID of the OU under which the claimed accounts of the pool are
+	// moved, instead of the base OU of the operator configmap
+	// +optional
+	OrganizationalUnit string `json:"organizationalUnit,omitempty"`
+
+	// ManagedTags are added to the managed tags of the operator configmap, one key=value pair
+	// per line. A key set in both uses the value of the pool.
+	// +optional
+	ManagedTags string `json:"managedTags,omitempty"`
+
+	// Regions to initialize in the accounts of the pool, instead of the regions of the operator configmap
+	// +optional
+	Regions []RegionAMI `json:"regions,omitempty"`
+
+	// Fedramp overrides the fedramp setting of the operator configmap when initializing the
+	// accounts of the pool
+	// +optional
+	Fedramp *bool `json:"fedramp,omitempty"`
+}
+
+// RegionAMI is a region to initialize with the AMI and instance type used to do it
+type RegionAMI struct {
+	Name         string `json:"name"`
+	Ami          string `json:"ami"`
+	InstanceType string `json:"instanceType"`
 }
 
 // AccountPoolStatus defines the observed state of AccountPool
 // +k8s:openapi-gen=true
 type AccountPoolStatus struct {
+	// PoolSize is the desired number of unclaimed accounts, from poolSize, the schedule or demand
+	// sizing. UnclaimedAccounts is the actual one.
 	PoolSize int `json:"poolSize"`
 
 	// UnclaimedAccounts is an approximate value representing the amount of non-failed accounts
@@ -32,6 +162,26 @@ type AccountPoolStatus struct {
 
 	// AWSLimitDelta shows the approximate difference between the number of AWS accounts currently created and the limit. This should be the same across all hive shards in an environment
 	AWSLimitDelta int `json:"awsLimitDelta"`
+
+	// SurplusAccounts is the approximate number of unclaimed accounts above the pool size
+	// +optional
+	SurplusAccounts int `json:"surplusAccounts,omitempty"`
+
+	// RetiringAccounts is the number of surplus accounts being closed or parked by the scale down policy
+	// +optional
+	RetiringAccounts int `json:"retiringAccounts,omitempty"`
+
+	// ActiveWindow is the name of the schedule window that sets the pool size
+	// +optional
+	ActiveWindow string `json:"activeWindow,omitempty"`
+
+	// DemandPoolSize is the pool size asked for by demand sizing
+	// +optional
+	DemandPoolSize int `json:"demandPoolSize,omitempty"`
+
+	// PendingClaims is the number of claims waiting for an account of an on-demand pool
+	// +optional
+	PendingClaims int `json:"pendingClaims,omitempty"`
 }
 
 // +genclient
@@ -46,6 +196,7 @@ type AccountPoolStatus struct {
 // +kubebuilder:printcolumn:name="Available Accounts",type="integer",JSONPath=".status.availableAccounts",description="Number of ready accounts"
 // +kubebuilder:printcolumn:name="Accounts Progressing",type="integer",JSONPath=".status.accountsProgressing",description="Number of accounts progressing towards ready"
 // +kubebuilder:printcolumn:name="AWS Limit Delta",type="integer",JSONPath=".status.awsLimitDelta",description="Difference between accounts created and soft limit"
+// +kubebuilder:printcolumn:name="Surplus Accounts",type="integer",JSONPath=".status.surplusAccounts",description="Number of unclaimed accounts above the pool size",priority=1
 // +kubebuilder:resource:path=accountpools,scope=Namespaced
 type AccountPool struct {
 	metav1.TypeMeta   `json:",inline"`
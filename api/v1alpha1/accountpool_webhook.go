@@ -0,0 +1,144 @@
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+// SetupWebhookWithManager registers the AccountPool validating webhook with the manager
+func (a *AccountPool) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(a).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/validate-aws-managed-openshift-io-v1alpha1-accountpool,mutating=false,failurePolicy=fail,sideEffects=None,groups=aws.managed.openshift.io,resources=accountpools,verbs=create;update,versions=v1alpha1,name=vaccountpool.managed.openshift.io,admissionReviewVersions=v1
+
+var _ webhook.Validator = &AccountPool{}
+
+// ValidateCreate implements webhook.Validator
+func (a *AccountPool) ValidateCreate() error {
+	return toInvalidError("AccountPool", a.Name, a.validateSpec())
+}
+
+// ValidateUpdate implements webhook.Validator
+func (a *AccountPool) ValidateUpdate(old runtime.Object) error {
+	if a.DeletionTimestamp != nil {
+		return nil
+	}
+	return toInvalidError("AccountPool", a.Name, a.validateSpec())
+}
+
+// ValidateDelete implements webhook.Validator
+func (a *AccountPool) ValidateDelete() error {
+	return nil
+}
+
+// validateSpec runs the checks on the AccountPool spec
+func (a *AccountPool) validateSpec() field.ErrorList {
+	allErrs := field.ErrorList{}
+	if a.Spec.PoolSize < 0 {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "poolSize"), a.Spec.PoolSize, "must not be negative"))
+	}
+
+	templatePath := field.NewPath("spec", "accountTemplate")
+	template := a.Spec.AccountTemplate
+	if template.OrganizationalUnit != "" && !awsOUIDRegex.MatchString(template.OrganizationalUnit) {
+		allErrs = append(allErrs, field.Invalid(templatePath.Child("organizationalUnit"), template.OrganizationalUnit, "must be an OU ID, e.g. ou-ab12-cd34ef56"))
+	}
+	allErrs = append(allErrs, validateTags(template.ManagedTags, templatePath.Child("managedTags"))...)
+	for i, region := range template.Regions {
+		regionPath := templatePath.Child("regions").Index(i)
+		if !awsRegionRegex.MatchString(region.Name) {
+			allErrs = append(allErrs, field.Invalid(regionPath.Child("name"), region.Name, "must be an AWS region name, e.g. us-east-1"))
+		}
+		if region.Ami == "" {
+			allErrs = append(allErrs, field.Required(regionPath.Child("ami"), "the AMI used to initialize the region must be set"))
+		}
+		if region.InstanceType == "" {
+			allErrs = append(allErrs, field.Required(regionPath.Child("instanceType"), "the instance type used to initialize the region must be set"))
+		}
+	}
+
+	if a.Spec.MaxInFlight < 0 {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "maxInFlight"), a.Spec.MaxInFlight, "must not be negative"))
+	}
+	if a.Spec.ScaleDown != nil {
+		allErrs = append(allErrs, validateScaleDownPolicy(a.Spec.ScaleDown, field.NewPath("spec", "scaleDown"))...)
+	}
+	if a.Spec.Schedule != nil {
+		allErrs = append(allErrs, validatePoolSchedule(a.Spec.Schedule, field.NewPath("spec", "schedule"))...)
+	}
+	if a.Spec.Demand != nil {
+		allErrs = append(allErrs, validateDemandSizing(a.Spec.Demand, field.NewPath("spec", "demand"))...)
+	}
+	return allErrs
+}
+
+// validatePoolSchedule checks the time zone of the schedule and the days and times of its windows
+func validatePoolSchedule(schedule *PoolSchedule, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if _, err := schedule.Location(); err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("timeZone"), schedule.TimeZone, "must be an IANA time zone, e.g. America/New_York"))
+	}
+	names := map[string]bool{}
+	for i, window := range schedule.Windows {
+		windowPath := fldPath.Child("windows").Index(i)
+		if window.Name == "" {
+			allErrs = append(allErrs, field.Required(windowPath.Child("name"), "windows must be named"))
+		} else if names[window.Name] {
+			allErrs = append(allErrs, field.Duplicate(windowPath.Child("name"), window.Name))
+		}
+		names[window.Name] = true
+		if _, err := parseDays(window.Days); err != nil {
+			allErrs = append(allErrs, field.Invalid(windowPath.Child("days"), window.Days, err.Error()))
+		}
+		if _, err := parseClock(window.Start); err != nil {
+			allErrs = append(allErrs, field.Invalid(windowPath.Child("start"), window.Start, err.Error()))
+		}
+		if _, err := parseClock(window.End); err != nil {
+			allErrs = append(allErrs, field.Invalid(windowPath.Child("end"), window.End, err.Error()))
+		}
+		if window.PoolSize < 0 {
+			allErrs = append(allErrs, field.Invalid(windowPath.Child("poolSize"), window.PoolSize, "must not be negative"))
+		}
+	}
+	return allErrs
+}
+
+// validateDemandSizing checks that the durations and the maximum pool size of demand sizing aren't negative
+func validateDemandSizing(demand *DemandSizing, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if demand.LookbackPeriod.Duration < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("lookbackPeriod"), demand.LookbackPeriod.Duration.String(), "must not be negative"))
+	}
+	if demand.LeadTime.Duration < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("leadTime"), demand.LeadTime.Duration.String(), "must not be negative"))
+	}
+	if demand.MaxPoolSize < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("maxPoolSize"), demand.MaxPoolSize, "must not be negative"))
+	}
+	return allErrs
+}
+
+// validateScaleDownPolicy checks the action of the policy, and that only the Park action has a holding OU
+func validateScaleDownPolicy(policy *ScaleDownPolicy, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	switch policy.Action {
+	case ScaleDownRetain, ScaleDownClose:
+		if policy.HoldingOU != "" {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("holdingOU"), "only the Park action uses a holding OU"))
+		}
+	case ScaleDownPark:
+		if policy.HoldingOU == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("holdingOU"), "the Park action needs a holding OU"))
+		} else if !awsOUIDRegex.MatchString(policy.HoldingOU) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("holdingOU"), policy.HoldingOU, "must be an OU ID, e.g. ou-ab12-cd34ef56"))
+		}
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("action"), policy.Action, []string{string(ScaleDownRetain), string(ScaleDownClose), string(ScaleDownPark)}))
+	}
+	return allErrs
+}
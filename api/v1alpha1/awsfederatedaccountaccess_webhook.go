@@ -0,0 +1,56 @@
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+// SetupWebhookWithManager registers the AWSFederatedAccountAccess validating webhook with the manager
+func (a *AWSFederatedAccountAccess) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(a).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/validate-aws-managed-openshift-io-v1alpha1-awsfederatedaccountaccess,mutating=false,failurePolicy=fail,sideEffects=None,groups=aws.managed.openshift.io,resources=awsfederatedaccountaccesses,verbs=create;update,versions=v1alpha1,name=vawsfederatedaccountaccess.managed.openshift.io,admissionReviewVersions=v1
+
+var _ webhook.Validator = &AWSFederatedAccountAccess{}
+
+// ValidateCreate implements webhook.Validator
+func (a *AWSFederatedAccountAccess) ValidateCreate() error {
+	return toInvalidError("AWSFederatedAccountAccess", a.Name, a.validateSpec())
+}
+
+// ValidateUpdate implements webhook.Validator
+func (a *AWSFederatedAccountAccess) ValidateUpdate(old runtime.Object) error {
+	if a.DeletionTimestamp != nil {
+		return nil
+	}
+	return toInvalidError("AWSFederatedAccountAccess", a.Name, a.validateSpec())
+}
+
+// ValidateDelete implements webhook.Validator
+func (a *AWSFederatedAccountAccess) ValidateDelete() error {
+	return nil
+}
+
+// validateSpec checks the customer ARN and the references to the credentials secret and the federated role
+func (a *AWSFederatedAccountAccess) validateSpec() field.ErrorList {
+	specPath := field.NewPath("spec")
+	allErrs := field.ErrorList{}
+
+	if !iamPrincipalARNRegex.MatchString(a.Spec.ExternalCustomerAWSIAMARN) {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("externalCustomerAWSIAMARN"), a.Spec.ExternalCustomerAWSIAMARN,
+			"must be an IAM user, role or account root ARN, e.g. arn:aws:iam::123456789012:user/name"))
+	}
+	if a.Spec.AWSCustomerCredentialSecret.Name == "" || a.Spec.AWSCustomerCredentialSecret.Namespace == "" {
+		allErrs = append(allErrs, field.Required(specPath.Child("awsCustomerCredentialSecret"), "name and namespace must be set"))
+	}
+	if a.Spec.AWSFederatedRole.Name == "" || a.Spec.AWSFederatedRole.Namespace == "" {
+		allErrs = append(allErrs, field.Required(specPath.Child("awsFederatedRole"), "name and namespace must be set"))
+	}
+
+	return allErrs
+}
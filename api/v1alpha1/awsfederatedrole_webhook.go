@@ -0,0 +1,74 @@
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+// SetupWebhookWithManager registers the AWSFederatedRole validating webhook with the manager
+func (a *AWSFederatedRole) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(a).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/validate-aws-managed-openshift-io-v1alpha1-awsfederatedrole,mutating=false,failurePolicy=fail,sideEffects=None,groups=aws.managed.openshift.io,resources=awsfederatedroles,verbs=create;update,versions=v1alpha1,name=vawsfederatedrole.managed.openshift.io,admissionReviewVersions=v1
+
+var _ webhook.Validator = &AWSFederatedRole{}
+
+// ValidateCreate implements webhook.Validator
+func (a *AWSFederatedRole) ValidateCreate() error {
+	return toInvalidError("AWSFederatedRole", a.Name, a.validateSpec())
+}
+
+// ValidateUpdate implements webhook.Validator
+func (a *AWSFederatedRole) ValidateUpdate(old runtime.Object) error {
+	if a.DeletionTimestamp != nil {
+		return nil
+	}
+	return toInvalidError("AWSFederatedRole", a.Name, a.validateSpec())
+}
+
+// ValidateDelete implements webhook.Validator
+func (a *AWSFederatedRole) ValidateDelete() error {
+	return nil
+}
+
+// validateSpec checks that the role has a name and that the policies can be created in AWS
+func (a *AWSFederatedRole) validateSpec() field.ErrorList {
+	specPath := field.NewPath("spec")
+	allErrs := field.ErrorList{}
+
+	if a.Spec.RoleDisplayName == "" {
+		allErrs = append(allErrs, field.Required(specPath.Child("roleDisplayName"), ""))
+	}
+	if a.Spec.AWSCustomPolicy.Name == "" && len(a.Spec.AWSManagedPolicies) == 0 {
+		allErrs = append(allErrs, field.Required(specPath, "at least one of awsCustomPolicy or awsManagedPolicies must be set"))
+	}
+	for i, policy := range a.Spec.AWSManagedPolicies {
+		if policy == "" {
+			allErrs = append(allErrs, field.Required(specPath.Child("awsManagedPolicies").Index(i), ""))
+		}
+	}
+
+	// Statements are only used when a custom policy is set
+	if a.Spec.AWSCustomPolicy.Name == "" {
+		return allErrs
+	}
+	statementsPath := specPath.Child("awsCustomPolicy", "awsStatements")
+	if len(a.Spec.AWSCustomPolicy.Statements) == 0 {
+		allErrs = append(allErrs, field.Required(statementsPath, "a custom policy needs at least one statement"))
+	}
+	for i, statement := range a.Spec.AWSCustomPolicy.Statements {
+		if statement.Effect != "Allow" && statement.Effect != "Deny" {
+			allErrs = append(allErrs, field.NotSupported(statementsPath.Index(i).Child("effect"), statement.Effect, []string{"Allow", "Deny"}))
+		}
+		if len(statement.Action) == 0 {
+			allErrs = append(allErrs, field.Required(statementsPath.Index(i).Child("action"), ""))
+		}
+	}
+
+	return allErrs
+}
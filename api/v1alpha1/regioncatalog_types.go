@@ -0,0 +1,178 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// AWS partitions the regions of a RegionCatalog belong to
+const (
+	PartitionAWS      = "aws"
+	PartitionAWSUSGov = "aws-us-gov"
+	PartitionAWSCN    = "aws-cn"
+)
+
+// ImageArchitecture is the processor architecture of an AMI
+type ImageArchitecture string
+
+const (
+	// ArchitectureX8664 is the architecture of the AMIs of x86_64 instance types
+	ArchitectureX8664 ImageArchitecture = "x86_64"
+	// ArchitectureArm64 is the architecture of the AMIs of arm64 (Graviton) instance types
+	ArchitectureArm64 ImageArchitecture = "arm64"
+)
+
+// RegionCatalogSpec defines the regions new accounts are initialized in
+// +k8s:openapi-gen=true
+type RegionCatalogSpec struct {
+	// Regions of the catalog
+	Regions []CatalogRegion `json:"regions"`
+}
+
+// CatalogRegion is a region with the AMIs and instance type it's initialized with
+type CatalogRegion struct {
+	// Name of the region, e.g. us-east-1
+	Name string `json:"name"`
+
+	// Partition of the region, aws, aws-us-gov or aws-cn. Defaults to aws.
+	// +optional
+	// +kubebuilder:validation:Enum=aws;aws-us-gov;aws-cn
+	Partition string `json:"partition,omitempty"`
+
+	// Enabled regions are initialized in new accounts. Defaults to true.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// InstanceType of the EC2 instance the region is initialized with
+	InstanceType string `json:"instanceType"`
+
+	// Architecture of the instance type, which picks the AMI the region is initialized with.
+	// Defaults to x86_64.
+	// +optional
+	// +kubebuilder:validation:Enum=x86_64;arm64
+	Architecture ImageArchitecture `json:"architecture,omitempty"`
+
+	// AMIs of the region, one per architecture
+	AMIs []ArchitectureAMI `json:"amis"`
+}
+
+// ArchitectureAMI is the AMI of a region for an architecture
+type ArchitectureAMI struct {
+	// Architecture of the AMI
+	// +kubebuilder:validation:Enum=x86_64;arm64
+	Architecture ImageArchitecture `json:"architecture"`
+
+	// ID of the AMI, e.g. ami-0123456789abcdef0
+	ID string `json:"id"`
+}
+
+// RegionCatalogStatus defines the observed state of RegionCatalog
+// +k8s:openapi-gen=true
+type RegionCatalogStatus struct {
+	// AMIs holds the outcome of the last verification of every AMI of the catalog
+	// +optional
+	AMIs []CatalogAMIStatus `json:"amis,omitempty"`
+
+	// LastVerificationTime is when the AMIs of the catalog were last verified
+	// +optional
+	LastVerificationTime *metav1.Time `json:"lastVerificationTime,omitempty"`
+
+	// ObservedGeneration is the generation of the spec the AMIs were last verified for
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+// CatalogAMIStatus is the outcome of the last verification of an AMI of the catalog
+type CatalogAMIStatus struct {
+	// Region of the AMI
+	Region string `json:"region"`
+
+	// Architecture of the AMI
+	Architecture ImageArchitecture `json:"architecture"`
+
+	// ID of the AMI
+	ID string `json:"id"`
+
+	// Exists is whether DescribeImages found the AMI when it was last verified
+	Exists bool `json:"exists"`
+
+	// LastVerifiedTime is when DescribeImages last answered for the AMI
+	// +optional
+	LastVerifiedTime *metav1.Time `json:"lastVerifiedTime,omitempty"`
+
+	// Message holds the error of the last verification, if any
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +kubebuilder:object:root=true
+
+// RegionCatalog is the Schema for the regioncatalogs API
+// +k8s:openapi-gen=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Last Verification",type="date",JSONPath=".status.lastVerificationTime",description="When the AMIs of the catalog were last verified"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="Age since the catalog was created"
+// +kubebuilder:resource:path=regioncatalogs,scope=Cluster
+type RegionCatalog struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RegionCatalogSpec   `json:"spec,omitempty"`
+	Status RegionCatalogStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// RegionCatalogList contains a list of RegionCatalog
+type RegionCatalogList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RegionCatalog `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&RegionCatalog{}, &RegionCatalogList{})
+}
+
+// IsEnabled returns whether the region is initialized in new accounts
+func (r *CatalogRegion) IsEnabled() bool {
+	return r.Enabled == nil || *r.Enabled
+}
+
+// GetPartition returns the partition of the region, aws when it's not set
+func (r *CatalogRegion) GetPartition() string {
+	if r.Partition == "" {
+		return PartitionAWS
+	}
+	return r.Partition
+}
+
+// GetArchitecture returns the architecture of the instance type of the region, x86_64 when it's not set
+func (r *CatalogRegion) GetArchitecture() ImageArchitecture {
+	if r.Architecture == "" {
+		return ArchitectureX8664
+	}
+	return r.Architecture
+}
+
+// GetAMI returns the ID of the AMI of the region for the architecture, or "" when there is none
+func (r *CatalogRegion) GetAMI(architecture ImageArchitecture) string {
+	for _, ami := range r.AMIs {
+		if ami.Architecture == architecture {
+			return ami.ID
+		}
+	}
+	return ""
+}
+
+// GetAMIStatus returns the outcome of the last verification of the AMI of the region, or nil when it
+// hasn't been verified
+func (c *RegionCatalog) GetAMIStatus(region, amiID string) *CatalogAMIStatus {
+	for i := range c.Status.AMIs {
+		if c.Status.AMIs[i].Region == region && c.Status.AMIs[i].ID == amiID {
+			return &c.Status.AMIs[i]
+		}
+	}
+	return nil
+}
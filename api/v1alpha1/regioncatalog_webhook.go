@@ -0,0 +1,101 @@
+package v1alpha1
+
+import (
+	"regexp"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+// amiIDRegex matches the ID of an AMI
+var amiIDRegex = regexp.MustCompile(`^ami-([0-9a-f]{8}|[0-9a-f]{17})$`)
+
+// SetupWebhookWithManager registers the RegionCatalog validating webhook with the manager
+func (c *RegionCatalog) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(c).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/validate-aws-managed-openshift-io-v1alpha1-regioncatalog,mutating=false,failurePolicy=fail,sideEffects=None,groups=aws.managed.openshift.io,resources=regioncatalogs,verbs=create;update,versions=v1alpha1,name=vregioncatalog.managed.openshift.io,admissionReviewVersions=v1
+
+var _ webhook.Validator = &RegionCatalog{}
+
+// ValidateCreate implements webhook.Validator
+func (c *RegionCatalog) ValidateCreate() error {
+	return toInvalidError("RegionCatalog", c.Name, c.validateSpec())
+}
+
+// ValidateUpdate implements webhook.Validator
+func (c *RegionCatalog) ValidateUpdate(old runtime.Object) error {
+	if c.DeletionTimestamp != nil {
+		return nil
+	}
+	return toInvalidError("RegionCatalog", c.Name, c.validateSpec())
+}
+
+// ValidateDelete implements webhook.Validator
+func (c *RegionCatalog) ValidateDelete() error {
+	return nil
+}
+
+// validateSpec checks the regions of the catalog, and that each has an AMI for the architecture
+// of its instance type
+func (c *RegionCatalog) validateSpec() field.ErrorList {
+	regionsPath := field.NewPath("spec", "regions")
+	allErrs := field.ErrorList{}
+	if len(c.Spec.Regions) == 0 {
+		return append(allErrs, field.Required(regionsPath, "at least one region must be set"))
+	}
+
+	names := map[string]bool{}
+	for i, region := range c.Spec.Regions {
+		regionPath := regionsPath.Index(i)
+		if !awsRegionRegex.MatchString(region.Name) {
+			allErrs = append(allErrs, field.Invalid(regionPath.Child("name"), region.Name, "must be an AWS region name, e.g. us-east-1"))
+		} else if partition := regionPartition(region.Name); partition != region.GetPartition() {
+			allErrs = append(allErrs, field.Invalid(regionPath.Child("partition"), region.Partition, "region "+region.Name+" is in the "+partition+" partition"))
+		}
+		if names[region.Name] {
+			allErrs = append(allErrs, field.Duplicate(regionPath.Child("name"), region.Name))
+		}
+		names[region.Name] = true
+		if region.InstanceType == "" {
+			allErrs = append(allErrs, field.Required(regionPath.Child("instanceType"), "the instance type used to initialize the region must be set"))
+		}
+
+		architectures := map[ImageArchitecture]bool{}
+		for j, ami := range region.AMIs {
+			amiPath := regionPath.Child("amis").Index(j)
+			if ami.Architecture != ArchitectureX8664 && ami.Architecture != ArchitectureArm64 {
+				allErrs = append(allErrs, field.NotSupported(amiPath.Child("architecture"), ami.Architecture, []string{string(ArchitectureX8664), string(ArchitectureArm64)}))
+			}
+			if architectures[ami.Architecture] {
+				allErrs = append(allErrs, field.Duplicate(amiPath.Child("architecture"), ami.Architecture))
+			}
+			architectures[ami.Architecture] = true
+			if !amiIDRegex.MatchString(ami.ID) {
+				allErrs = append(allErrs, field.Invalid(amiPath.Child("id"), ami.ID, "must be an AMI ID, e.g. ami-0123456789abcdef0"))
+			}
+		}
+		if !architectures[region.GetArchitecture()] {
+			allErrs = append(allErrs, field.Required(regionPath.Child("amis"), "an AMI for the "+string(region.GetArchitecture())+" architecture of the region must be set"))
+		}
+	}
+	return allErrs
+}
+
+// regionPartition returns the partition a region is in from its name
+func regionPartition(region string) string {
+	switch {
+	case strings.HasPrefix(region, "us-gov-"):
+		return PartitionAWSUSGov
+	case strings.HasPrefix(region, "cn-"):
+		return PartitionAWSCN
+	default:
+		return PartitionAWS
+	}
+}
@@ -86,6 +86,15 @@ var ErrFailedToCreateSubnet = errors.New("FailedToCreateSubnet")
 // ErrFailedToDeleteSubnet indicates that there was a failure while trying to delete subnet
 var ErrFailedToDeleteSubnet = errors.New("FailedToDeleteSubnet")
 
+// ErrAccountReuseCleanupFailed indicates that at least one cleaner failed while cleaning up an account for reuse
+var ErrAccountReuseCleanupFailed = errors.New("AccountReuseCleanupFailed")
+
+// ErrAccountReuseCleanupPending indicates that resources of the account wait on AWS to finish deleting what they depend on
+var ErrAccountReuseCleanupPending = errors.New("AccountReuseCleanupPending")
+
+// ErrAccountReuseCleanupDryRun indicates that the reuse cleanup only listed resources and the account was not reset
+var ErrAccountReuseCleanupDryRun = errors.New("AccountReuseCleanupDryRun")
+
 // Shared variables
 
 // UIDLabel is the string for the uid label on AWS Federated Account Access CRs
@@ -113,6 +122,23 @@ var EC2InstanceNameTagValue = "red-hat-region-init"
 // IAMUserIDLabel label key for IAM user suffix
 var IAMUserIDLabel = "iamUserId"
 
+// AccountPoolLabel label key for the name of the AccountPool that created an Account
+var AccountPoolLabel = "accountPool"
+
+// RetireAnnotation is set on the surplus Accounts an AccountPool deletes to scale down. Its value is
+// the ScaleDownAction the Account finalizer applies to the AWS account.
+var RetireAnnotation = "aws.managed.openshift.io/retire"
+
+// HoldingOUAnnotation holds the ID of the OU a retired Account is parked in
+var HoldingOUAnnotation = "aws.managed.openshift.io/holding-ou"
+
+// ForceRetryAnnotation set on a failed Account retries it immediately, whatever its retry budget and failure reason
+var ForceRetryAnnotation = "aws.managed.openshift.io/force-retry"
+
+// CaseBatchOwnerAnnotation holds the name of the Account that opens the support case of the batch an
+// Account was assigned to
+var CaseBatchOwnerAnnotation = "aws.managed.openshift.io/case-batch-owner"
+
 // EmailID is the ID used for prefixing Account CR names
 var EmailID = "osd-creds-mgmt"
 
@@ -0,0 +1,120 @@
+package v1alpha1
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+const (
+	// maxTagKeyLength is the longest tag key AWS accepts
+	maxTagKeyLength = 128
+	// maxTagValueLength is the longest tag value AWS accepts
+	maxTagValueLength = 256
+	// awsReservedTagPrefix is reserved by AWS and can't be used in user tags
+	awsReservedTagPrefix = "aws:"
+)
+
+var (
+	// awsAccountIDRegex matches a 12 digit AWS account ID
+	awsAccountIDRegex = regexp.MustCompile(`^[0-9]{12}$`)
+	// awsOUIDRegex matches the ID of an organizational unit
+	awsOUIDRegex = regexp.MustCompile(`^ou-[0-9a-z]{4,32}-[a-z0-9]{8,32}$`)
+	// awsRegionRegex matches region names such as us-east-1, ap-southeast-2 or us-gov-west-1
+	awsRegionRegex = regexp.MustCompile(`^[a-z]{2}(-[a-z]+)+-[0-9]+$`)
+	// iamARNRegex matches the ARN of an IAM user or role, in any partition
+	iamARNRegex = regexp.MustCompile(`^arn:aws(-[a-z]+)*:iam::[0-9]{12}:(role|user)/[\w+=,.@/-]+$`)
+	// iamPrincipalARNRegex matches the ARN of an IAM user, role or account root
+	iamPrincipalARNRegex = regexp.MustCompile(`^arn:aws(-[a-z]+)*:iam::[0-9]{12}:(root|(role|user)/[\w+=,.@/-]+)$`)
+	// tagCharactersRegex matches the characters AWS allows in tag keys and values
+	tagCharactersRegex = regexp.MustCompile(`^[\p{L}\p{Z}\p{N}_.:/=+\-@]*$`)
+)
+
+// validateAWSAccountID checks that id is a 12 digit AWS account ID
+func validateAWSAccountID(id string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if !awsAccountIDRegex.MatchString(id) {
+		allErrs = append(allErrs, field.Invalid(fldPath, id, "must be a 12 digit AWS account ID"))
+	}
+	return allErrs
+}
+
+// validateRegions checks that at least one region is set and that every region name looks like an AWS region
+func validateRegions(regions []AwsRegions, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if len(regions) == 0 {
+		return append(allErrs, field.Required(fldPath, "at least one region must be set"))
+	}
+	for i, region := range regions {
+		if !awsRegionRegex.MatchString(region.Name) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i).Child("name"), region.Name, "must be an AWS region name, e.g. us-east-1"))
+		}
+	}
+	return allErrs
+}
+
+// validateIAMARN checks that arn is the ARN of an IAM user or role
+func validateIAMARN(arn string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if !iamARNRegex.MatchString(arn) {
+		allErrs = append(allErrs, field.Invalid(fldPath, arn, "must be an IAM role or user ARN, e.g. arn:aws:iam::123456789012:role/name"))
+	}
+	return allErrs
+}
+
+// validateTags checks tags written one key=value pair per line, the format accepted in
+// AccountClaim.Spec.CustomTags and the managed tags of the operator configmap
+func validateTags(tags string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	keys := map[string]bool{}
+	for i, line := range strings.Split(tags, "\n") {
+		// Empty lines are skipped when the tags are parsed
+		if line == "" {
+			continue
+		}
+		kv := strings.SplitN(line, "=", 2)
+		if len(kv) != 2 {
+			allErrs = append(allErrs, field.Invalid(fldPath, line, fmt.Sprintf("line %d must be a key=value pair", i+1)))
+			continue
+		}
+		key, value := kv[0], kv[1]
+		switch {
+		case key == "" || len(key) > maxTagKeyLength:
+			allErrs = append(allErrs, field.Invalid(fldPath, line, fmt.Sprintf("line %d: tag keys must be 1 to %d characters long", i+1, maxTagKeyLength)))
+		case strings.HasPrefix(strings.ToLower(key), awsReservedTagPrefix):
+			allErrs = append(allErrs, field.Invalid(fldPath, line, fmt.Sprintf("line %d: tag keys can't start with %q", i+1, awsReservedTagPrefix)))
+		case !tagCharactersRegex.MatchString(key):
+			allErrs = append(allErrs, field.Invalid(fldPath, line, fmt.Sprintf("line %d: tag key has characters AWS doesn't allow", i+1)))
+		case keys[key]:
+			allErrs = append(allErrs, field.Duplicate(fldPath, key))
+		}
+		keys[key] = true
+		if len(value) > maxTagValueLength {
+			allErrs = append(allErrs, field.Invalid(fldPath, line, fmt.Sprintf("line %d: tag values can't be longer than %d characters", i+1, maxTagValueLength)))
+		} else if !tagCharactersRegex.MatchString(value) {
+			allErrs = append(allErrs, field.Invalid(fldPath, line, fmt.Sprintf("line %d: tag value has characters AWS doesn't allow", i+1)))
+		}
+	}
+	return allErrs
+}
+
+// validateImmutable rejects changing a field once it has been set
+func validateImmutable(newValue, oldValue string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if oldValue != "" && newValue != oldValue {
+		allErrs = append(allErrs, field.Invalid(fldPath, newValue, "field is immutable once set"))
+	}
+	return allErrs
+}
+
+// toInvalidError turns the field errors found on an object into the error returned by its webhook
+func toInvalidError(kind, name string, allErrs field.ErrorList) error {
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(schema.GroupKind{Group: GroupVersion.Group, Kind: kind}, name, allErrs)
+}
@@ -0,0 +1,482 @@
+package v1alpha1
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func validAccountClaim() *AccountClaim {
+	return &AccountClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: "claim", Namespace: "claim-ns"},
+		Spec: AccountClaimSpec{
+			Aws: Aws{Regions: []AwsRegions{{Name: "us-east-1"}}},
+		},
+	}
+}
+
+func TestAccountClaimValidateCreate(t *testing.T) {
+	tests := []struct {
+		name        string
+		mutate      func(a *AccountClaim)
+		expectedErr string
+	}{
+		{
+			name:   "Testing valid non-ccs",
+			mutate: func(a *AccountClaim) {},
+		},
+		{
+			name: "Testing valid STS",
+			mutate: func(a *AccountClaim) {
+				a.Spec.ManualSTSMode = true
+				a.Spec.STSRoleARN = "arn:aws:iam::123456789012:role/installer"
+				a.Spec.CustomTags = "test=true\nmy-cluster=yes\n"
+			},
+		},
+		{
+			name:        "Testing missing regions",
+			mutate:      func(a *AccountClaim) { a.Spec.Aws.Regions = nil },
+			expectedErr: "spec.aws.regions: Required value",
+		},
+		{
+			name:        "Testing invalid region",
+			mutate:      func(a *AccountClaim) { a.Spec.Aws.Regions[0].Name = "moon-base-1a" },
+			expectedErr: "spec.aws.regions[0].name: Invalid value",
+		},
+		{
+			name:        "Testing Validate errors are reported on their field",
+			mutate:      func(a *AccountClaim) { a.Spec.ManualSTSMode = true },
+			expectedErr: "spec.stsRoleARN: Required value: STSRoleARNMissing",
+		},
+		{
+			name: "Testing malformed STS role ARN",
+			mutate: func(a *AccountClaim) {
+				a.Spec.ManualSTSMode = true
+				a.Spec.STSRoleARN = "arn:aws:whatever:something:role/whomever"
+			},
+			expectedErr: "spec.stsRoleARN: Invalid value",
+		},
+		{
+			name:        "Testing malformed BYOC account ID",
+			mutate:      func(a *AccountClaim) { a.Spec.BYOCAWSAccountID = "123456789" },
+			expectedErr: "spec.byocAWSAccountID: Invalid value",
+		},
+		{
+			name:        "Testing custom tag without a value",
+			mutate:      func(a *AccountClaim) { a.Spec.CustomTags = "test=true\nbroken" },
+			expectedErr: "line 2 must be a key=value pair",
+		},
+		{
+			name: "Testing pool selector",
+			mutate: func(a *AccountClaim) {
+				a.Spec.AccountPoolSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"environment": "fedramp"}}
+			},
+		},
+		{
+			name: "Testing pool name and selector",
+			mutate: func(a *AccountClaim) {
+				a.Spec.AccountPool = "fedramp"
+				a.Spec.AccountPoolSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"environment": "fedramp"}}
+			},
+			expectedErr: "spec.accountPoolSelector: Forbidden",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			claim := validAccountClaim()
+			test.mutate(claim)
+			checkWebhookError(t, claim.ValidateCreate(), test.expectedErr)
+		})
+	}
+}
+
+func TestAccountClaimValidateUpdate(t *testing.T) {
+	tests := []struct {
+		name        string
+		old         func(a *AccountClaim)
+		new         func(a *AccountClaim)
+		expectedErr string
+	}{
+		{
+			name: "Testing accountLink can be set",
+			old:  func(a *AccountClaim) {},
+			new:  func(a *AccountClaim) { a.Spec.AccountLink = "osd-creds-mgmt-aaabbb" },
+		},
+		{
+			name:        "Testing accountLink can't be changed",
+			old:         func(a *AccountClaim) { a.Spec.AccountLink = "osd-creds-mgmt-aaabbb" },
+			new:         func(a *AccountClaim) { a.Spec.AccountLink = "osd-creds-mgmt-cccddd" },
+			expectedErr: "spec.accountLink: Invalid value",
+		},
+		{
+			name:        "Testing byocAWSAccountID can't be changed",
+			old:         func(a *AccountClaim) { a.Spec.BYOCAWSAccountID = "123456789012" },
+			new:         func(a *AccountClaim) { a.Spec.BYOCAWSAccountID = "210987654321" },
+			expectedErr: "spec.byocAWSAccountID: Invalid value",
+		},
+		{
+			name: "Testing unchanged invalid spec is let through",
+			old:  func(a *AccountClaim) { a.Spec.Aws.Regions = nil },
+			new: func(a *AccountClaim) {
+				a.Spec.Aws.Regions = nil
+				a.Finalizers = []string{"finalizer.aws.managed.openshift.io"}
+			},
+		},
+		{
+			name: "Testing accountLink can be set on claims without regions",
+			old: func(a *AccountClaim) {
+				a.Spec.Aws.Regions = nil
+				a.Spec.CustomTags = "aws:created-before-the-webhook=true"
+			},
+			new: func(a *AccountClaim) {
+				a.Spec.Aws.Regions = nil
+				a.Spec.CustomTags = "aws:created-before-the-webhook=true"
+				a.Spec.AccountLink = "osd-creds-mgmt-aaabbb"
+			},
+		},
+		{
+			name: "Testing changed fields are checked",
+			old:  func(a *AccountClaim) { a.Spec.Aws.Regions = nil },
+			new: func(a *AccountClaim) {
+				a.Spec.Aws.Regions = nil
+				a.Spec.CustomTags = "key"
+			},
+			expectedErr: "spec.customTags: Invalid value",
+		},
+		{
+			name: "Testing claims being deleted are let through",
+			old:  func(a *AccountClaim) { a.Spec.AccountLink = "osd-creds-mgmt-aaabbb" },
+			new: func(a *AccountClaim) {
+				a.Spec.AccountLink = ""
+				a.DeletionTimestamp = &metav1.Time{}
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			oldClaim, newClaim := validAccountClaim(), validAccountClaim()
+			test.old(oldClaim)
+			test.new(newClaim)
+			checkWebhookError(t, newClaim.ValidateUpdate(oldClaim), test.expectedErr)
+		})
+	}
+}
+
+func TestAccountValidateUpdate(t *testing.T) {
+	oldAccount := &Account{Spec: AccountSpec{}}
+	newAccount := &Account{Spec: AccountSpec{AwsAccountID: "123456789012"}}
+	checkWebhookError(t, newAccount.ValidateUpdate(oldAccount), "")
+
+	changedAccount := &Account{Spec: AccountSpec{AwsAccountID: "210987654321"}}
+	checkWebhookError(t, changedAccount.ValidateUpdate(newAccount), "spec.awsAccountID: Invalid value")
+}
+
+func TestAccountPoolValidateCreate(t *testing.T) {
+	pool := &AccountPool{
+		Spec: AccountPoolSpec{
+			PoolSize: 5,
+			AccountTemplate: AccountTemplate{
+				OrganizationalUnit: "ou-ab12-cd34ef56",
+				ManagedTags:        "environment=fedramp",
+				Regions:            []RegionAMI{{Name: "us-gov-east-1", Ami: "ami-0123456789", InstanceType: "t3.micro"}},
+			},
+		},
+	}
+	checkWebhookError(t, pool.ValidateCreate(), "")
+
+	pool.Spec.AccountTemplate.OrganizationalUnit = "r-ab12"
+	checkWebhookError(t, pool.ValidateCreate(), "spec.accountTemplate.organizationalUnit: Invalid value")
+
+	pool.Spec.AccountTemplate.OrganizationalUnit = ""
+	pool.Spec.AccountTemplate.Regions[0].Ami = ""
+	checkWebhookError(t, pool.ValidateCreate(), "spec.accountTemplate.regions[0].ami: Required value")
+}
+
+func TestAccountPoolValidateScaleDown(t *testing.T) {
+	pool := &AccountPool{
+		Spec: AccountPoolSpec{
+			PoolSize:    5,
+			MaxInFlight: 2,
+			ScaleDown:   &ScaleDownPolicy{Action: ScaleDownPark, HoldingOU: "ou-ab12-cd34ef56"},
+		},
+	}
+	checkWebhookError(t, pool.ValidateCreate(), "")
+
+	pool.Spec.ScaleDown.HoldingOU = ""
+	checkWebhookError(t, pool.ValidateCreate(), "spec.scaleDown.holdingOU: Required value")
+
+	pool.Spec.ScaleDown = &ScaleDownPolicy{Action: ScaleDownClose, HoldingOU: "ou-ab12-cd34ef56"}
+	checkWebhookError(t, pool.ValidateCreate(), "spec.scaleDown.holdingOU: Forbidden")
+
+	pool.Spec.ScaleDown = &ScaleDownPolicy{Action: "Delete"}
+	checkWebhookError(t, pool.ValidateCreate(), "spec.scaleDown.action: Unsupported value")
+
+	pool.Spec.ScaleDown = nil
+	pool.Spec.MaxInFlight = -1
+	checkWebhookError(t, pool.ValidateCreate(), "spec.maxInFlight: Invalid value")
+}
+
+func TestAccountPoolValidateSchedule(t *testing.T) {
+	pool := &AccountPool{
+		Spec: AccountPoolSpec{
+			PoolSize: 5,
+			Schedule: &PoolSchedule{
+				TimeZone: "America/New_York",
+				Windows:  []PoolSizeWindow{{Name: "business-hours", Days: "Mon-Fri", Start: "08:00", End: "18:00", PoolSize: 20}},
+			},
+			Demand: &DemandSizing{MaxPoolSize: 50},
+		},
+	}
+	checkWebhookError(t, pool.ValidateCreate(), "")
+
+	pool.Spec.Schedule.TimeZone = "Mars/Olympus_Mons"
+	checkWebhookError(t, pool.ValidateCreate(), "spec.schedule.timeZone: Invalid value")
+
+	pool.Spec.Schedule.TimeZone = ""
+	pool.Spec.Schedule.Windows = append(pool.Spec.Schedule.Windows, PoolSizeWindow{Name: "business-hours", Start: "08:00", End: "18:00"})
+	checkWebhookError(t, pool.ValidateCreate(), "spec.schedule.windows[1].name: Duplicate value")
+
+	pool.Spec.Schedule.Windows = []PoolSizeWindow{{Name: "weekend", Days: "Sat-Sunday", Start: "8am", End: "18:00"}}
+	checkWebhookError(t, pool.ValidateCreate(), "spec.schedule.windows[0].days: Invalid value")
+	checkWebhookError(t, pool.ValidateCreate(), "spec.schedule.windows[0].start: Invalid value")
+
+	pool.Spec.Schedule = nil
+	pool.Spec.Demand.LeadTime = metav1.Duration{Duration: -time.Hour}
+	checkWebhookError(t, pool.ValidateCreate(), "spec.demand.leadTime: Invalid value")
+}
+
+func TestPoolSizeWindowIsActive(t *testing.T) {
+	// 2022-06-03 is a Friday
+	friday := func(clock string) time.Time {
+		parsed, _ := time.Parse("2006-01-02 15:04", "2022-06-03 "+clock)
+		return parsed
+	}
+	tests := []struct {
+		name     string
+		window   PoolSizeWindow
+		t        time.Time
+		expected bool
+	}{
+		{
+			name:     "Active within the window",
+			window:   PoolSizeWindow{Days: "Mon-Fri", Start: "08:00", End: "18:00"},
+			t:        friday("12:00"),
+			expected: true,
+		},
+		{
+			name:     "Inactive at the end of the window",
+			window:   PoolSizeWindow{Days: "Mon-Fri", Start: "08:00", End: "18:00"},
+			t:        friday("18:00"),
+			expected: false,
+		},
+		{
+			name:     "Inactive on other days",
+			window:   PoolSizeWindow{Days: "Sat,Sun", Start: "08:00", End: "18:00"},
+			t:        friday("12:00"),
+			expected: false,
+		},
+		{
+			name:     "Day ranges wrap around the week",
+			window:   PoolSizeWindow{Days: "Fri-Mon", Start: "08:00", End: "18:00"},
+			t:        friday("12:00"),
+			expected: true,
+		},
+		{
+			name:     "Overnight windows are active the morning after their days",
+			window:   PoolSizeWindow{Days: "Thu", Start: "22:00", End: "06:00"},
+			t:        friday("05:00"),
+			expected: true,
+		},
+		{
+			name:     "Overnight windows aren't active the morning of their days",
+			window:   PoolSizeWindow{Days: "Fri", Start: "22:00", End: "06:00"},
+			t:        friday("05:00"),
+			expected: false,
+		},
+		{
+			name:     "Windows starting and ending at the same time last all day",
+			window:   PoolSizeWindow{Start: "00:00", End: "00:00"},
+			t:        friday("23:59"),
+			expected: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			active, err := test.window.IsActive(test.t)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if active != test.expected {
+				t.Errorf("expected active %t, got %t", test.expected, active)
+			}
+		})
+	}
+}
+
+func TestPoolScheduleNextTransition(t *testing.T) {
+	schedule := &PoolSchedule{
+		Windows: []PoolSizeWindow{{Name: "business-hours", Start: "08:00", End: "18:00"}},
+	}
+	now := time.Date(2022, 6, 3, 12, 0, 0, 0, time.UTC)
+	next, err := schedule.NextTransition(now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := time.Date(2022, 6, 3, 18, 0, 0, 0, time.UTC); !next.Equal(expected) {
+		t.Errorf("expected %v, got %v", expected, next)
+	}
+
+	next, _ = schedule.NextTransition(now.Add(8 * time.Hour))
+	if expected := time.Date(2022, 6, 4, 8, 0, 0, 0, time.UTC); !next.Equal(expected) {
+		t.Errorf("expected %v, got %v", expected, next)
+	}
+}
+
+func TestAWSFederatedRoleValidateCreate(t *testing.T) {
+	role := &AWSFederatedRole{
+		Spec: AWSFederatedRoleSpec{
+			RoleDisplayName: "Network management",
+			AWSCustomPolicy: AWSCustomPolicy{
+				Name:       "CustomerAdministratorAccess",
+				Statements: []StatementEntry{{Effect: "Allow", Action: []string{"ec2:CreateRoute"}}},
+			},
+		},
+	}
+	checkWebhookError(t, role.ValidateCreate(), "")
+
+	role.Spec.AWSCustomPolicy.Statements[0].Effect = "allow"
+	checkWebhookError(t, role.ValidateCreate(), "spec.awsCustomPolicy.awsStatements[0].effect: Unsupported value")
+}
+
+func TestAWSFederatedAccountAccessValidateCreate(t *testing.T) {
+	access := &AWSFederatedAccountAccess{
+		Spec: AWSFederatedAccountAccessSpec{
+			ExternalCustomerAWSIAMARN:   "arn:aws:iam::123456789012:user/customer",
+			AWSCustomerCredentialSecret: AWSSecretReference{Name: "secret", Namespace: "ns"},
+			AWSFederatedRole:            AWSFederatedRoleRef{Name: "read-only", Namespace: "aws-account-operator"},
+		},
+	}
+	checkWebhookError(t, access.ValidateCreate(), "")
+
+	access.Spec.ExternalCustomerAWSIAMARN = "customer"
+	checkWebhookError(t, access.ValidateCreate(), "spec.externalCustomerAWSIAMARN: Invalid value")
+}
+
+func TestRegionCatalogValidateCreate(t *testing.T) {
+	tests := []struct {
+		name        string
+		mutate      func(c *RegionCatalog)
+		expectedErr string
+	}{
+		{
+			name:   "Testing valid catalog",
+			mutate: func(c *RegionCatalog) {},
+		},
+		{
+			name:        "Testing missing regions",
+			mutate:      func(c *RegionCatalog) { c.Spec.Regions = nil },
+			expectedErr: "spec.regions: Required value",
+		},
+		{
+			name: "Testing duplicate region",
+			mutate: func(c *RegionCatalog) {
+				c.Spec.Regions = append(c.Spec.Regions, c.Spec.Regions[0])
+			},
+			expectedErr: "spec.regions[2].name: Duplicate value",
+		},
+		{
+			name:        "Testing region outside of its partition",
+			mutate:      func(c *RegionCatalog) { c.Spec.Regions[0].Partition = PartitionAWSUSGov },
+			expectedErr: "spec.regions[0].partition: Invalid value",
+		},
+		{
+			name:        "Testing malformed AMI ID",
+			mutate:      func(c *RegionCatalog) { c.Spec.Regions[0].AMIs[0].ID = "ami-xyz" },
+			expectedErr: "spec.regions[0].amis[0].id: Invalid value",
+		},
+		{
+			name:        "Testing missing AMI for the architecture of the region",
+			mutate:      func(c *RegionCatalog) { c.Spec.Regions[0].Architecture = ArchitectureArm64 },
+			expectedErr: "spec.regions[0].amis: Required value",
+		},
+		{
+			name: "Testing duplicate architecture",
+			mutate: func(c *RegionCatalog) {
+				c.Spec.Regions[1].AMIs = append(c.Spec.Regions[1].AMIs, ArchitectureAMI{Architecture: ArchitectureX8664, ID: "ami-0123456789abcdef0"})
+			},
+			expectedErr: "spec.regions[1].amis[2].architecture: Duplicate value",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			catalog := &RegionCatalog{
+				ObjectMeta: metav1.ObjectMeta{Name: "default"},
+				Spec: RegionCatalogSpec{
+					Regions: []CatalogRegion{
+						{
+							Name:         "us-east-1",
+							InstanceType: "t2.micro",
+							AMIs:         []ArchitectureAMI{{Architecture: ArchitectureX8664, ID: "ami-0123456789abcdef0"}},
+						},
+						{
+							Name:         "us-gov-west-1",
+							Partition:    PartitionAWSUSGov,
+							InstanceType: "t4g.micro",
+							Architecture: ArchitectureArm64,
+							AMIs: []ArchitectureAMI{
+								{Architecture: ArchitectureX8664, ID: "ami-12345678"},
+								{Architecture: ArchitectureArm64, ID: "ami-0fedcba9876543210"},
+							},
+						},
+					},
+				},
+			}
+			test.mutate(catalog)
+			checkWebhookError(t, catalog.ValidateCreate(), test.expectedErr)
+		})
+	}
+}
+
+func TestValidateTags(t *testing.T) {
+	tests := []struct {
+		name     string
+		tags     string
+		expected int
+	}{
+		{name: "Testing empty tags", tags: "", expected: 0},
+		{name: "Testing base64 values", tags: "base64-is-accepted=eWVzIQ==", expected: 0},
+		{name: "Testing reserved prefix", tags: "aws:cloudformation=true", expected: 1},
+		{name: "Testing duplicate keys", tags: "a=1\na=2", expected: 1},
+		{name: "Testing empty key", tags: "=value", expected: 1},
+		{name: "Testing long value", tags: "key=" + strings.Repeat("v", maxTagValueLength+1), expected: 1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			errs := validateTags(test.tags, field.NewPath("spec", "customTags"))
+			if len(errs) != test.expected {
+				t.Errorf("got %d errors (%v), wanted %d", len(errs), errs, test.expected)
+			}
+		})
+	}
+}
+
+// checkWebhookError fails the test if err doesn't contain expected, or isn't nil when expected is empty
+func checkWebhookError(t *testing.T, err error, expected string) {
+	t.Helper()
+	if expected == "" {
+		if err != nil {
+			t.Errorf("got %s, wanted no error", err)
+		}
+		return
+	}
+	if err == nil || !strings.Contains(err.Error(), expected) {
+		t.Errorf("got %v, wanted an error containing %q", err, expected)
+	}
+}
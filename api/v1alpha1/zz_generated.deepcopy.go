@@ -6,6 +6,7 @@
 package v1alpha1
 
 import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -405,6 +406,11 @@ func (in *AccountClaimSpec) DeepCopyInto(out *AccountClaimSpec) {
 	out.AwsCredentialSecret = in.AwsCredentialSecret
 	in.Aws.DeepCopyInto(&out.Aws)
 	out.BYOCSecretRef = in.BYOCSecretRef
+	if in.AccountPoolSelector != nil {
+		in, out := &in.AccountPoolSelector, &out.AccountPoolSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccountClaimSpec.
@@ -439,6 +445,23 @@ func (in *AccountClaimStatus) DeepCopy() *AccountClaimStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccountClosure) DeepCopyInto(out *AccountClosure) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccountClosure.
+func (in *AccountClosure) DeepCopy() *AccountClosure {
+	if in == nil {
+		return nil
+	}
+	out := new(AccountClosure)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AccountCondition) DeepCopyInto(out *AccountCondition) {
 	*out = *in
@@ -493,7 +516,7 @@ func (in *AccountPool) DeepCopyInto(out *AccountPool) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	out.Status = in.Status
 }
 
@@ -550,6 +573,22 @@ func (in *AccountPoolList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AccountPoolSpec) DeepCopyInto(out *AccountPoolSpec) {
 	*out = *in
+	in.AccountTemplate.DeepCopyInto(&out.AccountTemplate)
+	if in.ScaleDown != nil {
+		in, out := &in.ScaleDown, &out.ScaleDown
+		*out = new(ScaleDownPolicy)
+		**out = **in
+	}
+	if in.Schedule != nil {
+		in, out := &in.Schedule, &out.Schedule
+		*out = new(PoolSchedule)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Demand != nil {
+		in, out := &in.Demand, &out.Demand
+		*out = new(DemandSizing)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccountPoolSpec.
@@ -577,6 +616,85 @@ func (in *AccountPoolStatus) DeepCopy() *AccountPoolStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccountQuotaStatus) DeepCopyInto(out *AccountQuotaStatus) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccountQuotaStatus.
+func (in *AccountQuotaStatus) DeepCopy() *AccountQuotaStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AccountQuotaStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccountRegionNetwork) DeepCopyInto(out *AccountRegionNetwork) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccountRegionNetwork.
+func (in *AccountRegionNetwork) DeepCopy() *AccountRegionNetwork {
+	if in == nil {
+		return nil
+	}
+	out := new(AccountRegionNetwork)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccountRegionStatus) DeepCopyInto(out *AccountRegionStatus) {
+	*out = *in
+	if in.Network != nil {
+		in, out := &in.Network, &out.Network
+		*out = new(AccountRegionNetwork)
+		**out = **in
+	}
+	if in.Quotas != nil {
+		in, out := &in.Quotas, &out.Quotas
+		*out = make([]AccountQuotaStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccountRegionStatus.
+func (in *AccountRegionStatus) DeepCopy() *AccountRegionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AccountRegionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccountRetryStatus) DeepCopyInto(out *AccountRetryStatus) {
+	*out = *in
+	in.LastFailureTime.DeepCopyInto(&out.LastFailureTime)
+	if in.NextRetryTime != nil {
+		in, out := &in.NextRetryTime, &out.NextRetryTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccountRetryStatus.
+func (in *AccountRetryStatus) DeepCopy() *AccountRetryStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AccountRetryStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AccountSpec) DeepCopyInto(out *AccountSpec) {
 	*out = *in
@@ -603,6 +721,41 @@ func (in *AccountStatus) DeepCopyInto(out *AccountStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.CreateAccountRequestTime != nil {
+		in, out := &in.CreateAccountRequestTime, &out.CreateAccountRequestTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastCredentialRotation != nil {
+		in, out := &in.LastCredentialRotation, &out.LastCredentialRotation
+		*out = (*in).DeepCopy()
+	}
+	if in.ReuseCleanup != nil {
+		in, out := &in.ReuseCleanup, &out.ReuseCleanup
+		*out = new(ReuseCleanupReport)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Closure != nil {
+		in, out := &in.Closure, &out.Closure
+		*out = new(AccountClosure)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Retry != nil {
+		in, out := &in.Retry, &out.Retry
+		*out = new(AccountRetryStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Regions != nil {
+		in, out := &in.Regions, &out.Regions
+		*out = make([]AccountRegionStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SupportCase != nil {
+		in, out := &in.SupportCase, &out.SupportCase
+		*out = new(AccountSupportCase)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccountStatus.
@@ -615,6 +768,58 @@ func (in *AccountStatus) DeepCopy() *AccountStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccountSupportCase) DeepCopyInto(out *AccountSupportCase) {
+	*out = *in
+	if in.CreationTime != nil {
+		in, out := &in.CreationTime, &out.CreationTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastStatusChangeTime != nil {
+		in, out := &in.LastStatusChangeTime, &out.LastStatusChangeTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastFollowUpTime != nil {
+		in, out := &in.LastFollowUpTime, &out.LastFollowUpTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccountSupportCase.
+func (in *AccountSupportCase) DeepCopy() *AccountSupportCase {
+	if in == nil {
+		return nil
+	}
+	out := new(AccountSupportCase)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccountTemplate) DeepCopyInto(out *AccountTemplate) {
+	*out = *in
+	if in.Regions != nil {
+		in, out := &in.Regions, &out.Regions
+		*out = make([]RegionAMI, len(*in))
+		copy(*out, *in)
+	}
+	if in.Fedramp != nil {
+		in, out := &in.Fedramp, &out.Fedramp
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccountTemplate.
+func (in *AccountTemplate) DeepCopy() *AccountTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(AccountTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AmiSpec) DeepCopyInto(out *AmiSpec) {
 	*out = *in
@@ -630,6 +835,21 @@ func (in *AmiSpec) DeepCopy() *AmiSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArchitectureAMI) DeepCopyInto(out *ArchitectureAMI) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArchitectureAMI.
+func (in *ArchitectureAMI) DeepCopy() *ArchitectureAMI {
+	if in == nil {
+		return nil
+	}
+	out := new(ArchitectureAMI)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Aws) DeepCopyInto(out *Aws) {
 	*out = *in
@@ -665,6 +885,70 @@ func (in *AwsRegions) DeepCopy() *AwsRegions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CatalogAMIStatus) DeepCopyInto(out *CatalogAMIStatus) {
+	*out = *in
+	if in.LastVerifiedTime != nil {
+		in, out := &in.LastVerifiedTime, &out.LastVerifiedTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CatalogAMIStatus.
+func (in *CatalogAMIStatus) DeepCopy() *CatalogAMIStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CatalogAMIStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CatalogRegion) DeepCopyInto(out *CatalogRegion) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.AMIs != nil {
+		in, out := &in.AMIs, &out.AMIs
+		*out = make([]ArchitectureAMI, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CatalogRegion.
+func (in *CatalogRegion) DeepCopy() *CatalogRegion {
+	if in == nil {
+		return nil
+	}
+	out := new(CatalogRegion)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CleanupResult) DeepCopyInto(out *CleanupResult) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CleanupResult.
+func (in *CleanupResult) DeepCopy() *CleanupResult {
+	if in == nil {
+		return nil
+	}
+	out := new(CleanupResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Condition) DeepCopyInto(out *Condition) {
 	*out = *in
@@ -687,6 +971,23 @@ func (in *Condition) DeepCopy() *Condition {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DemandSizing) DeepCopyInto(out *DemandSizing) {
+	*out = *in
+	out.LookbackPeriod = in.LookbackPeriod
+	out.LeadTime = in.LeadTime
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DemandSizing.
+func (in *DemandSizing) DeepCopy() *DemandSizing {
+	if in == nil {
+		return nil
+	}
+	out := new(DemandSizing)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LegalEntity) DeepCopyInto(out *LegalEntity) {
 	*out = *in
@@ -702,6 +1003,41 @@ func (in *LegalEntity) DeepCopy() *LegalEntity {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PoolSchedule) DeepCopyInto(out *PoolSchedule) {
+	*out = *in
+	if in.Windows != nil {
+		in, out := &in.Windows, &out.Windows
+		*out = make([]PoolSizeWindow, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PoolSchedule.
+func (in *PoolSchedule) DeepCopy() *PoolSchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(PoolSchedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PoolSizeWindow) DeepCopyInto(out *PoolSizeWindow) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PoolSizeWindow.
+func (in *PoolSizeWindow) DeepCopy() *PoolSizeWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(PoolSizeWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Principal) DeepCopyInto(out *Principal) {
 	*out = *in
@@ -722,6 +1058,166 @@ func (in *Principal) DeepCopy() *Principal {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RegionAMI) DeepCopyInto(out *RegionAMI) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RegionAMI.
+func (in *RegionAMI) DeepCopy() *RegionAMI {
+	if in == nil {
+		return nil
+	}
+	out := new(RegionAMI)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RegionCatalog) DeepCopyInto(out *RegionCatalog) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RegionCatalog.
+func (in *RegionCatalog) DeepCopy() *RegionCatalog {
+	if in == nil {
+		return nil
+	}
+	out := new(RegionCatalog)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RegionCatalog) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RegionCatalogList) DeepCopyInto(out *RegionCatalogList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]RegionCatalog, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RegionCatalogList.
+func (in *RegionCatalogList) DeepCopy() *RegionCatalogList {
+	if in == nil {
+		return nil
+	}
+	out := new(RegionCatalogList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RegionCatalogList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RegionCatalogSpec) DeepCopyInto(out *RegionCatalogSpec) {
+	*out = *in
+	if in.Regions != nil {
+		in, out := &in.Regions, &out.Regions
+		*out = make([]CatalogRegion, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RegionCatalogSpec.
+func (in *RegionCatalogSpec) DeepCopy() *RegionCatalogSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RegionCatalogSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RegionCatalogStatus) DeepCopyInto(out *RegionCatalogStatus) {
+	*out = *in
+	if in.AMIs != nil {
+		in, out := &in.AMIs, &out.AMIs
+		*out = make([]CatalogAMIStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastVerificationTime != nil {
+		in, out := &in.LastVerificationTime, &out.LastVerificationTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RegionCatalogStatus.
+func (in *RegionCatalogStatus) DeepCopy() *RegionCatalogStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RegionCatalogStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReuseCleanupReport) DeepCopyInto(out *ReuseCleanupReport) {
+	*out = *in
+	in.CompletionTime.DeepCopyInto(&out.CompletionTime)
+	if in.Results != nil {
+		in, out := &in.Results, &out.Results
+		*out = make([]CleanupResult, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReuseCleanupReport.
+func (in *ReuseCleanupReport) DeepCopy() *ReuseCleanupReport {
+	if in == nil {
+		return nil
+	}
+	out := new(ReuseCleanupReport)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScaleDownPolicy) DeepCopyInto(out *ScaleDownPolicy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScaleDownPolicy.
+func (in *ScaleDownPolicy) DeepCopy() *ScaleDownPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ScaleDownPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SecretRef) DeepCopyInto(out *SecretRef) {
 	*out = *in
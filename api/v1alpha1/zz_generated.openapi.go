@@ -30,6 +30,9 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"github.com/ravitri/aws-account-operator/api/v1alpha1.AccountPoolStatus":               schema_openshift_aws_account_operator_api_v1alpha1_AccountPoolStatus(ref),
 		"github.com/ravitri/aws-account-operator/api/v1alpha1.AccountSpec":                     schema_openshift_aws_account_operator_api_v1alpha1_AccountSpec(ref),
 		"github.com/ravitri/aws-account-operator/api/v1alpha1.AccountStatus":                   schema_openshift_aws_account_operator_api_v1alpha1_AccountStatus(ref),
+		"github.com/ravitri/aws-account-operator/api/v1alpha1.RegionCatalog":                   schema_openshift_aws_account_operator_api_v1alpha1_RegionCatalog(ref),
+		"github.com/ravitri/aws-account-operator/api/v1alpha1.RegionCatalogSpec":               schema_openshift_aws_account_operator_api_v1alpha1_RegionCatalogSpec(ref),
+		"github.com/ravitri/aws-account-operator/api/v1alpha1.RegionCatalogStatus":             schema_openshift_aws_account_operator_api_v1alpha1_RegionCatalogStatus(ref),
 	}
 }
 
@@ -504,12 +507,32 @@ func schema_openshift_aws_account_operator_api_v1alpha1_AccountClaimSpec(ref com
 							Format: "",
 						},
 					},
+					"accountPool": {
+						SchemaProps: spec.SchemaProps{
+							Description: "AccountPool is the name of the AccountPool the account is claimed from",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"accountPoolSelector": {
+						SchemaProps: spec.SchemaProps{
+							Description: "AccountPoolSelector selects the AccountPools the account can be claimed from by their labels. Claims without AccountPool or AccountPoolSelector can be satisfied by any pool.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"),
+						},
+					},
+					"priorityClassName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PriorityClassName is the priority class of the claim. Pending claims of a higher priority get an account first. The priority classes are defined in the operator configmap.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 				},
 				Required: []string{"legalEntity", "awsCredentialSecret", "aws", "accountLink"},
 			},
 		},
 		Dependencies: []string{
-			"github.com/ravitri/aws-account-operator/api/v1alpha1.Aws", "github.com/ravitri/aws-account-operator/api/v1alpha1.LegalEntity", "github.com/ravitri/aws-account-operator/api/v1alpha1.SecretRef"},
+			"github.com/ravitri/aws-account-operator/api/v1alpha1.Aws", "github.com/ravitri/aws-account-operator/api/v1alpha1.LegalEntity", "github.com/ravitri/aws-account-operator/api/v1alpha1.SecretRef", "k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"},
 	}
 }
 
@@ -548,6 +571,13 @@ func schema_openshift_aws_account_operator_api_v1alpha1_AccountClaimStatus(ref c
 							Format:  "",
 						},
 					},
+					"queuePosition": {
+						SchemaProps: spec.SchemaProps{
+							Description: "QueuePosition is the position of the pending claim in the queue of the claims waiting for an account, 1 being the next claim to get one",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
 				},
 				Required: []string{"conditions", "state"},
 			},
@@ -675,10 +705,51 @@ func schema_openshift_aws_account_operator_api_v1alpha1_AccountPoolSpec(ref comm
 							Format:  "int32",
 						},
 					},
+					"accountTemplate": {
+						SchemaProps: spec.SchemaProps{
+							Description: "AccountTemplate holds the defaults of the Accounts created by this pool. Fields left empty fall back to the operator configmap.",
+							Default:     map[string]interface{}{},
+							Ref:         ref("github.com/ravitri/aws-account-operator/api/v1alpha1.AccountTemplate"),
+						},
+					},
+					"maxInFlight": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MaxInFlight is the largest number of new accounts the pool has progressing towards Ready at the same time. When it's not set the pool creates one account per reconcile, without a limit.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"scaleDown": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ScaleDown is what the pool does with its surplus never-claimed accounts when poolSize is lowered. The surplus accounts are kept when it's not set.",
+							Ref:         ref("github.com/ravitri/aws-account-operator/api/v1alpha1.ScaleDownPolicy"),
+						},
+					},
+					"schedule": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Schedule replaces poolSize with the pool size of its windows while they are active",
+							Ref:         ref("github.com/ravitri/aws-account-operator/api/v1alpha1.PoolSchedule"),
+						},
+					},
+					"demand": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Demand grows the pool to match the rate at which accounts have recently been claimed",
+							Ref:         ref("github.com/ravitri/aws-account-operator/api/v1alpha1.DemandSizing"),
+						},
+					},
+					"onDemand": {
+						SchemaProps: spec.SchemaProps{
+							Description: "OnDemand grows the pool to the number of claims waiting for one of its accounts, so a claim finding the pool empty gets an account created for it",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
 				},
 				Required: []string{"poolSize"},
 			},
 		},
+		Dependencies: []string{
+			"github.com/ravitri/aws-account-operator/api/v1alpha1.AccountTemplate", "github.com/ravitri/aws-account-operator/api/v1alpha1.DemandSizing", "github.com/ravitri/aws-account-operator/api/v1alpha1.PoolSchedule", "github.com/ravitri/aws-account-operator/api/v1alpha1.ScaleDownPolicy"},
 	}
 }
 
@@ -691,9 +762,10 @@ func schema_openshift_aws_account_operator_api_v1alpha1_AccountPoolStatus(ref co
 				Properties: map[string]spec.Schema{
 					"poolSize": {
 						SchemaProps: spec.SchemaProps{
-							Default: 0,
-							Type:    []string{"integer"},
-							Format:  "int32",
+							Description: "PoolSize is the desired number of unclaimed accounts, from poolSize, the schedule or demand sizing. UnclaimedAccounts is the actual one.",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int32",
 						},
 					},
 					"unclaimedAccounts": {
@@ -736,6 +808,41 @@ func schema_openshift_aws_account_operator_api_v1alpha1_AccountPoolStatus(ref co
 							Format:      "int32",
 						},
 					},
+					"surplusAccounts": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SurplusAccounts is the approximate number of unclaimed accounts above the pool size",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"retiringAccounts": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RetiringAccounts is the number of surplus accounts being closed or parked by the scale down policy",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"activeWindow": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ActiveWindow is the name of the schedule window that sets the pool size",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"demandPoolSize": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DemandPoolSize is the pool size asked for by demand sizing",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"pendingClaims": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PendingClaims is the number of claims waiting for an account of an on-demand pool",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
 				},
 				Required: []string{"poolSize", "unclaimedAccounts", "claimedAccounts", "availableAccounts", "accountsProgressing", "awsLimitDelta"},
 			},
@@ -860,10 +967,186 @@ func schema_openshift_aws_account_operator_api_v1alpha1_AccountStatus(ref common
 							Format: "",
 						},
 					},
+					"createAccountRequestID": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CreateAccountRequestID is the ID of the Organizations request creating the AWS account",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"createAccountRequestTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CreateAccountRequestTime is when the AWS account creation was requested",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"lastCredentialRotation": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LastCredentialRotation is the last time the osdManagedAdmin access keys were rotated",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"reuseCleanup": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ReuseCleanup summarises the last cleanup run before the account was returned to the pool",
+							Ref:         ref("github.com/ravitri/aws-account-operator/api/v1alpha1.ReuseCleanupReport"),
+						},
+					},
+					"closure": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Closure tracks the closure of the AWS account once the Account failed or was deleted",
+							Ref:         ref("github.com/ravitri/aws-account-operator/api/v1alpha1.AccountClosure"),
+						},
+					},
+					"retry": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Retry is the retry budget spent on the recoverable failures of the account",
+							Ref:         ref("github.com/ravitri/aws-account-operator/api/v1alpha1.AccountRetryStatus"),
+						},
+					},
+					"regions": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Regions tracks the initialization of every region of the account",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/ravitri/aws-account-operator/api/v1alpha1.AccountRegionStatus"),
+									},
+								},
+							},
+						},
+					},
+					"supportCase": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SupportCase tracks the support case opened to enable Enterprise Support on the account",
+							Ref:         ref("github.com/ravitri/aws-account-operator/api/v1alpha1.AccountSupportCase"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/ravitri/aws-account-operator/api/v1alpha1.AccountClosure", "github.com/ravitri/aws-account-operator/api/v1alpha1.AccountCondition", "github.com/ravitri/aws-account-operator/api/v1alpha1.AccountRegionStatus", "github.com/ravitri/aws-account-operator/api/v1alpha1.AccountRetryStatus", "github.com/ravitri/aws-account-operator/api/v1alpha1.AccountSupportCase", "github.com/ravitri/aws-account-operator/api/v1alpha1.ReuseCleanupReport", "k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
+func schema_openshift_aws_account_operator_api_v1alpha1_RegionCatalog(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RegionCatalog is the Schema for the regioncatalogs API",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+						},
+					},
+					"spec": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("github.com/ravitri/aws-account-operator/api/v1alpha1.RegionCatalogSpec"),
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("github.com/ravitri/aws-account-operator/api/v1alpha1.RegionCatalogStatus"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/ravitri/aws-account-operator/api/v1alpha1.RegionCatalogSpec", "github.com/ravitri/aws-account-operator/api/v1alpha1.RegionCatalogStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+	}
+}
+
+func schema_openshift_aws_account_operator_api_v1alpha1_RegionCatalogSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RegionCatalogSpec defines the regions new accounts are initialized in",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"regions": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Regions of the catalog",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/ravitri/aws-account-operator/api/v1alpha1.CatalogRegion"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"regions"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/ravitri/aws-account-operator/api/v1alpha1.CatalogRegion"},
+	}
+}
+
+func schema_openshift_aws_account_operator_api_v1alpha1_RegionCatalogStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RegionCatalogStatus defines the observed state of RegionCatalog",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"amis": {
+						SchemaProps: spec.SchemaProps{
+							Description: "AMIs holds the outcome of the last verification of every AMI of the catalog",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/ravitri/aws-account-operator/api/v1alpha1.CatalogAMIStatus"),
+									},
+								},
+							},
+						},
+					},
+					"lastVerificationTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LastVerificationTime is when the AMIs of the catalog were last verified",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"observedGeneration": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ObservedGeneration is the generation of the spec the AMIs were last verified for",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"github.com/ravitri/aws-account-operator/api/v1alpha1.AccountCondition"},
+			"github.com/ravitri/aws-account-operator/api/v1alpha1.CatalogAMIStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
 	}
 }
@@ -70,12 +70,17 @@ func GetDefaultRegion() (regionName string) {
 	return
 }
 
-// construct an ARN
-func GetIAMArn(awsAccountID, awsResourceType, awsResourceID string) (arn string) {
-	awsAPI := "aws"
+// GetPartition returns the AWS partition the operator manages accounts in
+func GetPartition() string {
 	if isFedramp {
-		awsAPI = "aws-us-gov"
+		return awsv1alpha1.PartitionAWSUSGov
 	}
+	return awsv1alpha1.PartitionAWS
+}
+
+// construct an ARN
+func GetIAMArn(awsAccountID, awsResourceType, awsResourceID string) (arn string) {
+	awsAPI := GetPartition()
 
 	// arn:partition:service:region:account-id:resource-type/resource-id
 	arn = strings.Join([]string{"arn:", awsAPI, ":iam::", awsAccountID, ":", awsResourceType, "/", awsResourceID}, "")
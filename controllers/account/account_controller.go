@@ -14,11 +14,14 @@ import (
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/aws/aws-sdk-go/service/organizations"
 	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/aws/aws-sdk-go/service/support"
 
 	corev1 "k8s.io/api/core/v1"
 	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
@@ -48,8 +51,8 @@ const (
 	// time out and set it to Failed.
 	createPendTime = utils.WaitTime * time.Minute
 	// regionInitTime is the maximum time we allow an account CR to be in the InitializingRegions
-	// state. This is based on async region init taking a theoretical maximum of WaitTime * 2
-	// minutes plus a handful of AWS API calls (see asyncRegionInit).
+	// state. This is based on region init taking a theoretical maximum of WaitTime * 2 minutes
+	// plus a handful of AWS API calls (see InitializeSupportedRegions).
 	regionInitTime = (time.Minute * utils.WaitTime * time.Duration(2)) + time.Minute
 
 	// AccountPending indicates an account is pending
@@ -82,6 +85,8 @@ type AccountReconciler struct {
 	Scheme           *runtime.Scheme
 	awsClientBuilder awsclient.IBuilder
 	shardName        string
+	recorder         record.EventRecorder
+	caseBatcher      *supportCaseBatcher
 }
 
 //+kubebuilder:rbac:groups=aws.managed.openshift.io,resources=accounts,verbs=get;list;watch;create;update;patch;delete
@@ -176,6 +181,18 @@ func (r *AccountReconciler) Reconcile(ctx context.Context, request ctrl.Request)
 			}
 		}
 		r.finalizeAccount(reqLogger, awsClient, currentAcctInstance)
+		// Surplus accounts deleted by their pool are parked or closed before they go
+		err = r.retireAccount(reqLogger, awsSetupClient, currentAcctInstance)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		// Deleted accounts are closed when the configmap opts in, or left open in the organization
+		if shouldCloseAccount(currentAcctInstance, configMap) {
+			err = r.closeAccount(reqLogger, awsSetupClient, currentAcctInstance, configMap)
+			if err != nil {
+				return reconcile.Result{}, err
+			}
+		}
 		//return reconcile.Result{}, nil
 
 		// Remove finalizer if account CR is non STS. For CCS accounts, the accountclaim controller will delete the account CR
@@ -191,15 +208,33 @@ func (r *AccountReconciler) Reconcile(ctx context.Context, request ctrl.Request)
 		return reconcile.Result{}, nil
 	}
 
-	// Log accounts that have failed and don't attempt to reconcile them
+	// SREs can retry a failed account with the force retry annotation
+	if _, ok := currentAcctInstance.GetAnnotations()[awsv1alpha1.ForceRetryAnnotation]; ok {
+		return r.handleForceRetry(reqLogger, currentAcctInstance)
+	}
+
+	// Recoverable failures are retried after a backoff
+	if currentAcctInstance.IsRetrying() {
+		return r.handleAccountRetrying(reqLogger, currentAcctInstance)
+	}
+
+	// Log accounts that have failed and don't attempt to reconcile them, past closing their AWS account
 	if currentAcctInstance.IsFailed() {
+		if shouldCloseAccount(currentAcctInstance, configMap) {
+			return reconcile.Result{}, r.closeAccount(reqLogger, awsSetupClient, currentAcctInstance, configMap)
+		}
 		reqLogger.Info(fmt.Sprintf("Account %s is failed. Ignoring.", currentAcctInstance.Name))
 		return reconcile.Result{}, nil
 	}
 
-	// Detect accounts for which we kicked off asynchronous region initialization
+	// Step through the initialization of the regions of the account
 	if currentAcctInstance.IsInitializingRegions() {
-		return r.handleAccountInitializingRegions(reqLogger, currentAcctInstance)
+		return r.handleAccountInitializingRegions(reqLogger, currentAcctInstance, awsSetupClient, configMap)
+	}
+
+	// Follow the quota increases requested during the region initialization until AWS answers them
+	if quotaPollDue(currentAcctInstance) {
+		return r.handleQuotaIncreases(reqLogger, currentAcctInstance, awsSetupClient)
 	}
 
 	// If the account is BYOC, needs some different set up
@@ -224,7 +259,7 @@ func (r *AccountReconciler) Reconcile(ctx context.Context, request ctrl.Request)
 			reqLogger.Error(initErr, "failed initializing new CCS account")
 			return result, initErr
 		}
-		utils.SetAccountStatus(currentAcctInstance, AccountCreating, awsv1alpha1.AccountCreating, AccountCreating)
+		r.setAccountStatus(currentAcctInstance, AccountCreating, awsv1alpha1.AccountCreating, AccountCreating)
 		updateErr := r.statusUpdate(currentAcctInstance)
 		if updateErr != nil {
 			// TODO: Validate this is retryable
@@ -238,7 +273,7 @@ func (r *AccountReconciler) Reconcile(ctx context.Context, request ctrl.Request)
 
 		// Test PendingVerification state creating support case and checking for case status
 		if currentAcctInstance.IsPendingVerification() {
-			return r.handleNonCCSPendingVerification(reqLogger, currentAcctInstance, awsSetupClient)
+			return r.handleNonCCSPendingVerification(reqLogger, currentAcctInstance, awsSetupClient, configMap)
 		}
 
 		// Update account Status.Claimed to true if the account is ready and the claim link is not empty
@@ -266,22 +301,24 @@ func (r *AccountReconciler) Reconcile(ctx context.Context, request ctrl.Request)
 
 		if currentAcctInstance.IsUnclaimedAndHasNoState() {
 			if !currentAcctInstance.HasAwsAccountID() {
-				// before doing anything make sure we are not over the limit if we are just error
-				if !totalaccountwatcher.TotalAccountWatcher.AccountsCanBeCreated() {
+				// before doing anything make sure we are not over the limit if we are just error.
+				// Accounts already requested are polled until they are created.
+				if currentAcctInstance.Status.CreateAccountRequestID == "" && !totalaccountwatcher.TotalAccountWatcher.AccountsCanBeCreated() {
 					// fedramp clusters are all CCS, so the account limit is irrelevant there
-					if !config.IsFedramp() {
+					if !r.isFedrampAccount(reqLogger, currentAcctInstance) {
 						reqLogger.Info("AWS Account limit reached. This does not always indicate a problem, it's a limit we enforce in the configmap to prevent runaway account creation")
 						// We don't expect the limit to change very frequently, so wait a while before requeueing to avoid hot lopping.
 						return reconcile.Result{Requeue: true, RequeueAfter: time.Duration(5) * time.Minute}, nil
 					}
 				}
 
-				if err := r.nonCCSAssignAccountID(reqLogger, currentAcctInstance, awsSetupClient); err != nil {
-					return reconcile.Result{}, err
+				result, err := r.nonCCSAssignAccountID(reqLogger, currentAcctInstance, awsSetupClient)
+				if err != nil || result.RequeueAfter > 0 {
+					return result, err
 				}
 			} else {
 				// set state creating if the account was already created
-				utils.SetAccountStatus(currentAcctInstance, "AWS account already created", awsv1alpha1.AccountCreating, AccountCreating)
+				r.setAccountStatus(currentAcctInstance, "AWS account already created", awsv1alpha1.AccountCreating, AccountCreating)
 				err = r.statusUpdate(currentAcctInstance)
 
 				if err != nil {
@@ -292,16 +329,10 @@ func (r *AccountReconciler) Reconcile(ctx context.Context, request ctrl.Request)
 	}
 
 	// Get regions from configmap data
-	stringRegions, ok := configMap.Data["regions"]
-	if !ok {
-		err = awsv1alpha1.ErrInvalidConfigMap
-		return reconcile.Result{}, err
-	}
+	regionAMIs, err := r.getRegionAMIs(reqLogger, currentAcctInstance, configMap)
 	if err != nil {
-		reqLogger.Error(err, "failed getting regions from configmap data")
 		return reconcile.Result{}, err
 	}
-	regionAMIs := processConfigMapRegions(stringRegions)
 
 	// Account init for both BYOC and Non-BYOC
 	if currentAcctInstance.ReadyForInitialization() {
@@ -336,7 +367,7 @@ func (r *AccountReconciler) Reconcile(ctx context.Context, request ctrl.Request)
 				// initializeRegions logs
 				return reconcile.Result{}, err
 			}
-			return reconcile.Result{}, nil
+			return reconcile.Result{Requeue: true}, nil
 		}
 
 		// Set IAMUserIDLabel if not there, and requeue
@@ -386,6 +417,7 @@ func (r *AccountReconciler) Reconcile(ctx context.Context, request ctrl.Request)
 			// initializeRegions logs
 			return reconcile.Result{}, err
 		}
+		return reconcile.Result{Requeue: true}, nil
 	}
 
 	if currentAcctInstance.IsReady() && probeSecretEnabled {
@@ -414,10 +446,18 @@ func (r *AccountReconciler) Reconcile(ctx context.Context, request ctrl.Request)
 		}
 	}
 
+	// Rotate the osdManagedAdmin credentials when requested (e.g. on reuse) or when they are too old.
+	// CCS accounts use the customer's credentials and STS accounts have no IAM user to rotate.
+	if currentAcctInstance.IsReady() && !currentAcctInstance.IsBYOC() && !currentAcctInstance.IsSTS() {
+		return r.handleCredentialRotation(reqLogger, currentAcctInstance, awsSetupClient, configMap)
+	}
+
 	return reconcile.Result{}, nil
 }
 
-func (r *AccountReconciler) handleAccountInitializingRegions(reqLogger logr.Logger, currentAcctInstance *awsv1alpha1.Account) (reconcile.Result, error) {
+// handleAccountInitializingRegions takes the next initialization step of every region of the account,
+// recording their progress in the account status, until they are all done
+func (r *AccountReconciler) handleAccountInitializingRegions(reqLogger logr.Logger, currentAcctInstance *awsv1alpha1.Account, awsSetupClient awsclient.Client, configMap *corev1.ConfigMap) (reconcile.Result, error) {
 	irCond := currentAcctInstance.GetCondition(awsv1alpha1.AccountInitializingRegions)
 	if irCond == nil {
 		// This should never happen: the thing that made IsInitializingRegions true
@@ -433,17 +473,10 @@ func (r *AccountReconciler) handleAccountInitializingRegions(reqLogger logr.Logg
 		)
 		return reconcile.Result{}, stateErr
 	}
-	// Detect whether we set the InitializingRegions condition in *this* invocation of the
-	// operator or a previous one.
-	if irCond.LastTransitionTime.Before(utils.GetOperatorStartTime()) {
-		// This means the region init goroutine(s) for this account were still running when an
-		// earlier invocation of the operator died. We want to recover those, so set them back
-		// to Creating, which should cause us to hit the region init code path again.
-		// TODO(efried): There's still a small hole here: If the controller was dead for
-		// too long, this can still land us in `accountCreatingTooLong` and fail the Account.
-		// At the time of this writing, that specifically applies to a) non-CCS accounts, and
-		// b) more than 25 minutes between initial Account creation and the Reconcile after
-		// this one.
+	// Accounts without region statuses started their region initialization in a goroutine of an
+	// operator that didn't record the progress, and died since.
+	if len(currentAcctInstance.Status.Regions) == 0 && irCond.LastTransitionTime.Before(utils.GetOperatorStartTime()) {
+		// Set them back to Creating, which should cause us to hit the region init code path again.
 		msg := "Recovering from stale region initialization."
 		// We're no longer InitializingRegions
 		utils.SetAccountCondition(
@@ -456,16 +489,12 @@ func (r *AccountReconciler) handleAccountInitializingRegions(reqLogger logr.Logg
 			// Make sure the existing condition is updated
 			utils.UpdateConditionAlways,
 			currentAcctInstance.Spec.BYOC)
-		// TODO(efried): This doesn't change the lastTransitionTime, which it really should.
-		// In fact, since the Creating condition is guaranteed to already be present, this
-		// is currently not doing anything more than
-		//    currentAcctInstance.Status.State = AccountCreating
-		utils.SetAccountStatus(currentAcctInstance, msg, awsv1alpha1.AccountCreating, AccountCreating)
+		r.setAccountStatus(currentAcctInstance, msg, awsv1alpha1.AccountCreating, AccountCreating)
 		// The status update will trigger another Reconcile, but be explicit. The requests get
 		// collapsed anyway.
 		return reconcile.Result{Requeue: true}, r.statusUpdate(currentAcctInstance)
 	}
-	// The goroutines happened in this invocation. Time out if that has taken too long.
+	// Time out if that has taken too long.
 	if time.Since(irCond.LastTransitionTime.Time) > regionInitTime {
 		errMsg := fmt.Sprintf("Initializing regions for longer than %d seconds", regionInitTime/time.Second)
 		_, stateErr := r.setAccountFailed(
@@ -478,28 +507,50 @@ func (r *AccountReconciler) handleAccountInitializingRegions(reqLogger logr.Logg
 		)
 		return reconcile.Result{}, stateErr
 	}
-	// Otherwise give it a chance to finish.
-	reqLogger.Info(fmt.Sprintf("Account %s is initializing regions. Ignoring.", currentAcctInstance.Name))
-	// No need to requeue. If the goroutine finishes, it changes the state, which will trigger
-	// a Reconcile. If it hangs forever, we'll eventually get a freebie periodic Reconcile
-	// that will hit the timeout condition above.
-	return reconcile.Result{}, nil
+
+	if !currentAcctInstance.RegionsInitialized() {
+		regionAMIs, err := r.getRegionAMIs(reqLogger, currentAcctInstance, configMap)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		creds, err := r.regionInitCredentials(reqLogger, currentAcctInstance, awsSetupClient)
+		if err != nil {
+			reqLogger.Error(err, "failed getting credentials to initialize regions")
+			return reconcile.Result{}, err
+		}
+
+		r.InitializeSupportedRegions(reqLogger, currentAcctInstance, creds, regionAMIs)
+		if !currentAcctInstance.RegionsInitialized() {
+			reqLogger.Info(fmt.Sprintf("Account %s is initializing regions.", currentAcctInstance.Name))
+			return reconcile.Result{RequeueAfter: regionInitPollInterval}, r.statusUpdate(currentAcctInstance)
+		}
+	}
+
+	return r.finishRegionInit(reqLogger, currentAcctInstance, configMap)
 }
 
-func (r *AccountReconciler) handleNonCCSPendingVerification(reqLogger logr.Logger, currentAcctInstance *awsv1alpha1.Account, awsSetupClient awsclient.Client) (reconcile.Result, error) {
+func (r *AccountReconciler) handleNonCCSPendingVerification(reqLogger logr.Logger, currentAcctInstance *awsv1alpha1.Account, awsSetupClient awsclient.Client, configMap *corev1.ConfigMap) (reconcile.Result, error) {
+	caseTmpl := getCaseTemplate(reqLogger, configMap)
+
 	// If the supportCaseID is blank and Account State = PendingVerification, create a case
 	if !currentAcctInstance.HasSupportCaseID() {
 		switch utils.DetectDevMode {
 		case utils.DevModeProduction:
-			caseID, err := createCase(reqLogger, currentAcctInstance, awsSetupClient)
+			// Accounts waiting within the same window share a case
+			if caseTmpl.BatchWindow > 0 && r.caseBatcher != nil {
+				return r.handleBatchedCase(reqLogger, currentAcctInstance, awsSetupClient, caseTmpl)
+			}
+			caseID, err := createCase(reqLogger, currentAcctInstance, awsSetupClient, caseTmpl)
 			if err != nil {
+				r.recorder.Eventf(currentAcctInstance, corev1.EventTypeWarning, utils.EventReasonSupportCaseFailed, "Failed to create support case: %v", err)
 				return reconcile.Result{}, err
 			}
 			reqLogger.Info("case created", "CaseID", caseID)
+			r.recorder.Eventf(currentAcctInstance, corev1.EventTypeNormal, utils.EventReasonSupportCaseCreated, "Created support case %s to enable Enterprise Support", caseID)
 
 			// Update supportCaseId in CR
 			currentAcctInstance.Status.SupportCaseID = caseID
-			utils.SetAccountStatus(currentAcctInstance, "Account pending verification in AWS", awsv1alpha1.AccountPendingVerification, AccountPendingVerification)
+			r.setAccountStatus(currentAcctInstance, "Account pending verification in AWS", awsv1alpha1.AccountPendingVerification, AccountPendingVerification)
 			err = r.statusUpdate(currentAcctInstance)
 			if err != nil {
 				reqLogger.Error(err, "failed to update account state, retrying", "desired state", AccountPendingVerification)
@@ -522,32 +573,98 @@ func (r *AccountReconciler) handleNonCCSPendingVerification(reqLogger logr.Logge
 		}
 	}
 
-	var resolved bool
+	var resolved, caseChanged bool
+	requeueAfter := intervalBetweenChecksMinutes * time.Minute
 
 	switch utils.DetectDevMode {
 	case utils.DevModeProduction:
-		resolvedScoped, err := checkCaseResolution(reqLogger, currentAcctInstance.Status.SupportCaseID, awsSetupClient)
+		var err error
+		caseChanged, requeueAfter, err = r.trackCase(reqLogger, currentAcctInstance, awsSetupClient, caseTmpl)
 		if err != nil {
 			reqLogger.Error(err, "Error checking for Case Resolution")
+			r.recorder.Eventf(currentAcctInstance, corev1.EventTypeWarning, utils.EventReasonSupportCaseFailed, "Failed to check support case %s: %v", currentAcctInstance.Status.SupportCaseID, err)
 			return reconcile.Result{}, err
 		}
-		resolved = resolvedScoped
+		resolved = currentAcctInstance.Status.SupportCase.Status == caseStatusResolved
 	default:
 		log.Info("Running in development mode, Skipping case resolution check")
 		resolved = true
 	}
 
+	// Accounts can be held out of the claimable set until their quota increase requests are answered
+	if resolved && currentAcctInstance.HasPendingQuotaIncreases() && holdClaimsForQuotas(configMap) {
+		reqLogger.Info("case resolved, waiting for the quota increase requests", "caseID", currentAcctInstance.Status.SupportCaseID)
+		if caseChanged {
+			return reconcile.Result{RequeueAfter: quotaPollInterval}, r.statusUpdate(currentAcctInstance)
+		}
+		return reconcile.Result{RequeueAfter: quotaPollInterval}, nil
+	}
+
 	// Case Resolved, account is Ready
 	if resolved {
 		reqLogger.Info("case resolved", "caseID", currentAcctInstance.Status.SupportCaseID)
+		r.recorder.Eventf(currentAcctInstance, corev1.EventTypeNormal, utils.EventReasonSupportCaseResolved, "Support case %s was resolved", currentAcctInstance.Status.SupportCaseID)
 
-		utils.SetAccountStatus(currentAcctInstance, "Account ready to be claimed", awsv1alpha1.AccountReady, AccountReady)
+		r.setAccountStatus(currentAcctInstance, "Account ready to be claimed", awsv1alpha1.AccountReady, AccountReady)
 		return reconcile.Result{}, r.statusUpdate(currentAcctInstance)
 	}
 
 	// Case not Resolved, log info and try again in pre-defined interval
-	reqLogger.Info("case not yet resolved, retrying", "caseID", currentAcctInstance.Status.SupportCaseID, "retry delay", intervalBetweenChecksMinutes)
-	return reconcile.Result{RequeueAfter: intervalBetweenChecksMinutes * time.Minute}, nil
+	reqLogger.Info("case not yet resolved, retrying", "caseID", currentAcctInstance.Status.SupportCaseID, "caseStatus", currentAcctInstance.Status.SupportCase.Status, "retry delay", requeueAfter)
+	if caseChanged {
+		return reconcile.Result{RequeueAfter: requeueAfter}, r.statusUpdate(currentAcctInstance)
+	}
+	return reconcile.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// trackCase records the status of the support case of the account and follows it up when it stalls.
+// The accounts of a batch share the details of their case, and only its owner follows it up.
+// It returns whether the status of the account changed and when the case should be checked again.
+func (r *AccountReconciler) trackCase(reqLogger logr.Logger, account *awsv1alpha1.Account, awsClient awsclient.Client, caseTmpl caseTemplate) (bool, time.Duration, error) {
+	caseID := account.Status.SupportCaseID
+	previousStatus := ""
+	if account.Status.SupportCase != nil {
+		previousStatus = account.Status.SupportCase.Status
+	}
+
+	var details *support.CaseDetails
+	var err error
+	batched := account.Status.SupportCase != nil && account.Status.SupportCase.BatchOwner != ""
+	if batched && r.caseBatcher != nil {
+		r.caseBatcher.forget(account.Name)
+		details, err = r.caseBatcher.describeCase(reqLogger, caseID, awsClient)
+	} else {
+		details, err = describeCase(reqLogger, caseID, awsClient)
+	}
+	if err != nil {
+		return false, 0, err
+	}
+	changed := recordCaseStatus(account, details)
+	if status := account.Status.SupportCase.Status; status != previousStatus {
+		reqLogger.Info("case status changed", "caseID", caseID, "from", previousStatus, "to", status)
+		if previousStatus != "" {
+			r.recorder.Eventf(account, corev1.EventTypeNormal, utils.EventReasonSupportCaseStatusChanged, "Support case %s is now %s, was %s", caseID, status, previousStatus)
+		}
+	}
+
+	requeueAfter := intervalBetweenChecksMinutes * time.Minute
+	untilFollowUp, due := caseFollowUpDue(account.Status.SupportCase, caseTmpl.FollowUpAfter, time.Now())
+	// Only the account that opened a batched case follows it up
+	if batched && account.Status.SupportCase.BatchOwner != account.Name {
+		untilFollowUp, due = 0, false
+	}
+	if due {
+		err = followUpCase(reqLogger, account, awsClient, caseTmpl)
+		if err != nil {
+			r.recorder.Eventf(account, corev1.EventTypeWarning, utils.EventReasonSupportCaseFailed, "Failed to follow up support case %s: %v", caseID, err)
+		} else {
+			r.recorder.Eventf(account, corev1.EventTypeNormal, utils.EventReasonSupportCaseFollowedUp, "Followed up support case %s, %s since %s", caseID, account.Status.SupportCase.Status, account.Status.SupportCase.LastStatusChangeTime.Format(time.RFC3339))
+			changed = true
+		}
+	} else if untilFollowUp > 0 && untilFollowUp < requeueAfter {
+		requeueAfter = untilFollowUp
+	}
+	return changed, requeueAfter, nil
 }
 
 func (r *AccountReconciler) finalizeAccount(reqLogger logr.Logger, awsClient awsclient.Client, account *awsv1alpha1.Account) {
@@ -570,7 +687,9 @@ func (r *AccountReconciler) accountSpecUpdate(reqLogger logr.Logger, account *aw
 	return err
 }
 
-func (r *AccountReconciler) nonCCSAssignAccountID(reqLogger logr.Logger, currentAcctInstance *awsv1alpha1.Account, awsSetupClient awsclient.Client) error {
+// nonCCSAssignAccountID creates the AWS account of the Account and sets its ID. The creation is
+// asynchronous, a non-zero RequeueAfter is returned until the AWS account is created.
+func (r *AccountReconciler) nonCCSAssignAccountID(reqLogger logr.Logger, currentAcctInstance *awsv1alpha1.Account, awsSetupClient awsclient.Client) (reconcile.Result, error) {
 	// Build Aws Account
 	var awsAccountID string
 
@@ -579,7 +698,10 @@ func (r *AccountReconciler) nonCCSAssignAccountID(reqLogger logr.Logger, current
 		var err error
 		awsAccountID, err = r.BuildAccount(reqLogger, awsSetupClient, currentAcctInstance)
 		if err != nil {
-			return err
+			return reconcile.Result{}, err
+		}
+		if awsAccountID == "" {
+			return reconcile.Result{RequeueAfter: createAccountPollInterval(currentAcctInstance)}, nil
 		}
 	default:
 		log.Info("Running in development mode, skipping account creation")
@@ -587,11 +709,11 @@ func (r *AccountReconciler) nonCCSAssignAccountID(reqLogger logr.Logger, current
 	}
 
 	// set state creating if the account was able to create
-	utils.SetAccountStatus(currentAcctInstance, AccountCreating, awsv1alpha1.AccountCreating, AccountCreating)
+	r.setAccountStatus(currentAcctInstance, AccountCreating, awsv1alpha1.AccountCreating, AccountCreating)
 	err := r.statusUpdate(currentAcctInstance)
 
 	if err != nil {
-		return err
+		return reconcile.Result{}, err
 	}
 
 	if utils.DetectDevMode != utils.DevModeProduction {
@@ -608,7 +730,7 @@ func (r *AccountReconciler) nonCCSAssignAccountID(reqLogger logr.Logger, current
 		reqLogger.Info("Unable to tag aws account.", "account", currentAcctInstance.Name, "AWSAccountID", awsAccountID, "Error", error.Error(err))
 	}
 
-	return r.accountSpecUpdate(reqLogger, currentAcctInstance)
+	return reconcile.Result{}, r.accountSpecUpdate(reqLogger, currentAcctInstance)
 }
 
 func TagAccount(awsSetupClient awsclient.Client, awsAccountID string, shardName string) error {
@@ -744,18 +866,15 @@ func (r *AccountReconciler) initializeRegions(reqLogger logr.Logger, currentAcct
 		return err
 	}
 
-	// We're about to kick off region init in a goroutine. This status makes subsequent
-	// Reconciles ignore the Account (unless it stays in this state for too long).
-	utils.SetAccountStatus(currentAcctInstance, "Initializing Regions", awsv1alpha1.AccountInitializingRegions, AccountInitializingRegions)
-	if err := r.statusUpdate(currentAcctInstance); err != nil {
-		// statusUpdate logs
-		return err
-	}
-
-	// For accounts created by the accountpool we want to ensure we initiate all regions
+	// For accounts created by the accountpool we want to ensure we initiate all the regions
+	// enabled in the account that have an AMI to initialize them with
 	if !currentAcctInstance.IsBYOC() {
-		go r.asyncRegionInit(reqLogger, currentAcctInstance, creds, regionAMIs, castAWSRegionType(regionsEnabledInAccount.Regions))
-		return nil
+		regions := filterRegions(castAWSRegionType(regionsEnabledInAccount.Regions), regionAMIs)
+		if len(regions) == 0 {
+			reqLogger.Error(awsv1alpha1.ErrInvalidConfigMap, "no region enabled in the account has an AMI to initialize it with")
+			return awsv1alpha1.ErrInvalidConfigMap
+		}
+		return r.startRegionInit(currentAcctInstance, newRegionStatuses(regions))
 	}
 
 	// For non OSD accounts we check the desired region from the accountclaim and ensure that the account has
@@ -765,97 +884,101 @@ func (r *AccountReconciler) initializeRegions(reqLogger logr.Logger, currentAcct
 		reqLogger.Info("Accountclaim not found")
 		return acctClaimErr
 	}
-	for _, wantedRegion := range accountClaim.Spec.Aws.Regions {
+	// Only initiate the requested regions
+	regions := newRegionStatuses(accountClaim.Spec.Aws.Regions)
+	for i := range regions {
 		found := false
 		for _, enabledRegion := range regionsEnabledInAccount.Regions {
-			if wantedRegion.Name == *enabledRegion.RegionName {
+			if regions[i].Name == *enabledRegion.RegionName {
 				found = true
 			}
 		}
 		if !found {
-			utils.SetAccountStatus(
-				currentAcctInstance,
-				fmt.Sprintf("AWS region %s is not supported for AWS account %s", wantedRegion, currentAcctInstance.Name),
-				awsv1alpha1.AccountInitializingRegions, AccountInitializingRegions)
-			if err := r.statusUpdate(currentAcctInstance); err != nil {
-				// statusUpdate logs
-				return err
-			}
-			return err
+			regions[i].Reason = "RegionNotEnabled"
+			setRegionPhase(&regions[i], awsv1alpha1.RegionFailed,
+				fmt.Sprintf("AWS region %s is not supported for AWS account %s", regions[i].Name, currentAcctInstance.Name))
 		}
 	}
-
-	// This initializes supported regions, and updates Account state when that's done. There is
-	// no error checking at this level.
-	// Only initiate the one requested region
-	go r.asyncRegionInit(reqLogger, currentAcctInstance, creds, regionAMIs, accountClaim.Spec.Aws.Regions)
-
-	return nil
+	return r.startRegionInit(currentAcctInstance, regions)
 }
 
-// asyncRegionInit initializes supported regions by creating and destroying an instance in each.
-// Upon completion, it *always* sets the Account status to either Ready or PendingVerification.
-// There is no mechanism for this func to report errors to its parent. The only error paths
-// currently possible are:
-// - The Status update fails.
-// - This goroutine dies in some horrible and unpredictable way.
-// In either case we would expect the main reconciler to eventually notice that the Account has
-// been in the InitializingRegions state for too long, and set it to Failed.
-func (r *AccountReconciler) asyncRegionInit(reqLogger logr.Logger, currentAcctInstance *awsv1alpha1.Account, creds *sts.AssumeRoleOutput, regionAMIs map[string]awsv1alpha1.AmiSpec, regionsEnabledInAccount []awsv1alpha1.AwsRegions) {
-
-	// Initialize all supported regions by creating and terminating an instance in each
-	r.InitializeSupportedRegions(reqLogger, currentAcctInstance, regionsEnabledInAccount, creds, regionAMIs)
-
-	if currentAcctInstance.IsBYOC() {
-		utils.SetAccountStatus(currentAcctInstance, "BYOC Account Ready", awsv1alpha1.AccountReady, AccountReady)
-
-	} else {
-		if currentAcctInstance.GetCondition(awsv1alpha1.AccountReady) != nil {
-			msg := "Account support case already resolved; Account Ready"
-			utils.SetAccountStatus(currentAcctInstance, msg, awsv1alpha1.AccountReady, AccountReady)
-			reqLogger.Info(msg)
-		} else {
-			msg := "Account pending AWS limits verification"
-			utils.SetAccountStatus(currentAcctInstance, msg, awsv1alpha1.AccountPendingVerification, AccountPendingVerification)
-			reqLogger.Info(msg)
-		}
-	}
-
-	if err := r.statusUpdate(currentAcctInstance); err != nil {
-		// If this happens, the Account should eventually get set to Failed by the
-		// accountOlderThan check in the main controller.
-		reqLogger.Error(err, "asyncRegionInit failed to update status")
-	}
+// startRegionInit records the regions to initialize in the account status and moves it to InitializingRegions.
+// The next reconciles step through the initialization of the regions.
+func (r *AccountReconciler) startRegionInit(currentAcctInstance *awsv1alpha1.Account, regions []awsv1alpha1.AccountRegionStatus) error {
+	currentAcctInstance.Status.Regions = regions
+	r.setAccountStatus(currentAcctInstance, "Initializing Regions", awsv1alpha1.AccountInitializingRegions, AccountInitializingRegions)
+	// statusUpdate logs
+	return r.statusUpdate(currentAcctInstance)
 }
 
-// BuildAccount take all parameters required and uses those to make an aws call to CreateAccount. It returns an account ID and and error
+// BuildAccount requests the creation of the AWS account, or checks on the request made by a previous
+// reconcile. The request ID is kept in the account status so it survives operator restarts. It returns
+// the AWS account ID once the account is created, or an empty ID while the creation is in progress.
 func (r *AccountReconciler) BuildAccount(reqLogger logr.Logger, awsClient awsclient.Client, account *awsv1alpha1.Account) (string, error) {
-	reqLogger.Info("Creating Account")
+	if account.Status.CreateAccountRequestID == "" {
+		reqLogger.Info("Creating Account")
 
-	email := formatAccountEmail(account.Name)
-	orgOutput, orgErr := CreateAccount(reqLogger, awsClient, account.Name, email)
-	// If it was an api or a limit issue don't modify account and exit if anything else set to failed
-	if orgErr != nil {
-		switch orgErr {
-		case awsv1alpha1.ErrAwsFailedCreateAccount:
-			utils.SetAccountStatus(account, "Failed to create AWS Account", awsv1alpha1.AccountCreationFailed, AccountFailed)
-			err := r.statusUpdate(account)
+		configMap, err := utils.GetOperatorConfigMap(r.Client)
+		if err != nil {
+			reqLogger.Error(err, "failed retrieving configmap")
+			return "", err
+		}
+		email, name, err := getAccountEmailAndName(account, configMap)
+		if err != nil {
+			reqLogger.Error(err, "failed generating the email and name of the AWS account")
+			r.recorder.Event(account, corev1.EventTypeWarning, utils.EventReasonAccountCreationFailed, err.Error())
+			return "", err
+		}
+		if accountUniqueCheckEnabled(configMap) {
+			conflict, err := checkAccountUnique(reqLogger, awsClient, email, name)
 			if err != nil {
 				return "", err
 			}
+			if conflict != "" {
+				reqLogger.Info("AWS account email or name already in use", "conflict", conflict)
+				r.recorder.Event(account, corev1.EventTypeWarning, utils.EventReasonAccountCreationFailed, conflict)
+				return "", r.handleCreateAccountError(reqLogger, account, awsv1alpha1.ErrAwsFailedCreateAccount)
+			}
+		}
 
-			reqLogger.Error(awsv1alpha1.ErrAwsFailedCreateAccount, "Failed to create AWS Account")
-			return "", orgErr
-
-		case awsv1alpha1.ErrAwsAccountLimitExceeded:
-			log.Error(orgErr, "Failed to create AWS Account limit reached")
-			return "", orgErr
+		requestID, orgErr := CreateAccount(reqLogger, awsClient, name, email)
+		if orgErr != nil {
+			return "", r.handleCreateAccountError(reqLogger, account, orgErr)
+		}
 
-		default:
-			log.Error(orgErr, "Failed to create AWS Account nonfatal error")
-			return "", orgErr
+		now := metav1.Now()
+		account.Status.CreateAccountRequestID = requestID
+		account.Status.CreateAccountRequestTime = &now
+		if err := r.statusUpdate(account); err != nil {
+			reqLogger.Error(err, "failed recording the AWS account creation request", "requestID", requestID)
+			return "", err
 		}
+		reqLogger.Info("account creation requested", "requestID", requestID)
+	}
 
+	createStatus, orgErr := GetCreateAccountStatus(awsClient, account.Status.CreateAccountRequestID)
+	if orgErr != nil {
+		return "", r.handleCreateAccountError(reqLogger, account, orgErr)
+	}
+
+	if aws.StringValue(createStatus.State) == organizations.CreateAccountStateInProgress {
+		if account.Status.CreateAccountRequestTime != nil && time.Since(account.Status.CreateAccountRequestTime.Time) > createPendTime {
+			errMsg := fmt.Sprintf("Creation request %s pending for longer than %d minutes", account.Status.CreateAccountRequestID, utils.WaitTime)
+			_, stateErr := r.setAccountFailed(
+				reqLogger,
+				account,
+				awsv1alpha1.AccountCreationFailed,
+				"CreationTimeout",
+				errMsg,
+				AccountFailed,
+			)
+			if stateErr != nil {
+				return "", stateErr
+			}
+			return "", errors.New(errMsg)
+		}
+		reqLogger.Info("account creation in progress", "requestID", account.Status.CreateAccountRequestID)
+		return "", nil
 	}
 
 	accountObjectKey := client.ObjectKeyFromObject(account)
@@ -865,12 +988,74 @@ func (r *AccountReconciler) BuildAccount(reqLogger logr.Logger, awsClient awscli
 	}
 
 	reqLogger.Info("account created successfully")
+	r.recorder.Eventf(account, corev1.EventTypeNormal, utils.EventReasonAccountCreated, "Created AWS account %s", *createStatus.AccountId)
+
+	return *createStatus.AccountId, nil
+}
+
+// handleCreateAccountError handles an error creating the AWS account. API or limit issues leave the
+// account as it is, anything else fails it, to be retried when AWS gave a transient failure reason.
+// Failed requests are forgotten so a new one can be made.
+func (r *AccountReconciler) handleCreateAccountError(reqLogger logr.Logger, account *awsv1alpha1.Account, orgErr error) error {
+	var requestErr *createAccountFailedError
+	isRequestErr := errors.As(orgErr, &requestErr)
+	requestFailed := account.Status.CreateAccountRequestID != "" && (isRequestErr || orgErr == awsv1alpha1.ErrAwsAccountLimitExceeded)
+	if requestFailed {
+		account.Status.CreateAccountRequestID = ""
+		account.Status.CreateAccountRequestTime = nil
+	}
+
+	switch {
+	case errors.Is(orgErr, awsv1alpha1.ErrAwsFailedCreateAccount):
+		reason := string(awsv1alpha1.AccountCreationFailed)
+		msg := "Failed to create AWS Account"
+		if isRequestErr {
+			reason = requestErr.reason
+			msg = fmt.Sprintf("%s: %s", msg, requestErr.reason)
+		}
+		_, err := r.setAccountFailed(reqLogger, account, awsv1alpha1.AccountCreationFailed, reason, msg, AccountFailed)
+		if err != nil {
+			return err
+		}
+
+		reqLogger.Error(orgErr, "Failed to create AWS Account")
+		return orgErr
 
-	return *orgOutput.CreateAccountStatus.AccountId, nil
+	case orgErr == awsv1alpha1.ErrAwsAccountLimitExceeded:
+		log.Error(orgErr, "Failed to create AWS Account limit reached")
+		r.recorder.Event(account, corev1.EventTypeWarning, utils.EventReasonAccountCreationFailed, orgErr.Error())
+
+	default:
+		log.Error(orgErr, "Failed to create AWS Account nonfatal error")
+		r.recorder.Event(account, corev1.EventTypeWarning, utils.EventReasonAccountCreationFailed, orgErr.Error())
+	}
+
+	if requestFailed {
+		if err := r.statusUpdate(account); err != nil {
+			reqLogger.Error(err, "failed clearing the AWS account creation request")
+		}
+	}
+	return orgErr
+}
+
+// createAccountPollInterval returns the wait before checking on the creation request of the account again.
+// It grows with the age of the request, from 5 seconds up to a minute.
+func createAccountPollInterval(account *awsv1alpha1.Account) time.Duration {
+	interval := 5 * time.Second
+	if account.Status.CreateAccountRequestTime != nil {
+		if elapsed := time.Since(account.Status.CreateAccountRequestTime.Time); elapsed > interval {
+			interval = elapsed
+		}
+	}
+	if interval > time.Minute {
+		return time.Minute
+	}
+	return interval
 }
 
-// CreateAccount creates an AWS account for the specified accountName and accountEmail in the organization
-func CreateAccount(reqLogger logr.Logger, client awsclient.Client, accountName, accountEmail string) (*organizations.DescribeCreateAccountStatusOutput, error) {
+// CreateAccount requests the creation of an AWS account for the specified accountName and accountEmail in the
+// organization, and returns the ID of the request
+func CreateAccount(reqLogger logr.Logger, client awsclient.Client, accountName, accountEmail string) (string, error) {
 
 	createInput := organizations.CreateAccountInput{
 		AccountName: aws.String(accountName),
@@ -894,43 +1079,43 @@ func CreateAccount(reqLogger logr.Logger, client awsclient.Client, accountName,
 			}
 
 		}
-		return &organizations.DescribeCreateAccountStatusOutput{}, returnErr
+		return "", returnErr
 	}
 
-	describeStatusInput := organizations.DescribeCreateAccountStatusInput{
-		CreateAccountRequestId: createOutput.CreateAccountStatus.Id,
-	}
+	return aws.StringValue(createOutput.CreateAccountStatus.Id), nil
+}
 
-	var accountStatus *organizations.DescribeCreateAccountStatusOutput
-	for {
-		status, err := client.DescribeCreateAccountStatus(&describeStatusInput)
-		if err != nil {
-			return &organizations.DescribeCreateAccountStatusOutput{}, err
-		}
+// createAccountFailedError is returned for a creation request AWS reports as FAILED, with the reason AWS gave
+type createAccountFailedError struct {
+	reason string
+}
 
-		accountStatus = status
-		createStatus := *status.CreateAccountStatus.State
+func (e *createAccountFailedError) Error() string {
+	return fmt.Sprintf("%s: %s", awsv1alpha1.ErrAwsFailedCreateAccount, e.reason)
+}
 
-		if createStatus == "FAILED" {
-			var returnErr error
-			switch *status.CreateAccountStatus.FailureReason {
-			case "ACCOUNT_LIMIT_EXCEEDED":
-				returnErr = awsv1alpha1.ErrAwsAccountLimitExceeded
-			case "INTERNAL_FAILURE":
-				returnErr = awsv1alpha1.ErrAwsInternalFailure
-			default:
-				returnErr = awsv1alpha1.ErrAwsFailedCreateAccount
-			}
+func (e *createAccountFailedError) Unwrap() error {
+	return awsv1alpha1.ErrAwsFailedCreateAccount
+}
 
-			return &organizations.DescribeCreateAccountStatusOutput{}, returnErr
-		}
+// GetCreateAccountStatus checks once on an account creation request. Failed requests are returned as errors.
+func GetCreateAccountStatus(client awsclient.Client, requestID string) (*organizations.CreateAccountStatus, error) {
+	status, err := client.DescribeCreateAccountStatus(&organizations.DescribeCreateAccountStatusInput{
+		CreateAccountRequestId: aws.String(requestID),
+	})
+	if err != nil {
+		return nil, err
+	}
 
-		if createStatus != "IN_PROGRESS" {
-			break
+	if aws.StringValue(status.CreateAccountStatus.State) == organizations.CreateAccountStateFailed {
+		reason := aws.StringValue(status.CreateAccountStatus.FailureReason)
+		if reason == organizations.CreateAccountFailureReasonAccountLimitExceeded {
+			return nil, awsv1alpha1.ErrAwsAccountLimitExceeded
 		}
+		return nil, &createAccountFailedError{reason: reason}
 	}
 
-	return accountStatus, nil
+	return status.CreateAccountStatus, nil
 }
 
 func ClaimAccount(r *AccountReconciler, currentAcctInstance *awsv1alpha1.Account) error {
@@ -949,29 +1134,45 @@ func ClaimAccount(r *AccountReconciler, currentAcctInstance *awsv1alpha1.Account
 		// Make sure the existing condition is updated
 		utils.UpdateConditionAlways,
 		currentAcctInstance.Spec.BYOC)
+	r.recorder.Event(currentAcctInstance, corev1.EventTypeNormal, utils.EventReasonClaimed, msg)
 	return r.statusUpdate(currentAcctInstance)
 }
 
-func formatAccountEmail(name string) string {
-	// osd-creds-mgmt
-	// libra-ops
-	splitString := strings.Split(name, "-")
-	prefix := splitString[0]
-	for i := 1; i < (len(splitString) - 1); i++ {
-		prefix = prefix + "-" + splitString[i]
-	}
-
-	email := prefix + "+" + splitString[len(splitString)-1] + "@redhat.com"
-	return email
-}
-
 func (r *AccountReconciler) statusUpdate(account *awsv1alpha1.Account) error {
 	err := r.Client.Status().Update(context.TODO(), account)
 	return err
 }
 
+// setAccountStatus sets the condition and state of the account and records an Event when the state changes
+func (r *AccountReconciler) setAccountStatus(account *awsv1alpha1.Account, message string, ctype awsv1alpha1.AccountConditionType, state string) {
+	if account.Status.State != state {
+		eventType := corev1.EventTypeNormal
+		if state == AccountFailed {
+			eventType = corev1.EventTypeWarning
+		}
+		r.recorder.Event(account, eventType, string(ctype), message)
+	}
+	utils.SetAccountStatus(account, message, ctype, state)
+	// A Ready account starts over with a full retry budget
+	if state == AccountReady {
+		account.Status.Retry = nil
+	}
+}
+
+// setAccountFailed records a failure of the account. Recoverable failures put the account in the
+// Retrying state until its retry budget is spent, the other ones set the given state.
 func (r *AccountReconciler) setAccountFailed(reqLogger logr.Logger, account *awsv1alpha1.Account, ctype awsv1alpha1.AccountConditionType, reason string, message string, state string) (reconcile.Result, error) {
 	reqLogger.Info(message)
+	retrying := state == AccountFailed && recordFailure(account, reason, r.getRetryMaxAttempts(), time.Now())
+	if retrying {
+		state = AccountRetrying
+		message = fmt.Sprintf("%s, retrying in %s", message, time.Until(account.Status.Retry.NextRetryTime.Time).Round(time.Second))
+	}
+	eventReason := reason
+	if eventReason == "" {
+		eventReason = string(ctype)
+	}
+	r.recorder.Event(account, corev1.EventTypeWarning, eventReason, message)
 	// Update account status and condition
 	account.Status.Conditions = utils.SetAccountCondition(
 		account.Status.Conditions,
@@ -984,13 +1185,21 @@ func (r *AccountReconciler) setAccountFailed(reqLogger logr.Logger, account *aws
 	)
 	account.Status.State = state
 
+	// Apply update
+	if retrying {
+		err := r.statusUpdate(account)
+		if err != nil {
+			reqLogger.Error(err, "failed to update account status")
+		}
+		return reconcile.Result{RequeueAfter: time.Until(account.Status.Retry.NextRetryTime.Time)}, nil
+	}
+
 	// Set the failure in the accountClaim as well
 	err := r.accountClaimError(reqLogger, account, reason, message)
 	if err != nil {
 		return reconcile.Result{}, err
 	}
 
-	// Apply update
 	err = r.statusUpdate(account)
 	if err != nil {
 		reqLogger.Error(err, "failed to update account status")
@@ -1122,8 +1331,9 @@ func getBuildIAMUserErrorReason(err error) (string, awsv1alpha1.AccountCondition
 	}
 }
 
-// processConfigMapRegions is a very hacky way of turning the region ami data we store in the configmap into an region-ami map
-func processConfigMapRegions(regionString string) map[string]awsv1alpha1.AmiSpec {
+// processConfigMapRegions is a very hacky way of turning the region ami data we store in the configmap into an region-ami map.
+// The configmap regions are superseded by RegionCatalogs, which are validated when they're created.
+func processConfigMapRegions(reqLogger logr.Logger, regionString string) map[string]awsv1alpha1.AmiSpec {
 	output := make(map[string]awsv1alpha1.AmiSpec)
 	regionsDelimited := strings.Split(regionString, "\n")
 	for _, value := range regionsDelimited {
@@ -1133,30 +1343,37 @@ func processConfigMapRegions(regionString string) map[string]awsv1alpha1.AmiSpec
 				Ami:          strings.ReplaceAll(tempArr[1], " ", ""),
 				InstanceType: strings.ReplaceAll(tempArr[2], " ", ""),
 			}
+		} else if strings.TrimSpace(value) != "" {
+			reqLogger.Info("skipping malformed configmap region, expected region:ami:instanceType", "line", value)
 		}
 	}
 	return output
 }
 
-// getManagedTags retrieves a list of managed tags from the configmap
-// returns an empty list on any failure.
-func (r *AccountReconciler) getManagedTags(log logr.Logger) []awsclient.AWSTag {
+// getManagedTags retrieves a list of managed tags from the configmap, with the managed tags
+// of the account's pool applied on top. Returns the pool tags alone on any configmap failure.
+func (r *AccountReconciler) getManagedTags(log logr.Logger, account *awsv1alpha1.Account) []awsclient.AWSTag {
 	tags := []awsclient.AWSTag{}
 
+	poolTags := []awsclient.AWSTag{}
+	if template := r.getAccountTemplate(log, account); template != nil {
+		poolTags = parseTagsFromString(template.ManagedTags)
+	}
+
 	cm := &corev1.ConfigMap{}
 	err := r.Client.Get(context.TODO(), types.NamespacedName{Namespace: awsv1alpha1.AccountCrNamespace, Name: awsv1alpha1.DefaultConfigMap}, cm)
 	if err != nil {
 		log.Info("There was an error getting the default configmap.", "error", err)
-		return tags
+		return mergeTags(tags, poolTags)
 	}
 
 	managedTags, ok := cm.Data[awsv1alpha1.ManagedTagsConfigMapKey]
 	if !ok {
 		log.Info("There are no Managed Tags defined.")
-		return tags
+		return mergeTags(tags, poolTags)
 	}
 
-	return parseTagsFromString(managedTags)
+	return mergeTags(parseTagsFromString(managedTags), poolTags)
 }
 
 // getCustomTags retrieves a list of tags from the linked accountclaim
@@ -1235,7 +1452,7 @@ func (r *AccountReconciler) handleCreateAdminAccessRole(
 	// Build the tags required to create the Admin Access Role
 	tags := awsclient.AWSTags.BuildTags(
 		currentAcctInstance,
-		r.getManagedTags(reqLogger),
+		r.getManagedTags(reqLogger, currentAcctInstance),
 		r.getCustomTags(reqLogger, currentAcctInstance),
 	).GetIAMTags()
 
@@ -1321,7 +1538,9 @@ func (r *AccountReconciler) handleCreateAdminAccessRole(
 func (r *AccountReconciler) SetupWithManager(mgr ctrl.Manager) error {
 
 	r.awsClientBuilder = &awsclient.Builder{}
-	
+	r.recorder = mgr.GetEventRecorderFor(controllerName)
+	r.caseBatcher = newSupportCaseBatcher()
+
 	maxReconciles, err := utils.GetControllerMaxReconciles(controllerName)
 	if err != nil {
 		log.Error(err, "missing max reconciles for controller", "controller", controllerName)
@@ -2,6 +2,7 @@ package account
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"testing"
 	"time"
@@ -22,6 +23,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -1155,8 +1157,9 @@ func TestFinalizeAccount(t *testing.T) {
 			defer mocks.mockCtrl.Finish()
 
 			r := AccountReconciler{
-				Client: mocks.fakeKubeClient,
-				Scheme: scheme.Scheme,
+				Client:   mocks.fakeKubeClient,
+				Scheme:   scheme.Scheme,
+				recorder: &record.FakeRecorder{},
 			}
 
 			r.finalizeAccount(nullLogger, mockAWSClient, &test.acct.acct)
@@ -1194,8 +1197,9 @@ func TestFinalizeAccount_LabelledBYOCAccount(t *testing.T) {
 	defer mocks.mockCtrl.Finish()
 
 	r := AccountReconciler{
-		Client: mocks.fakeKubeClient,
-		Scheme: scheme.Scheme,
+		Client:   mocks.fakeKubeClient,
+		Scheme:   scheme.Scheme,
+		recorder: &record.FakeRecorder{},
 	}
 	r.finalizeAccount(nullLogger, mockAWSClient, &account)
 }
@@ -1242,6 +1246,7 @@ var _ = Describe("Account Controller", func() {
 				MockController: ctrl,
 			},
 			shardName: "hivename",
+			recorder:  &record.FakeRecorder{},
 		}
 	})
 
@@ -1254,40 +1259,40 @@ var _ = Describe("Account Controller", func() {
 		It("AWS returns ErrCodeConstraintViolationException from CreateAccount", func() {
 			// ErrCodeConstraintViolationException is mapped to awsv1alpha1.ErrAwsAccountLimitExceeded in CreateAccount
 			mockAWSClient.EXPECT().CreateAccount(gomock.Any()).Return(nil, awserr.New(organizations.ErrCodeConstraintViolationException, "Error String", nil))
-			createAccountOutput, err := CreateAccount(nullLogger, mockAWSClient, accountName, accountEmail)
+			requestID, err := CreateAccount(nullLogger, mockAWSClient, accountName, accountEmail)
 			Expect(err).To(HaveOccurred())
-			Expect(createAccountOutput).To(Equal(&organizations.DescribeCreateAccountStatusOutput{}))
+			Expect(requestID).To(BeEmpty())
 			Expect(awsv1alpha1.ErrAwsAccountLimitExceeded).To(Equal(err))
 		})
 
 		It("AWS returns ErrCodeServiceException from CreateAccount", func() {
 			// ErrCodeServiceException is mapped to awsv1alpha1.ErrAwsInternalFailure in CreateAccount
 			mockAWSClient.EXPECT().CreateAccount(gomock.Any()).Return(nil, awserr.New(organizations.ErrCodeServiceException, "Error String", nil))
-			createAccountOutput, err := CreateAccount(nullLogger, mockAWSClient, accountName, accountEmail)
+			requestID, err := CreateAccount(nullLogger, mockAWSClient, accountName, accountEmail)
 			Expect(err).To(HaveOccurred())
-			Expect(createAccountOutput).To(Equal(&organizations.DescribeCreateAccountStatusOutput{}))
+			Expect(requestID).To(BeEmpty())
 			Expect(awsv1alpha1.ErrAwsInternalFailure).To(Equal(err))
 		})
 
 		It("AWS returns ErrCodeTooManyRequestsException from CreateAccount", func() {
 			// ErrCodeTooManyRequestsException is mapped to awsv1alpha1.ErrAwsTooManyRequests in CreateAccount
 			mockAWSClient.EXPECT().CreateAccount(gomock.Any()).Return(nil, awserr.New(organizations.ErrCodeTooManyRequestsException, "Error String", nil))
-			createAccountOutput, err := CreateAccount(nullLogger, mockAWSClient, accountName, accountEmail)
+			requestID, err := CreateAccount(nullLogger, mockAWSClient, accountName, accountEmail)
 			Expect(err).To(HaveOccurred())
-			Expect(createAccountOutput).To(Equal(&organizations.DescribeCreateAccountStatusOutput{}))
+			Expect(requestID).To(BeEmpty())
 			Expect(awsv1alpha1.ErrAwsTooManyRequests).To(Equal(err))
 		})
 
 		It("AWS returns error from CreateAccount", func() {
 			// Unhandled AWS exceptions get mapped awsv1alpha1.ErrAwsFailedCreateAccount in CreateAccount
 			mockAWSClient.EXPECT().CreateAccount(gomock.Any()).Return(nil, awserr.New(organizations.ErrCodeDuplicateAccountException, "Error String", nil))
-			createAccountOutput, err := CreateAccount(nullLogger, mockAWSClient, accountName, accountEmail)
+			requestID, err := CreateAccount(nullLogger, mockAWSClient, accountName, accountEmail)
 			Expect(err).To(HaveOccurred())
-			Expect(createAccountOutput).To(Equal(&organizations.DescribeCreateAccountStatusOutput{}))
+			Expect(requestID).To(BeEmpty())
 			Expect(awsv1alpha1.ErrAwsFailedCreateAccount).To(Equal(err))
 		})
 
-		It("AWS returns an error from DescribeCreateAccountStatus", func() {
+		It("CreateAccount returns the request ID", func() {
 			mockAWSClient.EXPECT().CreateAccount(gomock.Any()).Return(
 				&organizations.CreateAccountOutput{
 					CreateAccountStatus: &organizations.CreateAccountStatus{
@@ -1296,24 +1301,21 @@ var _ = Describe("Account Controller", func() {
 				},
 				nil,
 			)
+			requestID, err := CreateAccount(nullLogger, mockAWSClient, accountName, accountEmail)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(requestID).To(Equal("ID"))
+		})
 
+		It("AWS returns an error from DescribeCreateAccountStatus", func() {
 			expectedErr := awserr.New(organizations.ErrCodeServiceException, "Error String", nil)
-			mockAWSClient.EXPECT().DescribeCreateAccountStatus(gomock.Any()).Return(nil, expectedErr) //errors.New("MyError")) //)
-			createAccountOutput, err := CreateAccount(nullLogger, mockAWSClient, accountName, accountEmail)
+			mockAWSClient.EXPECT().DescribeCreateAccountStatus(gomock.Any()).Return(nil, expectedErr)
+			createStatus, err := GetCreateAccountStatus(mockAWSClient, "ID")
 			Expect(err).To(HaveOccurred())
-			Expect(createAccountOutput).To(Equal(&organizations.DescribeCreateAccountStatusOutput{}))
+			Expect(createStatus).To(BeNil())
 			Expect(expectedErr).To(Equal(err))
 		})
 
 		It("DescribeCreateAccountStatus returns a FAILED state", func() {
-			mockAWSClient.EXPECT().CreateAccount(gomock.Any()).Return(
-				&organizations.CreateAccountOutput{
-					CreateAccountStatus: &organizations.CreateAccountStatus{
-						Id: aws.String("ID"),
-					},
-				},
-				nil,
-			)
 			describeCreateAccountStatusOutput := &organizations.DescribeCreateAccountStatusOutput{
 				CreateAccountStatus: &organizations.CreateAccountStatus{
 					State:         aws.String("FAILED"),
@@ -1321,31 +1323,123 @@ var _ = Describe("Account Controller", func() {
 				},
 			}
 			mockAWSClient.EXPECT().DescribeCreateAccountStatus(gomock.Any()).Return(describeCreateAccountStatusOutput, nil)
-			createAccountOutput, err := CreateAccount(nullLogger, mockAWSClient, accountName, accountEmail)
+			createStatus, err := GetCreateAccountStatus(mockAWSClient, "ID")
 			Expect(err).To(HaveOccurred())
-
-			Expect(createAccountOutput).To(Equal(&organizations.DescribeCreateAccountStatusOutput{}))
+			Expect(createStatus).To(BeNil())
 			Expect(awsv1alpha1.ErrAwsAccountLimitExceeded).To(Equal(err))
 		})
-		It("CreateAccount creates account", func() {
+
+		It("DescribeCreateAccountStatus returns a SUCCEEDED state", func() {
+			describeCreateAccountStatusOutput := &organizations.DescribeCreateAccountStatusOutput{
+				CreateAccountStatus: &organizations.CreateAccountStatus{
+					State:     aws.String("SUCCEEDED"),
+					AccountId: aws.String("123456789012"),
+				},
+			}
+			mockAWSClient.EXPECT().DescribeCreateAccountStatus(&organizations.DescribeCreateAccountStatusInput{
+				CreateAccountRequestId: aws.String("ID"),
+			}).Return(describeCreateAccountStatusOutput, nil)
+			createStatus, err := GetCreateAccountStatus(mockAWSClient, "ID")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(createStatus).To(Equal(describeCreateAccountStatusOutput.CreateAccountStatus))
+		})
+	})
+
+	Context("Testing BuildAccount", func() {
+		BeforeEach(func() {
+			account = &newTestAccountBuilder().WithoutState().acct
+			r.Client = fake.NewClientBuilder().WithScheme(scheme.Scheme).WithRuntimeObjects([]runtime.Object{account, configMap}...).Build()
+		})
+
+		It("Records the creation request and returns while it's in progress", func() {
 			mockAWSClient.EXPECT().CreateAccount(gomock.Any()).Return(
 				&organizations.CreateAccountOutput{
+					CreateAccountStatus: &organizations.CreateAccountStatus{Id: aws.String("car-1234")},
+				},
+				nil,
+			)
+			mockAWSClient.EXPECT().DescribeCreateAccountStatus(gomock.Any()).Return(
+				&organizations.DescribeCreateAccountStatusOutput{
+					CreateAccountStatus: &organizations.CreateAccountStatus{State: aws.String("IN_PROGRESS")},
+				},
+				nil,
+			)
+			awsAccountID, err := r.BuildAccount(nullLogger, mockAWSClient, account)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(awsAccountID).To(BeEmpty())
+
+			updated := awsv1alpha1.Account{}
+			Expect(r.Client.Get(context.TODO(), types.NamespacedName{Namespace: account.Namespace, Name: account.Name}, &updated)).To(Succeed())
+			Expect(updated.Status.CreateAccountRequestID).To(Equal("car-1234"))
+			Expect(updated.Status.CreateAccountRequestTime).ToNot(BeNil())
+		})
+
+		It("Polls the recorded request without creating another account", func() {
+			now := metav1.Now()
+			account.Status.CreateAccountRequestID = "car-1234"
+			account.Status.CreateAccountRequestTime = &now
+			mockAWSClient.EXPECT().DescribeCreateAccountStatus(&organizations.DescribeCreateAccountStatusInput{
+				CreateAccountRequestId: aws.String("car-1234"),
+			}).Return(
+				&organizations.DescribeCreateAccountStatusOutput{
 					CreateAccountStatus: &organizations.CreateAccountStatus{
-						Id: aws.String("ID"),
+						State:     aws.String("SUCCEEDED"),
+						AccountId: aws.String("123456789012"),
 					},
 				},
 				nil,
 			)
-			describeCreateAccountStatusOutput := &organizations.DescribeCreateAccountStatusOutput{
-				CreateAccountStatus: &organizations.CreateAccountStatus{
-					State: aws.String("SUCCEEDED"),
+			awsAccountID, err := r.BuildAccount(nullLogger, mockAWSClient, account)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(awsAccountID).To(Equal("123456789012"))
+		})
+
+		It("Retries a request that failed for a transient reason", func() {
+			now := metav1.Now()
+			account.Status.CreateAccountRequestID = "car-1234"
+			account.Status.CreateAccountRequestTime = &now
+			Expect(r.Client.Status().Update(context.TODO(), account)).To(Succeed())
+			mockAWSClient.EXPECT().DescribeCreateAccountStatus(gomock.Any()).Return(
+				&organizations.DescribeCreateAccountStatusOutput{
+					CreateAccountStatus: &organizations.CreateAccountStatus{
+						State:         aws.String("FAILED"),
+						FailureReason: aws.String("CONCURRENT_ACCOUNT_MODIFICATION"),
+					},
 				},
-			}
-			mockAWSClient.EXPECT().DescribeCreateAccountStatus(gomock.Any()).Return(describeCreateAccountStatusOutput, nil)
-			createAccountOutput, err := CreateAccount(nullLogger, mockAWSClient, accountName, accountEmail)
-			Expect(err).To(Succeed())
-			Expect(createAccountOutput).To(Equal(describeCreateAccountStatusOutput))
-			Expect(err).Should(BeNil())
+				nil,
+			)
+			_, err := r.BuildAccount(nullLogger, mockAWSClient, account)
+			Expect(errors.Is(err, awsv1alpha1.ErrAwsFailedCreateAccount)).To(BeTrue())
+
+			updated := awsv1alpha1.Account{}
+			Expect(r.Client.Get(context.TODO(), types.NamespacedName{Namespace: account.Namespace, Name: account.Name}, &updated)).To(Succeed())
+			Expect(updated.Status.State).To(Equal(AccountRetrying))
+			Expect(updated.Status.Retry.LastFailureReason).To(Equal("CONCURRENT_ACCOUNT_MODIFICATION"))
+			Expect(updated.Status.CreateAccountRequestID).To(BeEmpty())
+		})
+
+		It("Fails the account when the request failed for a fatal reason", func() {
+			now := metav1.Now()
+			account.Status.CreateAccountRequestID = "car-1234"
+			account.Status.CreateAccountRequestTime = &now
+			Expect(r.Client.Status().Update(context.TODO(), account)).To(Succeed())
+			mockAWSClient.EXPECT().DescribeCreateAccountStatus(gomock.Any()).Return(
+				&organizations.DescribeCreateAccountStatusOutput{
+					CreateAccountStatus: &organizations.CreateAccountStatus{
+						State:         aws.String("FAILED"),
+						FailureReason: aws.String("EMAIL_ALREADY_EXISTS"),
+					},
+				},
+				nil,
+			)
+			_, err := r.BuildAccount(nullLogger, mockAWSClient, account)
+			Expect(errors.Is(err, awsv1alpha1.ErrAwsFailedCreateAccount)).To(BeTrue())
+
+			updated := awsv1alpha1.Account{}
+			Expect(r.Client.Get(context.TODO(), types.NamespacedName{Namespace: account.Namespace, Name: account.Name}, &updated)).To(Succeed())
+			Expect(updated.Status.State).To(Equal(AccountFailed))
+			Expect(updated.Status.Retry.LastFailureReason).To(Equal("EMAIL_ALREADY_EXISTS"))
+			Expect(updated.Status.Retry.NextRetryTime).To(BeNil())
 		})
 	})
 
@@ -1415,4 +1509,30 @@ var _ = Describe("Account Controller", func() {
 		})
 
 	})
+
+	Context("Testing Events", func() {
+		var recorder *record.FakeRecorder
+
+		BeforeEach(func() {
+			recorder = record.NewFakeRecorder(10)
+			r.recorder = recorder
+			account = &newTestAccountBuilder().WithState(awsv1alpha1.AccountCreating).acct
+		})
+
+		It("records an event when the account state changes", func() {
+			r.setAccountStatus(account, "Account ready to be claimed", awsv1alpha1.AccountReady, AccountReady)
+			Expect(account.Status.State).To(Equal(AccountReady))
+			Expect(recorder.Events).To(Receive(Equal("Normal Ready Account ready to be claimed")))
+		})
+
+		It("records a warning when the account fails", func() {
+			r.setAccountStatus(account, "Failed to create AWS Account", awsv1alpha1.AccountCreationFailed, AccountFailed)
+			Expect(recorder.Events).To(Receive(Equal("Warning AccountCreationFailed Failed to create AWS Account")))
+		})
+
+		It("doesn't record an event when the state is unchanged", func() {
+			r.setAccountStatus(account, AccountCreating, awsv1alpha1.AccountCreating, AccountCreating)
+			Expect(recorder.Events).ToNot(Receive())
+		})
+	})
 })
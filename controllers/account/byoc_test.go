@@ -20,6 +20,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -318,8 +319,9 @@ func TestClaimBYOCAccount(t *testing.T) {
 				defer mocks.mockCtrl.Finish()
 
 				r := AccountReconciler{
-					Client: mocks.fakeKubeClient,
-					Scheme: scheme.Scheme,
+					Client:   mocks.fakeKubeClient,
+					Scheme:   scheme.Scheme,
+					recorder: &record.FakeRecorder{},
 				}
 
 				result := claimBYOCAccount(&r, nullLogger, test.acct)
@@ -458,8 +460,9 @@ func TestInitializeNewCCSAccount(t *testing.T) {
 				defer mocks.mockCtrl.Finish()
 
 				r := AccountReconciler{
-					Client: mocks.fakeKubeClient,
-					Scheme: scheme.Scheme,
+					Client:   mocks.fakeKubeClient,
+					Scheme:   scheme.Scheme,
+					recorder: &record.FakeRecorder{},
 				}
 				_, err = r.initializeNewCCSAccount(nullLogger, test.acct)
 				if test.errExpected {
@@ -529,8 +532,9 @@ func TestGetSREAccessARN(t *testing.T) {
 				defer mocks.mockCtrl.Finish()
 
 				r := AccountReconciler{
-					Client: mocks.fakeKubeClient,
-					Scheme: scheme.Scheme,
+					Client:   mocks.fakeKubeClient,
+					Scheme:   scheme.Scheme,
+					recorder: &record.FakeRecorder{},
 				}
 
 				retVal, err := r.GetSREAccessARN(nullLogger, test.arnName)
@@ -0,0 +1,311 @@
+package account
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/support"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	awsv1alpha1 "github.com/ravitri/aws-account-operator/api/v1alpha1"
+	"github.com/ravitri/aws-account-operator/pkg/awsclient"
+	"github.com/ravitri/aws-account-operator/pkg/utils"
+)
+
+const (
+	// caseDescribeCacheTTL is how long the details of a case shared by a batch of accounts are reused,
+	// so the accounts of a batch don't each describe it
+	caseDescribeCacheTTL = time.Minute
+	// caseBatchPollInterval is the wait between two checks of an account whose batch owner didn't open
+	// the case yet
+	caseBatchPollInterval = 30 * time.Second
+)
+
+// supportCaseBatcher opens a single support case for the accounts that start waiting for
+// Enterprise Support within the same window. The accounts of a batch are annotated with the account
+// opening the case, their owner, before it is opened, and pick the case ID up from it.
+type supportCaseBatcher struct {
+	mutex sync.Mutex
+	// assigned holds the case the owner of a batch opened, until the account status with the case
+	// ID is read back from the cache
+	assigned map[string]batchedCase
+	// cases holds the last details of the cases shared by a batch of accounts
+	cases map[string]cachedCase
+}
+
+type batchedCase struct {
+	caseID      string
+	supportCase awsv1alpha1.AccountSupportCase
+}
+
+type cachedCase struct {
+	details *support.CaseDetails
+	time    time.Time
+}
+
+func newSupportCaseBatcher() *supportCaseBatcher {
+	return &supportCaseBatcher{
+		assigned: map[string]batchedCase{},
+		cases:    map[string]cachedCase{},
+	}
+}
+
+// forget drops the case assigned to the account once its status holds it
+func (b *supportCaseBatcher) forget(accountName string) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	delete(b.assigned, accountName)
+}
+
+// describeCase returns the details of the case, described at most once per caseDescribeCacheTTL
+func (b *supportCaseBatcher) describeCase(reqLogger logr.Logger, caseID string, client awsclient.Client) (*support.CaseDetails, error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	if cached, ok := b.cases[caseID]; ok && time.Since(cached.time) < caseDescribeCacheTTL {
+		return cached.details, nil
+	}
+	details, err := describeCase(reqLogger, caseID, client)
+	if err != nil {
+		return nil, err
+	}
+	for id, cached := range b.cases {
+		if time.Since(cached.time) >= caseDescribeCacheTTL {
+			delete(b.cases, id)
+		}
+	}
+	b.cases[caseID] = cachedCase{details: details, time: time.Now()}
+	return details, nil
+}
+
+// caseWaitingSince returns when the account started waiting for its support case
+func caseWaitingSince(account *awsv1alpha1.Account) time.Time {
+	if condition := account.GetCondition(awsv1alpha1.AccountPendingVerification); condition != nil {
+		return condition.LastTransitionTime.Time
+	}
+	return account.CreationTimestamp.Time
+}
+
+// caseBatchCandidates returns the accounts waiting for a support case that can join the batch of the
+// account, the account first and then the ones waiting the longest
+func caseBatchCandidates(account *awsv1alpha1.Account, accounts []awsv1alpha1.Account, batchMax int) []awsv1alpha1.Account {
+	candidates := []awsv1alpha1.Account{*account}
+	others := []awsv1alpha1.Account{}
+	for _, other := range accounts {
+		if other.Name == account.Name || other.IsBYOC() || !other.IsPendingVerification() || other.HasSupportCaseID() || other.DeletionTimestamp != nil {
+			continue
+		}
+		if caseBatchOwner(&other) != "" {
+			continue
+		}
+		others = append(others, other)
+	}
+	sort.SliceStable(others, func(i, j int) bool {
+		return caseWaitingSince(&others[i]).Before(caseWaitingSince(&others[j]))
+	})
+	candidates = append(candidates, others...)
+	if len(candidates) > batchMax {
+		candidates = candidates[:batchMax]
+	}
+	return candidates
+}
+
+// caseBatchOwner returns the account opening the support case of the batch of the account, if any
+func caseBatchOwner(account *awsv1alpha1.Account) string {
+	return account.GetAnnotations()[awsv1alpha1.CaseBatchOwnerAnnotation]
+}
+
+// setCaseBatchOwner annotates the account with the owner of its batch, or removes the annotation
+// when the owner is empty
+func (r *AccountReconciler) setCaseBatchOwner(account *awsv1alpha1.Account, owner string) error {
+	annotations := account.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	if owner == "" {
+		delete(annotations, awsv1alpha1.CaseBatchOwnerAnnotation)
+	} else {
+		annotations[awsv1alpha1.CaseBatchOwnerAnnotation] = owner
+	}
+	account.SetAnnotations(annotations)
+	return r.Client.Update(context.TODO(), account)
+}
+
+// handleBatchedCase opens one support case for the batch of accounts waiting for one. An account without
+// batch assigns one to itself once the batch window is over, the owner of a batch opens its case, and the
+// other accounts of the batch record the case from their owner.
+func (r *AccountReconciler) handleBatchedCase(reqLogger logr.Logger, account *awsv1alpha1.Account, awsClient awsclient.Client, caseTmpl caseTemplate) (reconcile.Result, error) {
+	// The case of a batch opened by the account, not yet in the cache the account was read from
+	b := r.caseBatcher
+	b.mutex.Lock()
+	opened, ok := b.assigned[account.Name]
+	b.mutex.Unlock()
+	if ok {
+		account.Status.SupportCaseID = opened.caseID
+		account.Status.SupportCase = opened.supportCase.DeepCopy()
+		return reconcile.Result{RequeueAfter: intervalAfterCaseCreationSecs * time.Second}, r.statusUpdate(account)
+	}
+
+	switch owner := caseBatchOwner(account); owner {
+	case "":
+		batch, result, err := r.assignCaseBatch(reqLogger, account, caseTmpl)
+		if err != nil || len(batch) == 0 {
+			return result, err
+		}
+		return r.openBatchedCase(reqLogger, account, awsClient, caseTmpl, batch)
+	case account.Name:
+		// Opening the case failed before, it's opened again for the accounts still assigned to the batch
+		batch, err := r.caseBatchMembers(account)
+		if err != nil {
+			reqLogger.Error(err, "failed listing the accounts of the support case batch")
+			return reconcile.Result{}, err
+		}
+		return r.openBatchedCase(reqLogger, account, awsClient, caseTmpl, batch)
+	default:
+		return r.joinBatchedCase(reqLogger, account, owner)
+	}
+}
+
+// assignCaseBatch waits for the batch window of the accounts waiting for a support case, then assigns
+// them to the batch of the account by annotating them with it. The annotations are written before the
+// case is opened, and an account read from a stale cache can't be assigned to two batches as its update
+// conflicts. It returns the accounts of the batch, the account first, or none while the window is open.
+func (r *AccountReconciler) assignCaseBatch(reqLogger logr.Logger, account *awsv1alpha1.Account, caseTmpl caseTemplate) ([]awsv1alpha1.Account, reconcile.Result, error) {
+	b := r.caseBatcher
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	accounts := &awsv1alpha1.AccountList{}
+	err := r.Client.List(context.TODO(), accounts, client.InNamespace(account.Namespace))
+	if err != nil {
+		reqLogger.Error(err, "failed listing the accounts waiting for a support case")
+		return nil, reconcile.Result{}, err
+	}
+	candidates := caseBatchCandidates(account, accounts.Items, caseTmpl.BatchMax)
+
+	waitingSince := caseWaitingSince(account)
+	for i := range candidates {
+		if since := caseWaitingSince(&candidates[i]); since.Before(waitingSince) {
+			waitingSince = since
+		}
+	}
+	if waited := time.Since(waitingSince); len(candidates) < caseTmpl.BatchMax && waited < caseTmpl.BatchWindow {
+		reqLogger.Info("waiting for more accounts to open a support case for", "accounts", len(candidates), "remaining", caseTmpl.BatchWindow-waited)
+		return nil, reconcile.Result{RequeueAfter: caseTmpl.BatchWindow - waited}, nil
+	}
+
+	// The account owns its batch first, the other candidates only join it if they weren't assigned
+	// to another batch since they were read
+	if err := r.setCaseBatchOwner(account, account.Name); err != nil {
+		reqLogger.Error(err, "failed assigning the account to its support case batch")
+		return nil, reconcile.Result{}, err
+	}
+	batch := []awsv1alpha1.Account{*account}
+	for i := range candidates[1:] {
+		member := &candidates[i+1]
+		if err := r.setCaseBatchOwner(member, account.Name); err != nil {
+			reqLogger.Info("failed assigning an account to the support case batch, it will join another one", "account", member.Name, "error", err.Error())
+			continue
+		}
+		batch = append(batch, *member)
+	}
+	return batch, reconcile.Result{}, nil
+}
+
+// caseBatchMembers returns the accounts assigned to the batch of the account that still wait for the
+// case, the account first
+func (r *AccountReconciler) caseBatchMembers(account *awsv1alpha1.Account) ([]awsv1alpha1.Account, error) {
+	accounts := &awsv1alpha1.AccountList{}
+	err := r.Client.List(context.TODO(), accounts, client.InNamespace(account.Namespace))
+	if err != nil {
+		return nil, err
+	}
+	batch := []awsv1alpha1.Account{*account}
+	for _, member := range accounts.Items {
+		if member.Name != account.Name && caseBatchOwner(&member) == account.Name && !member.HasSupportCaseID() && member.DeletionTimestamp == nil {
+			batch = append(batch, member)
+		}
+	}
+	return batch, nil
+}
+
+// openBatchedCase opens the support case of the batch of the account and records it in the account
+// status. The other accounts of the batch record it from the account on their next reconcile.
+func (r *AccountReconciler) openBatchedCase(reqLogger logr.Logger, account *awsv1alpha1.Account, awsClient awsclient.Client, caseTmpl caseTemplate, batch []awsv1alpha1.Account) (reconcile.Result, error) {
+	data := caseBatchData{}
+	for _, member := range batch {
+		data.Accounts = append(data.Accounts, caseTemplateData{AccountID: member.Spec.AwsAccountID, Name: member.Name})
+	}
+	subject, err := executeCaseTemplate("batch subject", caseTmpl.BatchSubject, data)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	body, err := executeCaseTemplate("batch body", caseTmpl.BatchBody, data)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	caseID, err := openCase(reqLogger, awsClient, caseTmpl, subject, body)
+	if err != nil {
+		r.recorder.Eventf(account, corev1.EventTypeWarning, utils.EventReasonSupportCaseFailed, "Failed to create support case: %v", err)
+		return reconcile.Result{}, err
+	}
+	reqLogger.Info("Support case created for a batch of accounts", "CaseID", caseID, "accounts", len(batch))
+	r.recorder.Eventf(account, corev1.EventTypeNormal, utils.EventReasonSupportCaseCreated, "Created support case %s to enable Enterprise Support on %d accounts", caseID, len(batch))
+
+	// The batcher remembers the case until the account status holds it, so the account read from a
+	// stale cache doesn't open another case
+	supportCase := awsv1alpha1.AccountSupportCase{BatchOwner: account.Name, BatchSize: len(batch)}
+	b := r.caseBatcher
+	b.mutex.Lock()
+	b.assigned[account.Name] = batchedCase{caseID: caseID, supportCase: supportCase}
+	b.mutex.Unlock()
+
+	account.Status.SupportCaseID = caseID
+	account.Status.SupportCase = supportCase.DeepCopy()
+	r.setAccountStatus(account, "Account pending verification in AWS", awsv1alpha1.AccountPendingVerification, AccountPendingVerification)
+	err = r.statusUpdate(account)
+	if err != nil {
+		reqLogger.Error(err, "failed to update account state, retrying", "desired state", AccountPendingVerification)
+		return reconcile.Result{}, err
+	}
+
+	return reconcile.Result{RequeueAfter: intervalAfterCaseCreationSecs * time.Second}, nil
+}
+
+// joinBatchedCase records the case opened by the owner of the batch of the account, once there is one.
+// The account leaves the batch when its owner is gone or stopped waiting for a case, to join another one.
+func (r *AccountReconciler) joinBatchedCase(reqLogger logr.Logger, account *awsv1alpha1.Account, ownerName string) (reconcile.Result, error) {
+	owner := &awsv1alpha1.Account{}
+	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: ownerName, Namespace: account.Namespace}, owner)
+	if err != nil && !k8serr.IsNotFound(err) {
+		reqLogger.Error(err, "failed getting the owner of the support case batch", "owner", ownerName)
+		return reconcile.Result{}, err
+	}
+
+	opened := err == nil && owner.HasSupportCaseID() && owner.Status.SupportCase != nil && owner.Status.SupportCase.BatchOwner == owner.Name
+	if !opened {
+		if err == nil && owner.DeletionTimestamp == nil && owner.IsPendingVerification() {
+			reqLogger.Info("waiting for the owner of the batch to open the support case", "owner", ownerName)
+			return reconcile.Result{RequeueAfter: caseBatchPollInterval}, nil
+		}
+		reqLogger.Info("the owner of the support case batch is gone, leaving the batch", "owner", ownerName)
+		return reconcile.Result{Requeue: true}, r.setCaseBatchOwner(account, "")
+	}
+
+	account.Status.SupportCaseID = owner.Status.SupportCaseID
+	account.Status.SupportCase = &awsv1alpha1.AccountSupportCase{BatchOwner: owner.Name, BatchSize: owner.Status.SupportCase.BatchSize}
+	r.recorder.Eventf(account, corev1.EventTypeNormal, utils.EventReasonSupportCaseCreated, "Support case %s opened by %s enables Enterprise Support on %d accounts", account.Status.SupportCaseID, owner.Name, owner.Status.SupportCase.BatchSize)
+	r.setAccountStatus(account, "Account pending verification in AWS", awsv1alpha1.AccountPendingVerification, AccountPendingVerification)
+	err = r.statusUpdate(account)
+	if err != nil {
+		reqLogger.Error(err, "failed to update account state, retrying", "desired state", AccountPendingVerification)
+		return reconcile.Result{}, err
+	}
+	return reconcile.Result{RequeueAfter: intervalAfterCaseCreationSecs * time.Second}, nil
+}
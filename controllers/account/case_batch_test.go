@@ -0,0 +1,194 @@
+package account
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/support"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+
+	apis "github.com/ravitri/aws-account-operator/api"
+	awsv1alpha1 "github.com/ravitri/aws-account-operator/api/v1alpha1"
+	"github.com/ravitri/aws-account-operator/pkg/testutils"
+)
+
+// newPendingVerificationAccount returns a non-CCS account waiting for its support case for the given time
+func newPendingVerificationAccount(name string, awsAccountID string, waiting time.Duration) *awsv1alpha1.Account {
+	account := newTestAccountBuilder().acct
+	account.Name = name
+	account.Spec.AwsAccountID = awsAccountID
+	account.Status.State = AccountPendingVerification
+	account.Status.Conditions = []awsv1alpha1.AccountCondition{{
+		Type:               awsv1alpha1.AccountPendingVerification,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.NewTime(time.Now().Add(-waiting)),
+	}}
+	return &account
+}
+
+func TestHandleBatchedCase(t *testing.T) {
+	err := apis.AddToScheme(scheme.Scheme)
+	assert.NoError(t, err)
+
+	oldest := newPendingVerificationAccount("osd-creds-mgmt-aaaaaa", "111111111111", 2*time.Hour)
+	newest := newPendingVerificationAccount("osd-creds-mgmt-bbbbbb", "222222222222", 10*time.Minute)
+	withCase := newPendingVerificationAccount("osd-creds-mgmt-cccccc", "333333333333", 3*time.Hour)
+	withCase.Status.SupportCaseID = "case-0"
+	ccs := newPendingVerificationAccount("osd-creds-mgmt-dddddd", "444444444444", 3*time.Hour)
+	ccs.Spec.BYOC = true
+
+	mocks := setupDefaultMocks(t, []runtime.Object{oldest, newest, withCase, ccs})
+	defer mocks.mockCtrl.Finish()
+	r := &AccountReconciler{
+		Client:      mocks.fakeKubeClient,
+		Scheme:      scheme.Scheme,
+		recorder:    record.NewFakeRecorder(10),
+		caseBatcher: newSupportCaseBatcher(),
+	}
+	logger := testutils.NewTestLogger().Logger()
+	caseTmpl := getCaseTemplate(logger, &corev1.ConfigMap{Data: map[string]string{caseBatchWindowConfigMapKey: "3h"}})
+
+	// The oldest account waits for the window
+	result, err := r.handleBatchedCase(logger, newest.DeepCopy(), mocks.mockAWSClient, caseTmpl)
+	assert.NoError(t, err)
+	assert.InDelta(t, time.Hour.Seconds(), result.RequeueAfter.Seconds(), 60)
+
+	// Once it is over, one case is opened for every account waiting for one
+	caseTmpl.BatchWindow = time.Hour
+	mocks.mockAWSClient.EXPECT().CreateCase(gomock.Any()).DoAndReturn(
+		func(input *support.CreateCaseInput) (*support.CreateCaseOutput, error) {
+			assert.Equal(t, "Add 2 accounts to Enterprise Support", aws.StringValue(input.Subject))
+			assert.True(t, strings.Index(aws.StringValue(input.CommunicationBody), "222222222222") < strings.Index(aws.StringValue(input.CommunicationBody), "111111111111"))
+			assert.NotContains(t, aws.StringValue(input.CommunicationBody), "333333333333")
+			assert.NotContains(t, aws.StringValue(input.CommunicationBody), "444444444444")
+			return &support.CreateCaseOutput{CaseId: aws.String("case-1")}, nil
+		})
+	_, err = r.handleBatchedCase(logger, newest.DeepCopy(), mocks.mockAWSClient, caseTmpl)
+	assert.NoError(t, err)
+
+	// The owner records the case, the other accounts of the batch are only assigned to it
+	owner := getAccount(t, r, newest.Name)
+	assert.Equal(t, "case-1", owner.Status.SupportCaseID)
+	assert.Equal(t, &awsv1alpha1.AccountSupportCase{BatchOwner: newest.Name, BatchSize: 2}, owner.Status.SupportCase)
+	member := getAccount(t, r, oldest.Name)
+	assert.Equal(t, newest.Name, member.Annotations[awsv1alpha1.CaseBatchOwnerAnnotation])
+	assert.Empty(t, member.Status.SupportCaseID)
+	assert.Empty(t, getAccount(t, r, withCase.Name).Annotations)
+
+	// They record the case from the owner on their next reconcile
+	_, err = r.handleBatchedCase(logger, member, mocks.mockAWSClient, caseTmpl)
+	assert.NoError(t, err)
+	member = getAccount(t, r, oldest.Name)
+	assert.Equal(t, "case-1", member.Status.SupportCaseID)
+	assert.Equal(t, &awsv1alpha1.AccountSupportCase{BatchOwner: newest.Name, BatchSize: 2}, member.Status.SupportCase)
+
+	// The owner read before its case was recorded picks it up instead of opening another case
+	stale := newest.DeepCopy()
+	stale.ResourceVersion = owner.ResourceVersion
+	_, err = r.handleBatchedCase(logger, stale, mocks.mockAWSClient, caseTmpl)
+	assert.NoError(t, err)
+	assert.Equal(t, "case-1", stale.Status.SupportCaseID)
+}
+
+// getAccount returns the account with the name from the client of the reconciler
+func getAccount(t *testing.T, r *AccountReconciler, name string) *awsv1alpha1.Account {
+	account := &awsv1alpha1.Account{}
+	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: TestAccountNamespace}, account)
+	assert.NoError(t, err)
+	return account
+}
+
+func TestHandleBatchedCaseFailures(t *testing.T) {
+	err := apis.AddToScheme(scheme.Scheme)
+	assert.NoError(t, err)
+
+	owner := newPendingVerificationAccount("osd-creds-mgmt-aaaaaa", "111111111111", 2*time.Hour)
+	member := newPendingVerificationAccount("osd-creds-mgmt-bbbbbb", "222222222222", 2*time.Hour)
+	mocks := setupDefaultMocks(t, []runtime.Object{owner, member})
+	defer mocks.mockCtrl.Finish()
+	r := &AccountReconciler{
+		Client:      mocks.fakeKubeClient,
+		Scheme:      scheme.Scheme,
+		recorder:    record.NewFakeRecorder(10),
+		caseBatcher: newSupportCaseBatcher(),
+	}
+	logger := testutils.NewTestLogger().Logger()
+	caseTmpl := getCaseTemplate(logger, &corev1.ConfigMap{Data: map[string]string{caseBatchWindowConfigMapKey: "1h"}})
+
+	staleMember := getAccount(t, r, member.Name)
+
+	// The batch is assigned before the case is opened, so it survives a failure to open it
+	mocks.mockAWSClient.EXPECT().CreateCase(gomock.Any()).Return(nil, awserr.New("InternalServerError", "try again", nil))
+	_, err = r.handleBatchedCase(logger, getAccount(t, r, owner.Name), mocks.mockAWSClient, caseTmpl)
+	assert.Error(t, err)
+	assert.Equal(t, owner.Name, getAccount(t, r, member.Name).Annotations[awsv1alpha1.CaseBatchOwnerAnnotation])
+
+	// The members wait for their owner
+	result, err := r.handleBatchedCase(logger, getAccount(t, r, member.Name), mocks.mockAWSClient, caseTmpl)
+	assert.NoError(t, err)
+	assert.Equal(t, caseBatchPollInterval, result.RequeueAfter)
+
+	// A member read from a stale cache can't assign itself to another batch
+	_, err = r.handleBatchedCase(logger, staleMember, mocks.mockAWSClient, caseTmpl)
+	assert.True(t, k8serr.IsConflict(err))
+	assert.Equal(t, owner.Name, getAccount(t, r, member.Name).Annotations[awsv1alpha1.CaseBatchOwnerAnnotation])
+
+	// The owner opens the case again for its batch
+	mocks.mockAWSClient.EXPECT().CreateCase(gomock.Any()).DoAndReturn(
+		func(input *support.CreateCaseInput) (*support.CreateCaseOutput, error) {
+			assert.Equal(t, "Add 2 accounts to Enterprise Support", aws.StringValue(input.Subject))
+			return &support.CreateCaseOutput{CaseId: aws.String("case-1")}, nil
+		})
+	_, err = r.handleBatchedCase(logger, getAccount(t, r, owner.Name), mocks.mockAWSClient, caseTmpl)
+	assert.NoError(t, err)
+	assert.Equal(t, "case-1", getAccount(t, r, owner.Name).Status.SupportCaseID)
+
+	// Accounts whose owner is gone leave the batch
+	err = r.Client.Delete(context.TODO(), getAccount(t, r, owner.Name))
+	assert.NoError(t, err)
+	_, err = r.handleBatchedCase(logger, getAccount(t, r, member.Name), mocks.mockAWSClient, caseTmpl)
+	assert.NoError(t, err)
+	assert.NotContains(t, getAccount(t, r, member.Name).Annotations, awsv1alpha1.CaseBatchOwnerAnnotation)
+}
+
+func TestBatchedCaseFollowUps(t *testing.T) {
+	mocks := setupDefaultMocks(t, []runtime.Object{})
+	defer mocks.mockCtrl.Finish()
+	r := &AccountReconciler{recorder: record.NewFakeRecorder(10), caseBatcher: newSupportCaseBatcher()}
+	logger := testutils.NewTestLogger().Logger()
+	caseTmpl := getCaseTemplate(logger, nil)
+
+	owner := newPendingVerificationAccount("osd-creds-mgmt-aaaaaa", "111111111111", time.Hour)
+	member := newPendingVerificationAccount("osd-creds-mgmt-bbbbbb", "222222222222", time.Hour)
+	for _, account := range []*awsv1alpha1.Account{owner, member} {
+		account.Status.SupportCaseID = "case-1"
+		account.Status.SupportCase = &awsv1alpha1.AccountSupportCase{BatchOwner: owner.Name, BatchSize: 2}
+	}
+
+	// The case is described once for the batch, and only its owner follows it up
+	mocks.mockAWSClient.EXPECT().DescribeCases(gomock.Any()).Return(&support.DescribeCasesOutput{Cases: []*support.CaseDetails{{
+		CaseId: aws.String("case-1"),
+		Status: aws.String(caseStatusPendingCustomer),
+	}}}, nil).Times(1)
+	mocks.mockAWSClient.EXPECT().AddCommunicationToCase(gomock.Any()).Return(&support.AddCommunicationToCaseOutput{Result: aws.Bool(true)}, nil).Times(1)
+
+	_, _, err := r.trackCase(logger, member, mocks.mockAWSClient, caseTmpl)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, member.Status.SupportCase.FollowUps)
+	_, _, err = r.trackCase(logger, owner, mocks.mockAWSClient, caseTmpl)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, owner.Status.SupportCase.FollowUps)
+	assert.Equal(t, caseStatusPendingCustomer, member.Status.SupportCase.Status)
+}
@@ -1,12 +1,18 @@
 package account
 
 import (
+	"bytes"
 	"fmt"
+	"strconv"
+	"text/template"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/support"
 	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/ravitri/aws-account-operator/api/v1alpha1"
 	"github.com/ravitri/aws-account-operator/pkg/awsclient"
@@ -20,44 +26,218 @@ const (
 	caseIssueType                 = "customer-service"
 	caseSeverity                  = "high"
 	caseStatusResolved            = "resolved"
+	caseStatusPendingCustomer     = "pending-customer-action"
 	caseLanguage                  = "en"
 	intervalAfterCaseCreationSecs = 30
 	intervalBetweenChecksMinutes  = 10
-)
 
-func createCase(reqLogger logr.Logger, account *v1alpha1.Account, client awsclient.Client) (string, error) {
-	accountID := account.Spec.AwsAccountID
+	// Operator configmap keys of the support case template, see caseTemplate
+	caseCategoryCodeConfigMapKey  = "support-case.category-code"
+	caseServiceCodeConfigMapKey   = "support-case.service-code"
+	caseIssueTypeConfigMapKey     = "support-case.issue-type"
+	caseSeverityConfigMapKey      = "support-case.severity"
+	caseLanguageConfigMapKey      = "support-case.language"
+	caseSubjectConfigMapKey       = "support-case.subject"
+	caseBodyConfigMapKey          = "support-case.body"
+	caseFollowUpAfterConfigMapKey = "support-case.follow-up-after"
+	caseFollowUpBodyConfigMapKey  = "support-case.follow-up-body"
+	caseBatchWindowConfigMapKey   = "support-case.batch-window"
+	caseBatchMaxConfigMapKey      = "support-case.batch-max-accounts"
+	caseBatchSubjectConfigMapKey  = "support-case.batch-subject"
+	caseBatchBodyConfigMapKey     = "support-case.batch-body"
 
-	// Initialize basic communication body and case subject
-	caseCommunicationBody := fmt.Sprintf(
-		`Hello AWS,
+	defaultCaseSubject = "Add account {{.AccountID}} to Enterprise Support"
+	defaultCaseBody    = `Hello AWS,
 
-Please enable Enterprise Support on AWS account %s.
+Please enable Enterprise Support on AWS account {{.AccountID}}.
 
 Once this has been completed and the default EC2 limits are ready for use, please resolve this support case. Please do not set the case to Pending Customer Action.
 
 Thanks.
 
-[rh-internal-account-name: %s]`, accountID, account.Name,
-	)
+[rh-internal-account-name: {{.Name}}]`
+	// defaultCaseFollowUpAfter is how long a case can stay in the same status before a follow-up is added
+	defaultCaseFollowUpAfter = 24 * time.Hour
+	defaultCaseFollowUpBody  = `Hello AWS,
+
+This case has been {{.Status}} for a while. Please complete the Enterprise Support enablement requested in this case and resolve it once it's done. No further action is needed on our side.
+
+Thanks.
+
+[rh-internal-account-name: {{.Name}}]`
+
+	// defaultCaseBatchMax is the most accounts a single case is opened for
+	defaultCaseBatchMax     = 50
+	defaultCaseBatchSubject = "Add {{len .Accounts}} accounts to Enterprise Support"
+	defaultCaseBatchBody    = `Hello AWS,
+
+Please enable Enterprise Support on the following AWS accounts:
+{{range .Accounts}}
+- {{.AccountID}} [rh-internal-account-name: {{.Name}}]{{end}}
+
+Once this has been completed and the default EC2 limits are ready for use on all of them, please resolve this support case. Please do not set the case to Pending Customer Action.
+
+Thanks.`
+)
+
+// caseTemplate is how the support cases are opened and followed up. Every field can be set in the
+// operator configmap, and defaults to the values the operator always used.
+type caseTemplate struct {
+	CategoryCode string
+	ServiceCode  string
+	IssueType    string
+	Severity     string
+	Language     string
+	// Subject, Body and FollowUpBody are Go templates executed with caseTemplateData
+	Subject      string
+	Body         string
+	FollowUpBody string
+	// FollowUpAfter is how long a case can stay in the same status without a follow-up. Zero
+	// disables the follow-ups.
+	FollowUpAfter time.Duration
+	// BatchWindow is how long accounts wait for others to share a case with. Zero opens a case
+	// per account.
+	BatchWindow time.Duration
+	// BatchMax is the most accounts a case is opened for. A full batch doesn't wait for the window.
+	BatchMax int
+	// BatchSubject and BatchBody are Go templates executed with caseBatchData
+	BatchSubject string
+	BatchBody    string
+}
+
+// caseTemplateData is what the subject and bodies of the support cases are executed with
+type caseTemplateData struct {
+	AccountID string
+	Name      string
+	CaseID    string
+	Status    string
+}
+
+// caseBatchData is what the subject and body of the cases opened for a batch of accounts are
+// executed with
+type caseBatchData struct {
+	Accounts []caseTemplateData
+}
+
+// getCaseTemplate returns the support case template of the operator configmap. A malformed
+// follow-up delay is logged and the default used.
+func getCaseTemplate(reqLogger logr.Logger, configMap *corev1.ConfigMap) caseTemplate {
+	tmpl := caseTemplate{
+		CategoryCode:  caseCategoryCode,
+		ServiceCode:   caseServiceCode,
+		IssueType:     caseIssueType,
+		Severity:      caseSeverity,
+		Language:      caseLanguage,
+		Subject:       defaultCaseSubject,
+		Body:          defaultCaseBody,
+		FollowUpBody:  defaultCaseFollowUpBody,
+		FollowUpAfter: defaultCaseFollowUpAfter,
+		BatchMax:      defaultCaseBatchMax,
+		BatchSubject:  defaultCaseBatchSubject,
+		BatchBody:     defaultCaseBatchBody,
+	}
+	if configMap == nil {
+		return tmpl
+	}
+
+	for key, field := range map[string]*string{
+		caseCategoryCodeConfigMapKey: &tmpl.CategoryCode,
+		caseServiceCodeConfigMapKey:  &tmpl.ServiceCode,
+		caseIssueTypeConfigMapKey:    &tmpl.IssueType,
+		caseSeverityConfigMapKey:     &tmpl.Severity,
+		caseLanguageConfigMapKey:     &tmpl.Language,
+		caseSubjectConfigMapKey:      &tmpl.Subject,
+		caseBodyConfigMapKey:         &tmpl.Body,
+		caseFollowUpBodyConfigMapKey: &tmpl.FollowUpBody,
+		caseBatchSubjectConfigMapKey: &tmpl.BatchSubject,
+		caseBatchBodyConfigMapKey:    &tmpl.BatchBody,
+	} {
+		if value := configMap.Data[key]; value != "" {
+			*field = value
+		}
+	}
+
+	if value, ok := configMap.Data[caseFollowUpAfterConfigMapKey]; ok {
+		followUpAfter, err := time.ParseDuration(value)
+		if err != nil || followUpAfter < 0 {
+			reqLogger.Info("ignoring malformed support case follow-up delay", "value", value)
+		} else {
+			tmpl.FollowUpAfter = followUpAfter
+		}
+	}
+
+	if value, ok := configMap.Data[caseBatchWindowConfigMapKey]; ok {
+		batchWindow, err := time.ParseDuration(value)
+		if err != nil || batchWindow < 0 {
+			reqLogger.Info("ignoring malformed support case batch window", "value", value)
+		} else {
+			tmpl.BatchWindow = batchWindow
+		}
+	}
+	if value, ok := configMap.Data[caseBatchMaxConfigMapKey]; ok {
+		batchMax, err := strconv.Atoi(value)
+		if err != nil || batchMax < 1 {
+			reqLogger.Info("ignoring malformed support case batch size", "value", value)
+		} else {
+			tmpl.BatchMax = batchMax
+		}
+	}
+	return tmpl
+}
+
+// executeCaseTemplate renders one of the text templates of a support case
+func executeCaseTemplate(name string, text string, data interface{}) (string, error) {
+	tmpl, err := template.New(name).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("failed parsing support case %s template: %w", name, err)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed executing support case %s template: %w", name, err)
+	}
+	return buf.String(), nil
+}
+
+func createCase(reqLogger logr.Logger, account *v1alpha1.Account, client awsclient.Client, caseTmpl caseTemplate) (string, error) {
+	accountID := account.Spec.AwsAccountID
+	data := caseTemplateData{AccountID: accountID, Name: account.Name}
+
+	caseCommunicationBody, err := executeCaseTemplate("body", caseTmpl.Body, data)
+	if err != nil {
+		return "", err
+	}
+	caseSubject, err := executeCaseTemplate("subject", caseTmpl.Subject, data)
+	if err != nil {
+		return "", err
+	}
+
+	caseID, err := openCase(reqLogger, client, caseTmpl, caseSubject, caseCommunicationBody)
+	if err != nil {
+		return "", err
+	}
 
-	caseSubject := fmt.Sprintf("Add account %s to Enterprise Support", accountID)
+	reqLogger.Info("Support case created", "AccountID", accountID, "CaseID", caseID)
 
+	return caseID, nil
+}
+
+// openCase opens a support case with the fields of the template and the given subject and body
+func openCase(reqLogger logr.Logger, client awsclient.Client, caseTmpl caseTemplate, caseSubject string, caseCommunicationBody string) (string, error) {
 	createCaseInput := support.CreateCaseInput{
-		CategoryCode:      aws.String(caseCategoryCode),
-		ServiceCode:       aws.String(caseServiceCode),
-		IssueType:         aws.String(caseIssueType),
+		CategoryCode:      aws.String(caseTmpl.CategoryCode),
+		ServiceCode:       aws.String(caseTmpl.ServiceCode),
+		IssueType:         aws.String(caseTmpl.IssueType),
 		CommunicationBody: aws.String(caseCommunicationBody),
 		Subject:           aws.String(caseSubject),
-		SeverityCode:      aws.String(caseSeverity),
-		Language:          aws.String(caseLanguage),
+		SeverityCode:      aws.String(caseTmpl.Severity),
+		Language:          aws.String(caseTmpl.Language),
 	}
 
 	reqLogger.Info("Creating the case", "CaseInput", createCaseInput)
 
 	caseResult, caseErr := client.CreateCase(&createCaseInput)
 	if caseErr != nil {
-		var returnErr error
+		returnErr := caseErr
 		if aerr, ok := caseErr.(awserr.Error); ok {
 			switch aerr.Code() {
 			case support.ErrCodeCaseCreationLimitExceeded:
@@ -73,17 +253,17 @@ Thanks.
 		return "", returnErr
 	}
 
-	reqLogger.Info("Support case created", "AccountID", accountID, "CaseID", caseResult.CaseId)
-
 	return *caseResult.CaseId, nil
 }
 
-func checkCaseResolution(reqLogger logr.Logger, caseID string, client awsclient.Client) (bool, error) {
+// describeCase returns the details of the support case
+func describeCase(reqLogger logr.Logger, caseID string, client awsclient.Client) (*support.CaseDetails, error) {
 	// Look for the case using the unique ID provided
 	describeCasesInput := support.DescribeCasesInput{
 		CaseIdList: []*string{
 			aws.String(caseID),
 		},
+		IncludeResolvedCases: aws.Bool(true),
 	}
 
 	caseResult, caseErr := client.DescribeCases(&describeCasesInput)
@@ -102,15 +282,94 @@ func checkCaseResolution(reqLogger logr.Logger, caseID string, client awsclient.
 			controllerutils.LogAwsError(reqLogger, "New AWS Error while checking case resolution", returnErr, caseErr)
 		}
 
-		return false, returnErr
+		return nil, returnErr
 	}
 
 	// Since we are describing cases based on the unique ID, this list will have only 1 element
-	if *caseResult.Cases[0].Status == caseStatusResolved {
-		reqLogger.Info(fmt.Sprintf("Case Resolved: %s", caseID))
-		return true, nil
+	if len(caseResult.Cases) == 0 {
+		return nil, v1alpha1.ErrAwsSupportCaseIDNotFound
+	}
+	return caseResult.Cases[0], nil
+}
+
+// recordCaseStatus records the status of the support case in the status of the account, and
+// returns whether it changed
+func recordCaseStatus(account *v1alpha1.Account, details *support.CaseDetails) bool {
+	status := aws.StringValue(details.Status)
+	now := metav1.Now()
+
+	supportCase := account.Status.SupportCase
+	if supportCase == nil {
+		supportCase = &v1alpha1.AccountSupportCase{}
+		account.Status.SupportCase = supportCase
+	}
+
+	changed := false
+	if supportCase.CreationTime == nil {
+		creationTime := now
+		if created, err := time.Parse(time.RFC3339, aws.StringValue(details.TimeCreated)); err == nil {
+			creationTime = metav1.NewTime(created)
+		}
+		supportCase.CreationTime = &creationTime
+		changed = true
+	}
+	if supportCase.Status != status {
+		supportCase.Status = status
+		supportCase.LastStatusChangeTime = &now
+		changed = true
+	}
+	return changed
+}
+
+// caseFollowUpDue returns when the next follow-up of the support case is due, and whether it is due
+// now. A case pending customer action is followed up right away, as nothing happens until it is
+// answered; a case of any other unresolved status once it spent followUpAfter without progress.
+func caseFollowUpDue(supportCase *v1alpha1.AccountSupportCase, followUpAfter time.Duration, now time.Time) (time.Duration, bool) {
+	if supportCase == nil || followUpAfter <= 0 || supportCase.Status == caseStatusResolved || supportCase.LastStatusChangeTime == nil {
+		return 0, false
+	}
+
+	followedUpSinceChange := supportCase.LastFollowUpTime != nil && !supportCase.LastFollowUpTime.Before(supportCase.LastStatusChangeTime)
+	if supportCase.Status == caseStatusPendingCustomer && !followedUpSinceChange {
+		return 0, true
+	}
+
+	lastProgress := supportCase.LastStatusChangeTime.Time
+	if followedUpSinceChange {
+		lastProgress = supportCase.LastFollowUpTime.Time
+	}
+	stalled := now.Sub(lastProgress)
+	if stalled >= followUpAfter {
+		return 0, true
+	}
+	return followUpAfter - stalled, false
+}
+
+// followUpCase adds a follow-up communication to the support case of the account and records it
+func followUpCase(reqLogger logr.Logger, account *v1alpha1.Account, client awsclient.Client, caseTmpl caseTemplate) error {
+	supportCase := account.Status.SupportCase
+	body, err := executeCaseTemplate("follow-up body", caseTmpl.FollowUpBody, caseTemplateData{
+		AccountID: account.Spec.AwsAccountID,
+		Name:      account.Name,
+		CaseID:    account.Status.SupportCaseID,
+		Status:    supportCase.Status,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = client.AddCommunicationToCase(&support.AddCommunicationToCaseInput{
+		CaseId:            aws.String(account.Status.SupportCaseID),
+		CommunicationBody: aws.String(body),
+	})
+	if err != nil {
+		controllerutils.LogAwsError(reqLogger, "New AWS Error while following up case", nil, err)
+		return err
 	}
 
-	// reqLogger.Info(fmt.Sprintf("Case [%s] not yet Resolved, waiting. Current Status: %s", caseID, *caseResult.Cases[0].Status))
-	return false, nil
+	now := metav1.Now()
+	supportCase.FollowUps++
+	supportCase.LastFollowUpTime = &now
+	reqLogger.Info("Support case followed up", "CaseID", account.Status.SupportCaseID, "CaseStatus", supportCase.Status, "FollowUps", supportCase.FollowUps)
+	return nil
 }
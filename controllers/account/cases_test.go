@@ -0,0 +1,199 @@
+package account
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/support"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	awsv1alpha1 "github.com/ravitri/aws-account-operator/api/v1alpha1"
+	"github.com/ravitri/aws-account-operator/pkg/awsclient/mock"
+	"github.com/ravitri/aws-account-operator/pkg/testutils"
+)
+
+func TestGetCaseTemplate(t *testing.T) {
+	logger := testutils.NewTestLogger().Logger()
+
+	tmpl := getCaseTemplate(logger, nil)
+	assert.Equal(t, caseSeverity, tmpl.Severity)
+	assert.Equal(t, defaultCaseFollowUpAfter, tmpl.FollowUpAfter)
+
+	tmpl = getCaseTemplate(logger, &corev1.ConfigMap{Data: map[string]string{
+		caseSeverityConfigMapKey:      "urgent",
+		caseSubjectConfigMapKey:       "Enterprise Support for {{.Name}}",
+		caseFollowUpAfterConfigMapKey: "0",
+	}})
+	assert.Equal(t, "urgent", tmpl.Severity)
+	assert.Equal(t, caseCategoryCode, tmpl.CategoryCode)
+	assert.Equal(t, "Enterprise Support for {{.Name}}", tmpl.Subject)
+	assert.Equal(t, time.Duration(0), tmpl.FollowUpAfter)
+
+	tmpl = getCaseTemplate(logger, &corev1.ConfigMap{Data: map[string]string{caseFollowUpAfterConfigMapKey: "a day"}})
+	assert.Equal(t, defaultCaseFollowUpAfter, tmpl.FollowUpAfter, "malformed delays are ignored")
+}
+
+func TestCreateCaseRendersTemplate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockAWSClient := mock.NewMockClient(ctrl)
+
+	account := &newTestAccountBuilder().acct
+	account.Spec.AwsAccountID = "123456789012"
+	tmpl := getCaseTemplate(testutils.NewTestLogger().Logger(), &corev1.ConfigMap{Data: map[string]string{
+		caseSubjectConfigMapKey: "Enterprise Support for {{.AccountID}}",
+		caseBodyConfigMapKey:    "Please enable Enterprise Support on {{.AccountID}} ({{.Name}})",
+	}})
+
+	mockAWSClient.EXPECT().CreateCase(&support.CreateCaseInput{
+		CategoryCode:      aws.String(caseCategoryCode),
+		ServiceCode:       aws.String(caseServiceCode),
+		IssueType:         aws.String(caseIssueType),
+		CommunicationBody: aws.String("Please enable Enterprise Support on 123456789012 (" + account.Name + ")"),
+		Subject:           aws.String("Enterprise Support for 123456789012"),
+		SeverityCode:      aws.String(caseSeverity),
+		Language:          aws.String(caseLanguage),
+	}).Return(&support.CreateCaseOutput{CaseId: aws.String("case-1")}, nil)
+
+	caseID, err := createCase(testutils.NewTestLogger().Logger(), account, mockAWSClient, tmpl)
+	assert.NoError(t, err)
+	assert.Equal(t, "case-1", caseID)
+
+	tmpl.Subject = "{{.Unknown}}"
+	_, err = createCase(testutils.NewTestLogger().Logger(), account, mockAWSClient, tmpl)
+	assert.Error(t, err, "templates referencing unknown fields fail before the case is created")
+}
+
+func TestCaseFollowUpDue(t *testing.T) {
+	now := time.Now()
+	at := func(ago time.Duration) *metav1.Time {
+		t := metav1.NewTime(now.Add(-ago))
+		return &t
+	}
+
+	tests := []struct {
+		name          string
+		supportCase   *awsv1alpha1.AccountSupportCase
+		followUpAfter time.Duration
+		expectedDue   bool
+		expectedWait  time.Duration
+	}{
+		{
+			name:          "No case",
+			followUpAfter: 24 * time.Hour,
+		},
+		{
+			name:          "Follow-ups disabled",
+			supportCase:   &awsv1alpha1.AccountSupportCase{Status: caseStatusPendingCustomer, LastStatusChangeTime: at(48 * time.Hour)},
+			followUpAfter: 0,
+		},
+		{
+			name:          "Resolved case",
+			supportCase:   &awsv1alpha1.AccountSupportCase{Status: caseStatusResolved, LastStatusChangeTime: at(48 * time.Hour)},
+			followUpAfter: 24 * time.Hour,
+		},
+		{
+			name:          "Pending customer action is followed up right away",
+			supportCase:   &awsv1alpha1.AccountSupportCase{Status: caseStatusPendingCustomer, LastStatusChangeTime: at(time.Minute)},
+			followUpAfter: 24 * time.Hour,
+			expectedDue:   true,
+		},
+		{
+			name:          "Pending customer action already followed up",
+			supportCase:   &awsv1alpha1.AccountSupportCase{Status: caseStatusPendingCustomer, LastStatusChangeTime: at(2 * time.Hour), LastFollowUpTime: at(time.Hour)},
+			followUpAfter: 24 * time.Hour,
+			expectedWait:  23 * time.Hour,
+		},
+		{
+			name:          "Case stalled past the threshold",
+			supportCase:   &awsv1alpha1.AccountSupportCase{Status: "unassigned", LastStatusChangeTime: at(25 * time.Hour)},
+			followUpAfter: 24 * time.Hour,
+			expectedDue:   true,
+		},
+		{
+			name:          "Case progressing",
+			supportCase:   &awsv1alpha1.AccountSupportCase{Status: "reopened", LastStatusChangeTime: at(4 * time.Hour)},
+			followUpAfter: 24 * time.Hour,
+			expectedWait:  20 * time.Hour,
+		},
+		{
+			name:          "Status changed since the last follow-up",
+			supportCase:   &awsv1alpha1.AccountSupportCase{Status: "work-in-progress", LastStatusChangeTime: at(time.Hour), LastFollowUpTime: at(30 * time.Hour)},
+			followUpAfter: 24 * time.Hour,
+			expectedWait:  23 * time.Hour,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			wait, due := caseFollowUpDue(test.supportCase, test.followUpAfter, now)
+			assert.Equal(t, test.expectedDue, due)
+			assert.Equal(t, test.expectedWait, wait)
+		})
+	}
+}
+
+func TestTrackCase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockAWSClient := mock.NewMockClient(ctrl)
+	recorder := record.NewFakeRecorder(10)
+	r := &AccountReconciler{recorder: recorder}
+	logger := testutils.NewTestLogger().Logger()
+	tmpl := getCaseTemplate(logger, nil)
+
+	account := &newTestAccountBuilder().acct
+	account.Spec.AwsAccountID = "123456789012"
+	account.Status.SupportCaseID = "case-1"
+	describeInput := &support.DescribeCasesInput{CaseIdList: aws.StringSlice([]string{"case-1"}), IncludeResolvedCases: aws.Bool(true)}
+	caseWithStatus := func(status string) *support.DescribeCasesOutput {
+		return &support.DescribeCasesOutput{Cases: []*support.CaseDetails{{
+			CaseId:      aws.String("case-1"),
+			Status:      aws.String(status),
+			TimeCreated: aws.String("2022-07-18T22:04:38.000Z"),
+		}}}
+	}
+
+	// The first check records the case
+	mockAWSClient.EXPECT().DescribeCases(describeInput).Return(caseWithStatus("unassigned"), nil)
+	changed, requeueAfter, err := r.trackCase(logger, account, mockAWSClient, tmpl)
+	assert.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, intervalBetweenChecksMinutes*time.Minute, requeueAfter)
+	assert.Equal(t, "unassigned", account.Status.SupportCase.Status)
+	assert.Equal(t, time.Date(2022, 7, 18, 22, 4, 38, 0, time.UTC), account.Status.SupportCase.CreationTime.UTC())
+
+	// An unchanged case doesn't change the account
+	mockAWSClient.EXPECT().DescribeCases(describeInput).Return(caseWithStatus("unassigned"), nil)
+	changed, _, err = r.trackCase(logger, account, mockAWSClient, tmpl)
+	assert.NoError(t, err)
+	assert.False(t, changed)
+
+	// A case pending customer action is followed up
+	mockAWSClient.EXPECT().DescribeCases(describeInput).Return(caseWithStatus(caseStatusPendingCustomer), nil)
+	mockAWSClient.EXPECT().AddCommunicationToCase(gomock.Any()).DoAndReturn(
+		func(input *support.AddCommunicationToCaseInput) (*support.AddCommunicationToCaseOutput, error) {
+			assert.Equal(t, "case-1", aws.StringValue(input.CaseId))
+			assert.Contains(t, aws.StringValue(input.CommunicationBody), account.Name)
+			return &support.AddCommunicationToCaseOutput{Result: aws.Bool(true)}, nil
+		})
+	changed, _, err = r.trackCase(logger, account, mockAWSClient, tmpl)
+	assert.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, 1, account.Status.SupportCase.FollowUps)
+	assert.NotNil(t, account.Status.SupportCase.LastFollowUpTime)
+	assert.Contains(t, <-recorder.Events, "SupportCaseStatusChanged")
+	assert.Contains(t, <-recorder.Events, "SupportCaseFollowedUp")
+
+	// It isn't followed up again until it stalls
+	mockAWSClient.EXPECT().DescribeCases(describeInput).Return(caseWithStatus(caseStatusPendingCustomer), nil)
+	changed, requeueAfter, err = r.trackCase(logger, account, mockAWSClient, tmpl)
+	assert.NoError(t, err)
+	assert.False(t, changed)
+	assert.Equal(t, intervalBetweenChecksMinutes*time.Minute, requeueAfter)
+}
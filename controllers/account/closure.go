@@ -0,0 +1,194 @@
+package account
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/organizations"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	awsv1alpha1 "github.com/ravitri/aws-account-operator/api/v1alpha1"
+	"github.com/ravitri/aws-account-operator/pkg/awsclient"
+	"github.com/ravitri/aws-account-operator/pkg/utils"
+)
+
+const (
+	// closureEnabledConfigMapKey turns on the closure of the AWS accounts of failed and deleted non-CCS Accounts
+	closureEnabledConfigMapKey = "feature.account_closure"
+	// closureSuspendedOUConfigMapKey is the OU the AWS accounts are moved to before they are closed
+	closureSuspendedOUConfigMapKey = "closure.suspended-ou"
+	// closureMethodConfigMapKey is the Organizations API used to close the AWS accounts
+	closureMethodConfigMapKey = "closure.method"
+
+	// closureMethodClose closes the account, AWS keeps it SUSPENDED in the organization for 90 days
+	closureMethodClose = "CloseAccount"
+	// closureMethodRemove removes the account from the organization, the account must be able to stand alone
+	closureMethodRemove = "RemoveAccountFromOrganization"
+)
+
+// accountClosureEnabled returns true if the operator configmap opts in to closing AWS accounts
+func accountClosureEnabled(configMap *corev1.ConfigMap) bool {
+	enabled, err := strconv.ParseBool(configMap.Data[closureEnabledConfigMapKey])
+	if err != nil {
+		return false
+	}
+	return enabled
+}
+
+// shouldCloseAccount returns true if the AWS account of the account must be closed: it's a non-CCS
+// account with an AWS account that failed for good, or was deleted without being retired by its pool.
+func shouldCloseAccount(account *awsv1alpha1.Account, configMap *corev1.ConfigMap) bool {
+	if !accountClosureEnabled(configMap) || account.IsBYOC() || !account.HasAwsAccountID() || account.IsClosed() {
+		return false
+	}
+	if account.IsPendingDeletion() {
+		// The pool closes or parks the accounts it retires itself
+		return account.GetAnnotations()[awsv1alpha1.RetireAnnotation] == ""
+	}
+	// A failed account some claim still uses is left alone
+	if !account.IsFailed() || account.IsClaimed() || account.HasClaimLink() {
+		return false
+	}
+	// The finalizer of the claim that released a reused account keeps cleaning it up after a
+	// failed cleanup, and only deleting the Account closes it
+	if account.HasBeenClaimedAtLeastOnce() || reuseCleanupFailed(account) {
+		return false
+	}
+	return failureIsTerminal(account)
+}
+
+// reuseCleanupFailed returns true if the last reuse cleanup of the account failed
+func reuseCleanupFailed(account *awsv1alpha1.Account) bool {
+	condition := account.GetCondition(awsv1alpha1.AccountReuseCleanupFailed)
+	return condition != nil && condition.Status == corev1.ConditionTrue
+}
+
+// failureIsTerminal returns true if the retries gave up on the last failure of the account, either
+// because it isn't recoverable or because the retry budget is spent
+func failureIsTerminal(account *awsv1alpha1.Account) bool {
+	retry := account.Status.Retry
+	return retry != nil && retry.LastFailureReason != "" && retry.NextRetryTime == nil
+}
+
+// closeAccount moves the AWS account to the suspended OU, removes its tags and closes it, or removes it
+// from the organization. Every step is recorded in the account status so the closure resumes where it
+// stopped when a step fails.
+func (r *AccountReconciler) closeAccount(reqLogger logr.Logger, awsSetupClient awsclient.Client, account *awsv1alpha1.Account, configMap *corev1.ConfigMap) error {
+	accountID := account.Spec.AwsAccountID
+	if account.Status.Closure == nil {
+		now := metav1.Now()
+		account.Status.Closure = &awsv1alpha1.AccountClosure{
+			Phase:              awsv1alpha1.ClosureStarted,
+			StartTime:          now,
+			LastTransitionTime: now,
+			Message:            fmt.Sprintf("Closing AWS account %s", accountID),
+		}
+		if err := r.statusUpdate(account); err != nil {
+			return err
+		}
+	}
+
+	for !account.IsClosed() {
+		var err error
+		var phase awsv1alpha1.AccountClosurePhase
+		var msg string
+		switch account.Status.Closure.Phase {
+		case awsv1alpha1.ClosureStarted:
+			suspendedOU := configMap.Data[closureSuspendedOUConfigMapKey]
+			if suspendedOU == "" {
+				// Without a suspended OU the account is closed where it is
+				phase, msg = awsv1alpha1.ClosureMovedToSuspendedOU, "No suspended OU configured, AWS account left in its OU"
+				break
+			}
+			err = parkAccount(awsSetupClient, accountID, suspendedOU)
+			phase, msg = awsv1alpha1.ClosureMovedToSuspendedOU, fmt.Sprintf("AWS account moved to OU %s", suspendedOU)
+		case awsv1alpha1.ClosureMovedToSuspendedOU:
+			err = removeAccountTags(awsSetupClient, accountID)
+			phase, msg = awsv1alpha1.ClosureTagsRemoved, "AWS account tags removed"
+		case awsv1alpha1.ClosureTagsRemoved:
+			phase, err = closeOrRemoveAccount(awsSetupClient, accountID, configMap.Data[closureMethodConfigMapKey])
+			msg = fmt.Sprintf("AWS account %s closed", accountID)
+			if phase == awsv1alpha1.ClosureRemovedFromOrganization {
+				msg = fmt.Sprintf("AWS account %s removed from the organization", accountID)
+			}
+		default:
+			return fmt.Errorf("unknown closure phase %s", account.Status.Closure.Phase)
+		}
+
+		if err != nil {
+			utils.LogAwsError(reqLogger, "failed closing AWS account", nil, err)
+			account.Status.Closure.Message = err.Error()
+			r.recorder.Eventf(account, corev1.EventTypeWarning, utils.EventReasonAccountClosureFailed,
+				"Closure of AWS account %s failed: %s", accountID, err)
+			if updateErr := r.statusUpdate(account); updateErr != nil {
+				reqLogger.Error(updateErr, "failed updating account closure status")
+			}
+			return err
+		}
+
+		reqLogger.Info(msg, "phase", phase)
+		account.Status.Closure.Phase = phase
+		account.Status.Closure.LastTransitionTime = metav1.Now()
+		account.Status.Closure.Message = msg
+		if err := r.statusUpdate(account); err != nil {
+			return err
+		}
+	}
+
+	r.recorder.Event(account, corev1.EventTypeNormal, utils.EventReasonAccountClosed, account.Status.Closure.Message)
+	return nil
+}
+
+// removeAccountTags removes every tag of the AWS account
+func removeAccountTags(client awsclient.Client, accountID string) error {
+	var keys []*string
+	input := &organizations.ListTagsForResourceInput{ResourceId: aws.String(accountID)}
+	for {
+		output, err := client.ListTagsForResource(input)
+		if err != nil {
+			return err
+		}
+		for _, tag := range output.Tags {
+			keys = append(keys, tag.Key)
+		}
+		if output.NextToken == nil {
+			break
+		}
+		input.NextToken = output.NextToken
+	}
+
+	if len(keys) == 0 {
+		return nil
+	}
+	_, err := client.UntagResource(&organizations.UntagResourceInput{
+		ResourceId: aws.String(accountID),
+		TagKeys:    keys,
+	})
+	return err
+}
+
+// closeOrRemoveAccount closes the AWS account, or removes it from the organization, and returns the phase reached
+func closeOrRemoveAccount(client awsclient.Client, accountID string, method string) (awsv1alpha1.AccountClosurePhase, error) {
+	switch method {
+	case "", closureMethodClose:
+		_, err := client.CloseAccount(&awsclient.CloseAccountInput{AccountId: aws.String(accountID)})
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == "AccountAlreadyClosedException" {
+			err = nil
+		}
+		return awsv1alpha1.ClosureClosed, err
+	case closureMethodRemove:
+		_, err := client.RemoveAccountFromOrganization(&organizations.RemoveAccountFromOrganizationInput{
+			AccountId: aws.String(accountID),
+		})
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == organizations.ErrCodeAccountNotFoundException {
+			err = nil
+		}
+		return awsv1alpha1.ClosureRemovedFromOrganization, err
+	default:
+		return "", fmt.Errorf("unknown closure method %s, must be %s or %s", method, closureMethodClose, closureMethodRemove)
+	}
+}
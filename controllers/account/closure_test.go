@@ -0,0 +1,165 @@
+package account
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/organizations"
+	"github.com/golang/mock/gomock"
+	apis "github.com/ravitri/aws-account-operator/api"
+	awsv1alpha1 "github.com/ravitri/aws-account-operator/api/v1alpha1"
+	"github.com/ravitri/aws-account-operator/pkg/awsclient"
+	"github.com/ravitri/aws-account-operator/pkg/awsclient/mock"
+	"github.com/ravitri/aws-account-operator/pkg/testutils"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+)
+
+func TestShouldCloseAccount(t *testing.T) {
+	enabled := &v1.ConfigMap{Data: map[string]string{closureEnabledConfigMapKey: "true"}}
+
+	failed := newTestAccountBuilder().WithState(awsv1alpha1.AccountFailed).acct
+	failed.Spec.AwsAccountID = "123456789012"
+	failed.Status.Retry = &awsv1alpha1.AccountRetryStatus{LastFailureReason: "CreationFailed"}
+	assert.True(t, shouldCloseAccount(&failed, enabled))
+	assert.False(t, shouldCloseAccount(&failed, &v1.ConfigMap{}), "closure is opt-in")
+
+	unrecorded := failed
+	unrecorded.Status.Retry = nil
+	assert.False(t, shouldCloseAccount(&unrecorded, enabled), "failures the retries didn't give up on aren't terminal")
+
+	claimed := failed
+	claimed.Spec.ClaimLink = "claim"
+	assert.False(t, shouldCloseAccount(&claimed, enabled), "failed accounts used by a claim are kept")
+
+	ccs := failed
+	ccs.Spec.BYOC = true
+	assert.False(t, shouldCloseAccount(&ccs, enabled), "CCS accounts belong to the customer")
+
+	closed := failed
+	closed.Status.Closure = &awsv1alpha1.AccountClosure{Phase: awsv1alpha1.ClosureClosed}
+	assert.False(t, shouldCloseAccount(&closed, enabled))
+
+	ready := newTestAccountBuilder().acct
+	ready.Spec.AwsAccountID = "123456789012"
+	assert.False(t, shouldCloseAccount(&ready, enabled))
+}
+
+func TestShouldCloseAccountAfterFailedReuse(t *testing.T) {
+	enabled := &v1.ConfigMap{Data: map[string]string{closureEnabledConfigMapKey: "true"}}
+
+	// The account as the claim finalizer leaves it when a cleaner fails: failed, released by its
+	// claim and still waiting for the finalizer to clean it up again
+	account := newTestAccountBuilder().WithState(awsv1alpha1.AccountFailed).acct
+	account.Spec.AwsAccountID = "123456789012"
+	account.Spec.LegalEntity = awsv1alpha1.LegalEntity{ID: "entity", Name: "entity"}
+	account.Status.Reused = true
+	account.Status.Retry = &awsv1alpha1.AccountRetryStatus{LastFailureReason: "CreationFailed"}
+	account.Status.Conditions = []awsv1alpha1.AccountCondition{
+		{Type: awsv1alpha1.AccountReuseCleanupFailed, Status: v1.ConditionTrue},
+		{Type: awsv1alpha1.AccountFailed, Status: v1.ConditionTrue},
+	}
+	assert.False(t, shouldCloseAccount(&account, enabled), "reused accounts are only closed when deleted")
+
+	account.Spec.LegalEntity = awsv1alpha1.LegalEntity{}
+	account.Status.Reused = false
+	assert.False(t, shouldCloseAccount(&account, enabled), "accounts whose reuse cleanup failed are kept")
+
+	account.Status.Conditions[0].Status = v1.ConditionFalse
+	assert.True(t, shouldCloseAccount(&account, enabled))
+
+	deleted := account
+	deleted.Status.Reused = true
+	now := metav1.Now()
+	deleted.DeletionTimestamp = &now
+	assert.True(t, shouldCloseAccount(&deleted, enabled), "deleting the Account is the explicit request to close it")
+}
+
+func TestCloseAccount(t *testing.T) {
+	err := apis.AddToScheme(scheme.Scheme)
+	if err != nil {
+		fmt.Printf("failed adding apis to scheme in account controller test")
+	}
+
+	accountID := aws.String("123456789012")
+	tests := []struct {
+		name          string
+		configMap     map[string]string
+		phase         awsv1alpha1.AccountClosurePhase
+		setupMocks    func(*mock.MockClientMockRecorder)
+		expectedPhase awsv1alpha1.AccountClosurePhase
+		expectErr     bool
+	}{
+		{
+			name:      "Moves, untags and closes the account",
+			configMap: map[string]string{closureSuspendedOUConfigMapKey: "ou-ab12-suspended"},
+			setupMocks: func(r *mock.MockClientMockRecorder) {
+				r.ListParents(gomock.Any()).Return(&organizations.ListParentsOutput{
+					Parents: []*organizations.Parent{{Id: aws.String("ou-ab12-pool")}},
+				}, nil)
+				r.MoveAccount(&organizations.MoveAccountInput{
+					AccountId:           accountID,
+					SourceParentId:      aws.String("ou-ab12-pool"),
+					DestinationParentId: aws.String("ou-ab12-suspended"),
+				}).Return(&organizations.MoveAccountOutput{}, nil)
+				r.ListTagsForResource(gomock.Any()).Return(&organizations.ListTagsForResourceOutput{
+					Tags: []*organizations.Tag{{Key: aws.String("owner"), Value: aws.String("osd")}},
+				}, nil)
+				r.UntagResource(&organizations.UntagResourceInput{
+					ResourceId: accountID,
+					TagKeys:    []*string{aws.String("owner")},
+				}).Return(&organizations.UntagResourceOutput{}, nil)
+				r.CloseAccount(&awsclient.CloseAccountInput{AccountId: accountID}).Return(&awsclient.CloseAccountOutput{}, nil)
+			},
+			expectedPhase: awsv1alpha1.ClosureClosed,
+		},
+		{
+			name:      "Resumes from the last step and removes the account from the organization",
+			configMap: map[string]string{closureMethodConfigMapKey: closureMethodRemove},
+			phase:     awsv1alpha1.ClosureTagsRemoved,
+			setupMocks: func(r *mock.MockClientMockRecorder) {
+				r.RemoveAccountFromOrganization(&organizations.RemoveAccountFromOrganizationInput{AccountId: accountID}).
+					Return(&organizations.RemoveAccountFromOrganizationOutput{}, nil)
+			},
+			expectedPhase: awsv1alpha1.ClosureRemovedFromOrganization,
+		},
+		{
+			name:      "Records how far the closure got when a step fails",
+			configMap: map[string]string{},
+			setupMocks: func(r *mock.MockClientMockRecorder) {
+				r.ListTagsForResource(gomock.Any()).Return(nil, awserr.New("TooManyRequestsException", "slow down", nil))
+			},
+			expectedPhase: awsv1alpha1.ClosureMovedToSuspendedOU,
+			expectErr:     true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			account := newTestAccountBuilder().WithState(awsv1alpha1.AccountFailed).acct
+			account.Spec.AwsAccountID = *accountID
+			if test.phase != "" {
+				account.Status.Closure = &awsv1alpha1.AccountClosure{Phase: test.phase}
+			}
+
+			mocks := setupDefaultMocks(t, []runtime.Object{&account})
+			defer mocks.mockCtrl.Finish()
+			test.setupMocks(mocks.mockAWSClient.EXPECT())
+
+			r := AccountReconciler{Client: mocks.fakeKubeClient, Scheme: scheme.Scheme, recorder: &record.FakeRecorder{}}
+			err := r.closeAccount(testutils.NewTestLogger().Logger(), mocks.mockAWSClient, &account, &v1.ConfigMap{Data: test.configMap})
+			if test.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, test.expectedPhase, account.Status.Closure.Phase)
+		})
+	}
+}
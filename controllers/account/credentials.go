@@ -0,0 +1,210 @@
+package account
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	awsv1alpha1 "github.com/ravitri/aws-account-operator/api/v1alpha1"
+	"github.com/ravitri/aws-account-operator/pkg/awsclient"
+	"github.com/ravitri/aws-account-operator/pkg/utils"
+)
+
+const (
+	// maxKeyAgeConfigMapKey is the configmap key holding the maximum age, in days, of the
+	// osdManagedAdmin access keys before they are rotated. Unset or 0 disables periodic rotation.
+	maxKeyAgeConfigMapKey = "credentials.max-key-age-days"
+
+	awsCredsAccessKeyID     = "aws_access_key_id"     // #nosec G101 -- This is a false positive
+	awsCredsSecretAccessKey = "aws_secret_access_key" // #nosec G101 -- This is a false positive
+)
+
+// getMaxKeyAge returns the maximum access key age configured in the operator configmap.
+// A zero duration means periodic rotation is disabled.
+func getMaxKeyAge(configMap *corev1.ConfigMap) (time.Duration, error) {
+	value, ok := configMap.Data[maxKeyAgeConfigMapKey]
+	if !ok || value == "" {
+		return 0, nil
+	}
+
+	days, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, err
+	}
+	if days < 0 {
+		return 0, fmt.Errorf("invalid value for %s: %d", maxKeyAgeConfigMapKey, days)
+	}
+
+	return time.Duration(days) * 24 * time.Hour, nil
+}
+
+// credentialsAge returns how long ago the access keys were last rotated. Accounts that have never
+// been rotated fall back to the age of the Account CR.
+func credentialsAge(account *awsv1alpha1.Account) time.Duration {
+	lastRotation := account.CreationTimestamp
+	if account.Status.LastCredentialRotation != nil {
+		lastRotation = *account.Status.LastCredentialRotation
+	}
+	return time.Since(lastRotation.Time)
+}
+
+// credentialRotationDue returns true if one of the rotation flags is set or, when periodic rotation
+// is enabled, the access keys are older than maxKeyAge
+func credentialRotationDue(account *awsv1alpha1.Account, maxKeyAge time.Duration) bool {
+	if account.NeedsCredentialRotation() {
+		return true
+	}
+	return maxKeyAge > 0 && credentialsAge(account) >= maxKeyAge
+}
+
+// nextCredentialRotation returns the reconcile result that brings the account back when its keys
+// reach maxKeyAge
+func nextCredentialRotation(account *awsv1alpha1.Account, maxKeyAge time.Duration) reconcile.Result {
+	if maxKeyAge <= 0 {
+		return reconcile.Result{}
+	}
+	return reconcile.Result{RequeueAfter: maxKeyAge - credentialsAge(account)}
+}
+
+// handleCredentialRotation rotates the osdManagedAdmin access keys of a Ready non-CCS account when
+// either rotation flag has been set (e.g. when the account is reused) or the keys are older than the
+// maximum age set in the configmap.
+func (r *AccountReconciler) handleCredentialRotation(reqLogger logr.Logger, currentAcctInstance *awsv1alpha1.Account, awsSetupClient awsclient.Client, configMap *corev1.ConfigMap) (reconcile.Result, error) {
+	maxKeyAge, err := getMaxKeyAge(configMap)
+	if err != nil {
+		reqLogger.Error(err, "failed parsing max key age from configmap, periodic credential rotation disabled")
+		maxKeyAge = 0
+	}
+
+	if !credentialRotationDue(currentAcctInstance, maxKeyAge) {
+		return nextCredentialRotation(currentAcctInstance, maxKeyAge), nil
+	}
+
+	awsClient, _, err := r.assumeRole(reqLogger, currentAcctInstance, awsSetupClient, awsv1alpha1.AccountOperatorIAMRole, "")
+	if err != nil {
+		reqLogger.Error(err, "failed building AWS client from assume_role for credential rotation")
+		return reconcile.Result{}, err
+	}
+
+	err = r.rotateAccountCredentials(reqLogger, awsClient, currentAcctInstance)
+	if err != nil {
+		reqLogger.Error(err, "failed rotating account credentials")
+		r.recorder.Eventf(currentAcctInstance, corev1.EventTypeWarning, utils.EventReasonCredentialRotationFailed, "Failed to rotate credentials: %v", err)
+		return reconcile.Result{}, err
+	}
+
+	return nextCredentialRotation(currentAcctInstance, maxKeyAge), nil
+}
+
+// rotateAccountCredentials replaces the osdManagedAdmin access keys, rewrites the account secret and
+// the AccountClaim copy of it, then clears the rotation flags and records the rotation time.
+// The osdManagedAdmin user has no console login profile, console access is federated through
+// AWSFederatedAccountAccess, so RotateConsoleCredentials is satisfied by the same key rotation.
+func (r *AccountReconciler) rotateAccountCredentials(reqLogger logr.Logger, awsClient awsclient.Client, account *awsv1alpha1.Account) error {
+	iamUserUHC := fmt.Sprintf("%s-%s", iamUserNameUHC, account.Labels[awsv1alpha1.IAMUserIDLabel])
+
+	iamUserExists, iamUserExistsOutput, err := awsclient.CheckIAMUserExists(reqLogger, awsClient, iamUserUHC)
+	if err != nil {
+		return err
+	}
+	if !iamUserExists {
+		return fmt.Errorf("IAM user %s does not exist in account %s", iamUserUHC, account.Spec.AwsAccountID)
+	}
+
+	reqLogger.Info(fmt.Sprintf("Rotating access keys for IAM user %s", aws.StringValue(iamUserExistsOutput.User.UserName)))
+	accessKeyOutput, err := r.RotateIAMAccessKeys(reqLogger, awsClient, account, iamUserExistsOutput.User)
+	if err != nil {
+		return err
+	}
+
+	// Every copy of the credentials is rewritten before the flags are cleared, so a failure part
+	// way through leaves the flags set and the whole rotation is retried on the next reconcile.
+	// The retry deletes the keys created here, and a Ready account isn't failed over a transient
+	// error.
+	secretName := types.NamespacedName{Name: createIAMUserSecretName(account.Name), Namespace: account.Namespace}
+	if account.Spec.IAMUserSecret != "" {
+		secretName.Name = account.Spec.IAMUserSecret
+	}
+	err = r.writeIAMUserSecret(account, secretName, accessKeyOutput)
+	if err != nil {
+		reqLogger.Error(err, fmt.Sprintf("Failed to update secret %s", secretName.Name))
+		return err
+	}
+
+	err = r.updateAccountClaimCredentialSecret(reqLogger, account, accessKeyOutput)
+	if err != nil {
+		return err
+	}
+
+	now := metav1.Now()
+	account.Status.RotateCredentials = false
+	account.Status.RotateConsoleCredentials = false
+	account.Status.LastCredentialRotation = &now
+	err = r.statusUpdate(account)
+	if err != nil {
+		reqLogger.Error(err, "failed clearing credential rotation flags")
+		return err
+	}
+
+	reqLogger.Info(fmt.Sprintf("Rotated credentials for account %s", account.Name))
+	r.recorder.Event(account, corev1.EventTypeNormal, utils.EventReasonCredentialsRotated, "Rotated the IAM user access keys")
+	return nil
+}
+
+// updateAccountClaimCredentialSecret rewrites the AwsCredentialSecret of the AccountClaim linked to
+// the account, if there is one. A missing claim secret is left alone: the accountclaim controller
+// copies it from the account secret, which already holds the new keys.
+func (r *AccountReconciler) updateAccountClaimCredentialSecret(reqLogger logr.Logger, account *awsv1alpha1.Account, createAccessKeyOutput *iam.CreateAccessKeyOutput) error {
+	if !account.HasClaimLink() {
+		return nil
+	}
+
+	accountClaim, err := r.getAccountClaim(account)
+	if err != nil {
+		if k8serr.IsNotFound(err) {
+			return nil
+		}
+		reqLogger.Error(err, "unable to get accountclaim")
+		return err
+	}
+
+	secretRef := accountClaim.Spec.AwsCredentialSecret
+	if secretRef.Name == "" {
+		return nil
+	}
+
+	claimSecret := &corev1.Secret{}
+	err = r.Client.Get(context.TODO(), types.NamespacedName{Name: secretRef.Name, Namespace: secretRef.Namespace}, claimSecret)
+	if err != nil {
+		if k8serr.IsNotFound(err) {
+			return nil
+		}
+		reqLogger.Error(err, fmt.Sprintf("Unable to get secret %s/%s", secretRef.Namespace, secretRef.Name))
+		return err
+	}
+
+	if claimSecret.Data == nil {
+		claimSecret.Data = map[string][]byte{}
+	}
+	claimSecret.Data[awsCredsAccessKeyID] = []byte(*createAccessKeyOutput.AccessKey.AccessKeyId)
+	claimSecret.Data[awsCredsSecretAccessKey] = []byte(*createAccessKeyOutput.AccessKey.SecretAccessKey)
+
+	err = r.Client.Update(context.TODO(), claimSecret)
+	if err != nil {
+		reqLogger.Error(err, fmt.Sprintf("Failed to update secret %s/%s", secretRef.Namespace, secretRef.Name))
+		return err
+	}
+
+	reqLogger.Info(fmt.Sprintf("Updated secret %s/%s", secretRef.Namespace, secretRef.Name))
+	return nil
+}
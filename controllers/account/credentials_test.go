@@ -0,0 +1,235 @@
+package account
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/go-logr/logr"
+	"github.com/golang/mock/gomock"
+	apis "github.com/ravitri/aws-account-operator/api"
+	awsv1alpha1 "github.com/ravitri/aws-account-operator/api/v1alpha1"
+	"github.com/ravitri/aws-account-operator/pkg/awsclient/mock"
+	"github.com/ravitri/aws-account-operator/pkg/testutils"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Credential rotation", func() {
+	var (
+		nullLogger    logr.Logger
+		ctrl          *gomock.Controller
+		mockAWSClient *mock.MockClient
+		r             *AccountReconciler
+	)
+
+	err := apis.AddToScheme(scheme.Scheme)
+	if err != nil {
+		fmt.Printf("failed adding apis to scheme in credential rotation test")
+	}
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		nullLogger = testutils.NewTestLogger().Logger()
+		mockAWSClient = mock.NewMockClient(ctrl)
+		r = &AccountReconciler{
+			Scheme:   scheme.Scheme,
+			recorder: &record.FakeRecorder{},
+		}
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	Context("Testing getMaxKeyAge", func() {
+		It("is disabled when the key is missing", func() {
+			maxKeyAge, err := getMaxKeyAge(&corev1.ConfigMap{Data: map[string]string{}})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(maxKeyAge).To(BeZero())
+		})
+
+		It("converts days to a duration", func() {
+			maxKeyAge, err := getMaxKeyAge(&corev1.ConfigMap{Data: map[string]string{maxKeyAgeConfigMapKey: "90"}})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(maxKeyAge).To(Equal(90 * 24 * time.Hour))
+		})
+
+		It("rejects invalid values", func() {
+			_, err := getMaxKeyAge(&corev1.ConfigMap{Data: map[string]string{maxKeyAgeConfigMapKey: "-1"}})
+			Expect(err).To(HaveOccurred())
+			_, err = getMaxKeyAge(&corev1.ConfigMap{Data: map[string]string{maxKeyAgeConfigMapKey: "ninety"}})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("Testing credentialRotationDue", func() {
+		It("is due when RotateCredentials is set", func() {
+			account := newTestAccountBuilder().RotateCredentials(true).GetTestAccount()
+			Expect(credentialRotationDue(account, 0)).To(BeTrue())
+		})
+
+		It("is due when RotateConsoleCredentials is set", func() {
+			account := newTestAccountBuilder().RotateConsoleCredentials(true).GetTestAccount()
+			Expect(credentialRotationDue(account, 0)).To(BeTrue())
+		})
+
+		It("is not due without flags and with periodic rotation disabled", func() {
+			account := newTestAccountBuilder().WithCreationTimeStamp(time.Now().Add(-365 * 24 * time.Hour)).GetTestAccount()
+			Expect(credentialRotationDue(account, 0)).To(BeFalse())
+		})
+
+		It("is due when the keys are older than the max key age", func() {
+			account := newTestAccountBuilder().WithCreationTimeStamp(time.Now().Add(-48 * time.Hour)).GetTestAccount()
+			Expect(credentialRotationDue(account, 24*time.Hour)).To(BeTrue())
+		})
+
+		It("uses the last rotation time over the creation time", func() {
+			account := newTestAccountBuilder().WithCreationTimeStamp(time.Now().Add(-48 * time.Hour)).GetTestAccount()
+			lastRotation := metav1.NewTime(time.Now().Add(-time.Hour))
+			account.Status.LastCredentialRotation = &lastRotation
+			Expect(credentialRotationDue(account, 24*time.Hour)).To(BeFalse())
+		})
+	})
+
+	Context("Testing rotateAccountCredentials", func() {
+		It("rewrites the account and accountclaim secrets and clears the flags", func() {
+			claimName := "rotation-claim"
+			claimNamespace := "rotation-claim-ns"
+			iamUserName := fmt.Sprintf("%s-%s", iamUserNameUHC, "abcdef")
+
+			account := newTestAccountBuilder().
+				WithLabels(map[string]string{awsv1alpha1.IAMUserIDLabel: "abcdef"}).
+				WithClaimLink(claimName).
+				WithClaimLinkNamespace(claimNamespace).
+				RotateCredentials(true).
+				RotateConsoleCredentials(true).
+				GetTestAccount()
+			account.Spec.IAMUserSecret = createIAMUserSecretName(account.Name)
+
+			accountClaim := &awsv1alpha1.AccountClaim{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      claimName,
+					Namespace: claimNamespace,
+				},
+				Spec: awsv1alpha1.AccountClaimSpec{
+					AccountLink: account.Name,
+					AwsCredentialSecret: awsv1alpha1.SecretRef{
+						Name:      "aws",
+						Namespace: claimNamespace,
+					},
+				},
+			}
+			accountSecret := CreateSecret(account.Spec.IAMUserSecret, account.Namespace, map[string][]byte{
+				awsCredsAccessKeyID:     []byte("OLDKEY"),
+				awsCredsSecretAccessKey: []byte("OLDSECRET"),
+			})
+			claimSecret := CreateSecret("aws", claimNamespace, map[string][]byte{
+				awsCredsAccessKeyID:     []byte("OLDKEY"),
+				awsCredsSecretAccessKey: []byte("OLDSECRET"),
+			})
+
+			r.Client = fake.NewClientBuilder().WithScheme(scheme.Scheme).WithRuntimeObjects([]runtime.Object{account, accountClaim, accountSecret, claimSecret}...).Build()
+
+			mockAWSClient.EXPECT().GetUser(gomock.Any()).Return(&iam.GetUserOutput{
+				User: &iam.User{UserName: aws.String(iamUserName)},
+			}, nil)
+			mockAWSClient.EXPECT().ListAccessKeys(gomock.Any()).Return(&iam.ListAccessKeysOutput{
+				AccessKeyMetadata: []*iam.AccessKeyMetadata{
+					{AccessKeyId: aws.String("OLDKEY"), UserName: aws.String(iamUserName)},
+				},
+			}, nil)
+			mockAWSClient.EXPECT().DeleteAccessKey(gomock.Any()).Return(&iam.DeleteAccessKeyOutput{}, nil)
+			mockAWSClient.EXPECT().CreateAccessKey(gomock.Any()).Return(&iam.CreateAccessKeyOutput{
+				AccessKey: &iam.AccessKey{
+					UserName:        aws.String(iamUserName),
+					AccessKeyId:     aws.String("NEWKEY"),
+					SecretAccessKey: aws.String("NEWSECRET"),
+				},
+			}, nil)
+
+			err := r.rotateAccountCredentials(nullLogger, mockAWSClient, account)
+			Expect(err).ToNot(HaveOccurred())
+
+			for _, key := range []types.NamespacedName{
+				{Name: account.Spec.IAMUserSecret, Namespace: account.Namespace},
+				{Name: "aws", Namespace: claimNamespace},
+			} {
+				secret := &corev1.Secret{}
+				Expect(r.Client.Get(context.TODO(), key, secret)).To(Succeed())
+				Expect(string(secret.Data[awsCredsAccessKeyID])).To(Equal("NEWKEY"))
+				Expect(string(secret.Data[awsCredsSecretAccessKey])).To(Equal("NEWSECRET"))
+			}
+
+			ac := &awsv1alpha1.Account{}
+			Expect(r.Client.Get(context.TODO(), types.NamespacedName{Name: account.Name, Namespace: account.Namespace}, ac)).To(Succeed())
+			Expect(ac.Status.RotateCredentials).To(BeFalse())
+			Expect(ac.Status.RotateConsoleCredentials).To(BeFalse())
+			Expect(ac.Status.LastCredentialRotation).ToNot(BeNil())
+		})
+
+		It("keeps the account Ready and the flags set when the secret can't be updated", func() {
+			iamUserName := fmt.Sprintf("%s-%s", iamUserNameUHC, "abcdef")
+			account := newTestAccountBuilder().
+				WithLabels(map[string]string{awsv1alpha1.IAMUserIDLabel: "abcdef"}).
+				WithState(awsv1alpha1.AccountReady).
+				RotateCredentials(true).
+				GetTestAccount()
+			account.Spec.IAMUserSecret = createIAMUserSecretName(account.Name)
+
+			// The account secret is missing, so updating it fails
+			r.Client = fake.NewClientBuilder().WithScheme(scheme.Scheme).WithRuntimeObjects([]runtime.Object{account}...).Build()
+
+			mockAWSClient.EXPECT().GetUser(gomock.Any()).Return(&iam.GetUserOutput{
+				User: &iam.User{UserName: aws.String(iamUserName)},
+			}, nil)
+			mockAWSClient.EXPECT().ListAccessKeys(gomock.Any()).Return(&iam.ListAccessKeysOutput{}, nil)
+			mockAWSClient.EXPECT().CreateAccessKey(gomock.Any()).Return(&iam.CreateAccessKeyOutput{
+				AccessKey: &iam.AccessKey{
+					UserName:        aws.String(iamUserName),
+					AccessKeyId:     aws.String("NEWKEY"),
+					SecretAccessKey: aws.String("NEWSECRET"),
+				},
+			}, nil)
+
+			err := r.rotateAccountCredentials(nullLogger, mockAWSClient, account)
+			Expect(err).To(HaveOccurred())
+
+			ac := &awsv1alpha1.Account{}
+			Expect(r.Client.Get(context.TODO(), types.NamespacedName{Name: account.Name, Namespace: account.Namespace}, ac)).To(Succeed())
+			Expect(ac.Status.State).To(Equal(string(awsv1alpha1.AccountReady)))
+			Expect(ac.Status.RotateCredentials).To(BeTrue())
+			Expect(ac.Status.LastCredentialRotation).To(BeNil())
+		})
+
+		It("keeps the flags set when the IAM user is missing", func() {
+			account := newTestAccountBuilder().
+				WithLabels(map[string]string{awsv1alpha1.IAMUserIDLabel: "abcdef"}).
+				RotateCredentials(true).
+				GetTestAccount()
+
+			r.Client = fake.NewClientBuilder().WithScheme(scheme.Scheme).WithRuntimeObjects([]runtime.Object{account}...).Build()
+
+			mockAWSClient.EXPECT().GetUser(gomock.Any()).Return(nil, awserr.New(iam.ErrCodeNoSuchEntityException, "", nil))
+
+			err := r.rotateAccountCredentials(nullLogger, mockAWSClient, account)
+			Expect(err).To(HaveOccurred())
+
+			ac := &awsv1alpha1.Account{}
+			Expect(r.Client.Get(context.TODO(), types.NamespacedName{Name: account.Name, Namespace: account.Namespace}, ac)).To(Succeed())
+			Expect(ac.Status.RotateCredentials).To(BeTrue())
+			Expect(ac.Status.LastCredentialRotation).To(BeNil())
+		})
+	})
+})
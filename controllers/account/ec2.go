@@ -3,48 +3,44 @@ package account
 import (
 	"errors"
 	"fmt"
-	"strconv"
+	"sync"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/ec2"
-	"github.com/aws/aws-sdk-go/service/servicequotas"
 	"github.com/aws/aws-sdk-go/service/sts"
 	"github.com/go-logr/logr"
 	awsv1alpha1 "github.com/ravitri/aws-account-operator/api/v1alpha1"
-	"github.com/ravitri/aws-account-operator/config"
 	"github.com/ravitri/aws-account-operator/pkg/awsclient"
 	controllerutils "github.com/ravitri/aws-account-operator/pkg/utils"
-
-	retry "github.com/avast/retry-go"
 )
 
-type regionInitializationError struct {
-	ErrorMsg string
-	Region   string
-}
-
 var sampleCIDR = "10.0.0.0/16"
-var sampleVPCID = ""
 
-// InitializeSupportedRegions concurrently calls InitializeRegion to create instances in all supported regions
-// This should ensure we don't see any AWS API "PendingVerification" errors when launching instances
-// NOTE: This function does not have any returns. In particular, error conditions from the
-// goroutines are logged, but do not result in a failure up the stack.
-func (r *AccountReconciler) InitializeSupportedRegions(reqLogger logr.Logger, account *awsv1alpha1.Account, regions []awsv1alpha1.AwsRegions, creds *sts.AssumeRoleOutput, regionAMIs map[string]awsv1alpha1.AmiSpec) {
-	// Create some channels to listen and error on when creating EC2 instances in all supported regions
-	ec2Notifications, ec2Errors := make(chan string), make(chan regionInitializationError)
+// pendingRegionErrorCodes are the AWS error codes of initialization steps that can't be taken yet,
+// because the region is still being enabled or a resource it depends on is still being deleted.
+// The step is taken again in the next reconcile.
+var pendingRegionErrorCodes = map[string]bool{
+	"PendingVerification": true,
+	"OptInRequired":       true,
+	"DependencyViolation": true,
+}
 
-	// Make sure we close our channels when we're done
-	defer close(ec2Notifications)
-	defer close(ec2Errors)
+// isRegionStepPending returns true if the error means the initialization step must be taken again later
+func isRegionStepPending(err error) bool {
+	var aerr awserr.Error
+	return errors.As(err, &aerr) && pendingRegionErrorCodes[aerr.Code()]
+}
 
-	// We should not bomb out just because we can't retrieve the vCPU value
-	// and we'll just continue with a "0"
-	// Errors are logged already in getDesiredVCPUValue
-	vCPUQuota, _ := r.getDesiredVCPUValue(reqLogger)
-	reqLogger.Info("retrieved desired vCPU quota value from configMap", "quota.vcpu", vCPUQuota)
+// InitializeSupportedRegions advances the initialization of every region of the account that isn't done
+// by one step, concurrently. The progress of each region is recorded in the account status, which the
+// caller updates, so the initialization resumes where it stopped in the next reconcile.
+// NOTE: Failed regions are recorded in their status, they don't result in a failure up the stack.
+func (r *AccountReconciler) InitializeSupportedRegions(reqLogger logr.Logger, account *awsv1alpha1.Account, creds *sts.AssumeRoleOutput, regionAMIs map[string]awsv1alpha1.AmiSpec) {
+	// We should not bomb out just because we can't retrieve the quotas, errors are logged already
+	quotas := r.getServiceQuotas(reqLogger)
+	reqLogger.Info("retrieved service quotas from configMap", "quotas", len(quotas))
 
 	var kmsKeyId string
 	accountClaim, accountClaimError := r.getAccountClaim(account)
@@ -55,230 +51,244 @@ func (r *AccountReconciler) InitializeSupportedRegions(reqLogger logr.Logger, ac
 		kmsKeyId = accountClaim.Spec.KmsKeyId
 		reqLogger.Info("Retrieved KMS key to use", "KmsKeyID", kmsKeyId)
 	}
-	managedTags := r.getManagedTags(reqLogger)
+	managedTags := r.getManagedTags(reqLogger, account)
+	fedramp := r.isFedrampAccount(reqLogger, account)
 	customerTags := r.getCustomTags(reqLogger, account)
 
-	// Create go routines to initialize regions in parallel
-	for _, region := range regions {
-		go r.InitializeRegion(reqLogger, account, region.Name, regionAMIs[region.Name], vCPUQuota, ec2Notifications, ec2Errors, creds, managedTags, customerTags, kmsKeyId) //nolint:errcheck // Unable to do anything with the returned error
-	}
-
-	var regionInitFailedRegion []string
-	regionInitFailed := false
-	// Wait for all go routines to send a message or error to notify that the region initialization has finished
-	for i := 0; i < len(regions); i++ {
-		select {
-		case msg := <-ec2Notifications:
-			reqLogger.Info(msg)
-		case errMsg := <-ec2Errors:
-			regionInitFailed = true
-			// If we fail to initialize the desired region we want to fail the account
-			reqLogger.Error(errors.New(errMsg.ErrorMsg), errMsg.ErrorMsg)
-			regionInitFailedRegion = append(regionInitFailedRegion, errMsg.Region)
-		}
-	}
-	// If an account is BYOC or CCS and region initialization fails for the region expected, we want to fail the account else output success log
-	if regionInitFailed && len(regions) == 1 {
-		controllerutils.SetAccountStatus(
-			account,
-			fmt.Sprintf("Account %s failed to initialize expected region %v", account.Name, regionInitFailedRegion),
-			awsv1alpha1.AccountInitializingRegions,
-			AccountFailed,
-		)
-	} else {
-		reqLogger.Info("Successfully completed initializing desired regions")
-	}
+	// Every goroutine only updates the status of its own region
+	var wg sync.WaitGroup
+	for i := range account.Status.Regions {
+		region := &account.Status.Regions[i]
+		if region.IsDone() {
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			r.InitializeRegion(reqLogger, account, region, regionAMIs[region.Name], quotas, creds, managedTags, customerTags, kmsKeyId, fedramp) //nolint:errcheck // Errors are recorded in the region status
+		}()
+	}
+	wg.Wait()
+	r.setQuotaIncreaseCondition(account)
 }
 
-// InitializeRegion sets up a connection to the AWS `region` and then creates and terminates an EC2 instance if necessary
+// InitializeRegion sets up a connection to the AWS region and takes the next step of its initialization:
+// launching an EC2 instance, then terminating it once it runs
 func (r *AccountReconciler) InitializeRegion(
 	reqLogger logr.Logger,
 	account *awsv1alpha1.Account,
-	region string,
+	region *awsv1alpha1.AccountRegionStatus,
 	instanceInfo awsv1alpha1.AmiSpec,
-	vCPUQuota float64,
-	ec2Notifications chan string,
-	ec2Errors chan regionInitializationError,
+	quotas []serviceQuota,
 	creds *sts.AssumeRoleOutput,
 	managedTags []awsclient.AWSTag,
 	customerTags []awsclient.AWSTag,
 	kmsKeyId string,
+	fedramp bool,
 ) error {
-	var quotaIncreaseRequired bool
-	var caseID string
-
 	awsClient, err := r.awsClientBuilder.GetClient(controllerName, r.Client, awsclient.NewAwsClientInput{
 		AwsCredsSecretIDKey:     *creds.Credentials.AccessKeyId,
 		AwsCredsSecretAccessKey: *creds.Credentials.SecretAccessKey,
 		AwsToken:                *creds.Credentials.SessionToken,
-		AwsRegion:               region,
+		AwsRegion:               region.Name,
 	})
 
 	if err != nil {
-		connErr := fmt.Sprintf("unable to connect to region %s when attempting to initialize it", region)
+		connErr := fmt.Sprintf("unable to connect to region %s when attempting to initialize it", region.Name)
 		reqLogger.Error(err, connErr)
-		// Notify Error channel that this region has errored and to move on
-		ec2Errors <- regionInitializationError{ErrorMsg: connErr, Region: region}
-
+		if region.Phase == awsv1alpha1.RegionFailed {
+			// The tag based cleanup of the next initialization removes the network
+			region.Network = nil
+			return err
+		}
+		r.setRegionFailed(account, region, err, "ClientCreationFailed", connErr)
 		return err
 	}
 
-	reqLogger.Info("initializing region", "region", region)
-
-	// Attempt to clean the region from any hanging resources
-	cleaned, err := cleanRegion(awsClient, reqLogger, account.Name, region)
-	if err != nil {
-		cleanErr := fmt.Sprintf("Error while attempting to clean region: %v", err.Error())
-		ec2Errors <- regionInitializationError{ErrorMsg: cleanErr, Region: region}
-		return err
-	}
-	if cleaned {
-		// Getting here indicates that the current region is already initialized
-		// and had hanging t2.micro instances that were cleaned. We can forgo creating any new resources
-		ec2Notifications <- fmt.Sprintf("Region %s was already innitialized", region)
+	switch region.Phase {
+	case awsv1alpha1.RegionInstanceLaunched:
+		return r.terminateRegionInstance(reqLogger, account, awsClient, region)
+	case awsv1alpha1.RegionInstanceTerminating:
+		return r.deleteTerminatedInstanceNetwork(reqLogger, account, awsClient, region)
+	case awsv1alpha1.RegionFailed:
+		deleteFailedRegionNetwork(reqLogger, awsClient, region)
 		return nil
 	}
+	return r.launchRegionInstance(reqLogger, account, awsClient, region, instanceInfo, quotas, managedTags, customerTags, kmsKeyId, fedramp)
+}
 
-	// If in fedramp, create vpc and set sampleVPCID value
-	if config.IsFedramp() {
-		// Attempt to clean the region from any hanging fedramp resources
-		fedrampCleaned, err := cleanFedrampInitializationResources(reqLogger, awsClient, account.Name, region)
+// launchRegionInstance cleans the region from hanging resources, requests the service quota increases
+// if needed and launches the EC2 instance of the region
+func (r *AccountReconciler) launchRegionInstance(
+	reqLogger logr.Logger,
+	account *awsv1alpha1.Account,
+	awsClient awsclient.Client,
+	region *awsv1alpha1.AccountRegionStatus,
+	instanceInfo awsv1alpha1.AmiSpec,
+	quotas []serviceQuota,
+	managedTags []awsclient.AWSTag,
+	customerTags []awsclient.AWSTag,
+	kmsKeyId string,
+	fedramp bool,
+) error {
+	reqLogger.Info("initializing region", "region", region.Name)
+
+	// Hanging resources are cleaned before anything is created. A region with a network already
+	// created resumes after a pending step, its cleanup is done.
+	if region.Network == nil {
+		// Attempt to clean the region from any hanging resources
+		cleaned, err := cleanRegion(awsClient, reqLogger, account.Name, region.Name)
 		if err != nil {
-			fedrampCleanedErr := fmt.Sprintf("Error while attempting to clean fedramp region: %v", err.Error())
-			ec2Errors <- regionInitializationError{ErrorMsg: fedrampCleanedErr, Region: region}
+			cleanErr := fmt.Sprintf("Error while attempting to clean region: %v", err.Error())
+			r.setRegionFailed(account, region, err, "RegionCleanupFailed", cleanErr)
 			return err
 		}
-		if fedrampCleaned {
-			ec2Notifications <- fmt.Sprintf("Region %s was already innitialized", region)
+		if cleaned {
+			// Getting here indicates that the current region is already initialized
+			// and had hanging t2.micro instances that were cleaned. We can forgo creating any new resources
+			r.setRegionInitialized(account, region, fmt.Sprintf("Region %s was already initialized", region.Name))
 			return nil
 		}
-
-		vpcID, err := createVpc(reqLogger, awsClient, account, managedTags, customerTags)
-		if err != nil {
-			vpcErr := fmt.Sprintf("Error while attempting to create VPC: %s", vpcID)
-			controllerutils.LogAwsError(reqLogger, vpcErr, nil, err)
-			ec2Errors <- regionInitializationError{ErrorMsg: vpcErr, Region: region}
-			return err
-		}
-		sampleVPCID = vpcID
 	}
 
-	// If the quota is 0, there was an error and we cannot act on it
-	if vCPUQuota != 0 {
-		// Check if a request is necessary
-		// If there are errors, this will return false, and will not continue to try
-		// to set the quota
-		quotaIncreaseRequired, err = vCPUQuotaNeedsIncrease(awsClient, vCPUQuota)
-		if err != nil {
-			reqLogger.Error(err, "failed retrieving current vCPU quota from AWS")
+	// If in fedramp, the instance runs in a network of its own
+	var subnetID string
+	if fedramp {
+		if region.Network == nil {
+			// Attempt to clean the region from any hanging fedramp resources
+			fedrampCleaned, err := cleanFedrampInitializationResources(reqLogger, awsClient, account.Name, region.Name)
+			if isRegionStepPending(err) {
+				setRegionMessage(region, fmt.Sprintf("Waiting to clean fedramp region: %v", err))
+				return nil
+			}
+			if err != nil {
+				fedrampCleanedErr := fmt.Sprintf("Error while attempting to clean fedramp region: %v", err.Error())
+				r.setRegionFailed(account, region, err, "RegionCleanupFailed", fedrampCleanedErr)
+				return err
+			}
+			if fedrampCleaned {
+				r.setRegionInitialized(account, region, fmt.Sprintf("Region %s was already initialized", region.Name))
+				return nil
+			}
+			region.Network = &awsv1alpha1.AccountRegionNetwork{}
 		}
-	}
 
-	if quotaIncreaseRequired {
-		reqLogger.Info("vCPU quota increase required", "region", region)
-		caseID, err = checkQuotaRequestHistory(awsClient, vCPUQuota)
+		// Every part of the network is recorded once created, so it's deleted whatever happens next
+		err := createFedrampNetwork(reqLogger, awsClient, account, region.Network, managedTags, customerTags)
+		if isRegionStepPending(err) {
+			setRegionMessage(region, fmt.Sprintf("Waiting to create fedramp network: %v", err))
+			return nil
+		}
 		if err != nil {
-			reqLogger.Error(err, "failed retrieving quota change history")
+			networkErr := fmt.Sprintf("Error while attempting to create fedramp network in region: %s", region.Name)
+			controllerutils.LogAwsError(reqLogger, networkErr, nil, err)
+			r.setRegionFailed(account, region, err, "NetworkCreationFailed", networkErr)
+			return err
 		}
+		subnetID = region.Network.SubnetID
+	}
 
-		// If a Case ID was found, log it - the request was already submitted
-		if caseID != "" {
-			reqLogger.Info("found matching quota change request", "caseID", caseID)
-		}
+	r.requestQuotaIncreases(reqLogger, account, awsClient, region, quotas)
 
-		// If there is not matching request already,
-		// and there were no errors trying to retrieve them,
-		// then request a quota increase
-		if caseID == "" && err == nil {
-			reqLogger.Info("submitting vCPU quota increase request", "region", region)
-			caseID, err = setVCPUQuota(awsClient, vCPUQuota)
-			if err != nil {
-				reqLogger.Error(err, "failed requesting vCPU quota increase")
+	instanceID, err := CreateEC2Instance(reqLogger, account, awsClient, instanceInfo, managedTags, customerTags, kmsKeyId, subnetID)
+	if isRegionStepPending(err) && instanceID == "" {
+		setRegionMessage(region, fmt.Sprintf("Waiting to launch EC2 instance: %v", err))
+		return nil
+	}
+	if err != nil {
+		// Terminate instance id if it exists
+		if instanceID != "" {
+			// Log instance id of instance that will be terminated
+			reqLogger.Error(err, fmt.Sprintf("Early termination of instance with ID: %s", instanceID))
+			// The network of the region is deleted once the instance is terminated
+			region.InstanceID = instanceID
+			termErr := TerminateEC2Instance(reqLogger, awsClient, instanceID)
+			if termErr != nil {
+				controllerutils.LogAwsError(reqLogger, "AWS error while attempting to terminate instance", nil, termErr)
 			}
 		}
+		createErr := fmt.Sprintf("Unable to create instance in region: %s", region.Name)
+		controllerutils.LogAwsError(reqLogger, createErr, nil, err)
+		r.setRegionFailed(account, region, err, "InstanceLaunchFailed", createErr)
+		return err
+	}
 
-		// If the caseID is set, a quota increase was requested, either just now or previously. Log it.
-		// Can't update account conditions from within the asyncRegionInit goroutine, because
-		// the account is being updated elsewhere and will conflict.
-		if caseID != "" {
-			reqLogger.Info("quota increase request submitted successfully", "region", region, "caseID", caseID)
-		}
+	region.InstanceID = instanceID
+	setRegionPhase(region, awsv1alpha1.RegionInstanceLaunched, fmt.Sprintf("EC2 instance %s launched", instanceID))
+	reqLogger.Info(fmt.Sprintf("EC2 Instance: %s Launched", instanceID), "region", region.Name)
+	return nil
+}
+
+// terminateRegionInstance terminates the EC2 instance of the region once it runs, or once it had
+// regionInstanceWaitTime to start. The fedramp network of the region is deleted by the next reconciles,
+// once the instance is terminated.
+func (r *AccountReconciler) terminateRegionInstance(reqLogger logr.Logger, account *awsv1alpha1.Account, awsClient awsclient.Client, region *awsv1alpha1.AccountRegionStatus) error {
+	instanceID := region.InstanceID
+	code, descErr := DescribeEC2Instances(reqLogger, awsClient, instanceID)
+	switch {
+	case code == 16: // 16 represents a successful region initialization
+		reqLogger.Info(fmt.Sprintf("EC2 Instance: %s Running", instanceID))
+	case code == 401: // 401 represents an UnauthorizedOperation error
+		// Missing permission to perform operations, the region needs to fail
+		permErr := fmt.Sprintf("Missing required permissions to describe EC2 instance %s", instanceID)
+		reqLogger.Error(descErr, permErr)
+		r.setRegionFailed(account, region, descErr, "UnauthorizedOperation", permErr)
+		return descErr
+	case time.Since(region.LastTransitionTime.Time) < regionInstanceWaitTime:
+		// Not running yet, checked again in the next reconcile
+		return nil
+	default:
+		// Log an error and make sure that instance is terminated
+		reqLogger.Error(descErr, fmt.Sprintf("Could not get EC2 instance state, terminating instance %s", instanceID))
 	}
 
-	err = r.BuildAndDestroyEC2Instances(reqLogger, account, awsClient, instanceInfo, managedTags, customerTags, kmsKeyId)
+	// Terminate Instance
+	reqLogger.Info(fmt.Sprintf("Terminating EC2 Instance: %s", instanceID))
+	err := TerminateEC2Instance(reqLogger, awsClient, instanceID)
 	if err != nil {
-		createErr := fmt.Sprintf("Unable to create instance in region: %s", region)
-		controllerutils.LogAwsError(reqLogger, createErr, nil, err)
-		// Notify Error channel that this region has errored and to move on
-		ec2Errors <- regionInitializationError{ErrorMsg: createErr, Region: region}
+		r.setRegionFailed(account, region, err, "InstanceTerminationFailed", fmt.Sprintf("Unable to terminate instance %s in region: %s", instanceID, region.Name))
 		return err
 	}
+	reqLogger.Info(fmt.Sprintf("EC2 Instance: %s Terminated", instanceID))
 
-	successMsg := fmt.Sprintf("EC2 instance created and terminated successfully in region: %s", region)
-
-	// if vpcID exists then make sure we delete it
-	if sampleVPCID != "" {
-		err = deleteFedrampInitializationResources(reqLogger, awsClient, sampleVPCID)
-		if err != nil {
-			deleteResourcesErr := fmt.Sprintf("Unable to delete VPC with ID: %s", sampleVPCID)
-			controllerutils.LogAwsError(reqLogger, deleteResourcesErr, nil, err)
-			ec2Errors <- regionInitializationError{ErrorMsg: deleteResourcesErr, Region: region}
-			return err
-		}
+	// The fedramp VPC and subnet can't be deleted while the instance shuts down
+	if region.Network != nil {
+		setRegionPhase(region, awsv1alpha1.RegionInstanceTerminating, fmt.Sprintf("EC2 instance %s terminating, its network is deleted once it is terminated", instanceID))
+		return nil
 	}
 
-	// Notify Notifications channel that an instance has successfully been created and terminated and to move on
-	ec2Notifications <- successMsg
-
+	r.setRegionInitialized(account, region, fmt.Sprintf("EC2 instance created and terminated successfully in region: %s", region.Name))
 	return nil
 }
 
 // createVpc creates a vpc and returns the VpcId
 func createVpc(reqLogger logr.Logger, client awsclient.Client, account *awsv1alpha1.Account, managedTags []awsclient.AWSTag, customTags []awsclient.AWSTag) (string, error) {
-	// Retain vpcID
-	var timeoutVpcID string
-	// Loop until VPC is created or timeout, double wait time until totalWait seconds
-	totalWait := controllerutils.WaitTime * 60
-	currentWait := 1
-	for totalWait > 0 {
-		currentWait = currentWait * 2
-		if currentWait > totalWait {
-			currentWait = totalWait
-		}
-		totalWait -= currentWait
-		time.Sleep(time.Duration(currentWait) * time.Second)
-		tags := awsclient.AWSTags.BuildTags(account, managedTags, customTags).GetEC2Tags()
-		input := &ec2.CreateVpcInput{
-			CidrBlock: aws.String(sampleCIDR),
-			TagSpecifications: []*ec2.TagSpecification{
-				{
-					ResourceType: &awsv1alpha1.VpcResourceType,
-					Tags:         tags,
-				},
+	tags := awsclient.AWSTags.BuildTags(account, managedTags, customTags).GetEC2Tags()
+	input := &ec2.CreateVpcInput{
+		CidrBlock: aws.String(sampleCIDR),
+		TagSpecifications: []*ec2.TagSpecification{
+			{
+				ResourceType: &awsv1alpha1.VpcResourceType,
+				Tags:         tags,
 			},
+		},
+	}
+	result, vpcErr := client.CreateVpc(input)
+	if vpcErr != nil {
+		var vpcID string
+		if result != nil && result.Vpc != nil {
+			vpcID = aws.StringValue(result.Vpc.VpcId)
 		}
-		result, vpcErr := client.CreateVpc(input)
-		if vpcErr != nil {
-			if aerr, ok := vpcErr.(awserr.Error); ok {
-				if *result.Vpc.VpcId != "" {
-					timeoutVpcID = *result.Vpc.VpcId
-				}
-				switch aerr.Code() {
-				case "PendingVerification", "OptInRequired":
-					continue
-				default:
-					vpcCreateFailed := fmt.Sprintf("Error while attempting to create VPC: %v", *result.Vpc.VpcId)
-					controllerutils.LogAwsError(reqLogger, vpcCreateFailed, vpcErr, vpcErr)
-					return timeoutVpcID, vpcErr
-				}
-			}
-			return timeoutVpcID, awsv1alpha1.ErrFailedAWSTypecast
+		if _, ok := vpcErr.(awserr.Error); !ok {
+			return vpcID, awsv1alpha1.ErrFailedAWSTypecast
+		}
+		if !isRegionStepPending(vpcErr) {
+			vpcCreateFailed := fmt.Sprintf("Error while attempting to create VPC: %v", vpcID)
+			controllerutils.LogAwsError(reqLogger, vpcCreateFailed, vpcErr, vpcErr)
 		}
-		// No error found, vpc running, return vpcID
-		return *result.Vpc.VpcId, nil
+		return vpcID, vpcErr
 	}
-	// Timeout occurred, return vpcID and timeout error
-	return timeoutVpcID, awsv1alpha1.ErrFailedToCreateVpc
+	return *result.Vpc.VpcId, nil
 }
 
 // cleanFedrampSubnet removes all subnet in a given vpc
@@ -339,6 +349,9 @@ func cleanFedrampSubnet(reqLogger logr.Logger, client awsclient.Client, vpcIDtoD
 	for _, subnet := range result.Subnets {
 		reqLogger.Info("Delete hanging subnet", "subnet", subnet.SubnetId)
 		subnetErr := deleteSubnet(reqLogger, client, *subnet.SubnetId)
+		if isRegionStepPending(subnetErr) {
+			return subnetErr
+		}
 		if subnetErr != nil {
 			subnetErrMsg := fmt.Sprintf("Error while attempting to delete subnet: %s", *subnet.SubnetId)
 			controllerutils.LogAwsError(reqLogger, subnetErrMsg, nil, subnetErr)
@@ -348,7 +361,7 @@ func cleanFedrampSubnet(reqLogger logr.Logger, client awsclient.Client, vpcIDtoD
 	return nil
 }
 
-// deleteVpc deletes a vpc and returns err
+// deleteFedrampInitializationResources deletes the subnets of a vpc, then the vpc, and returns err
 func deleteFedrampInitializationResources(reqLogger logr.Logger, client awsclient.Client, vpcIDtoDelete string) error {
 	subnetErr := cleanFedrampSubnet(reqLogger, client, vpcIDtoDelete)
 	if subnetErr != nil {
@@ -356,36 +369,25 @@ func deleteFedrampInitializationResources(reqLogger logr.Logger, client awsclien
 		controllerutils.LogAwsError(reqLogger, subnetErrMsg, subnetErr, subnetErr)
 		return subnetErr
 	}
+	return deleteVpc(reqLogger, client, vpcIDtoDelete)
+}
 
-	totalWait := controllerutils.WaitTime * 60
-	currentWait := 1
-	for totalWait > 0 {
-		currentWait = currentWait * 2
-		if currentWait > totalWait {
-			currentWait = totalWait
-		}
-		totalWait -= currentWait
-		time.Sleep(time.Duration(currentWait) * time.Second)
-
-		_, vpcErr := client.DeleteVpc(&ec2.DeleteVpcInput{
-			VpcId: aws.String(vpcIDtoDelete),
-		})
-		if vpcErr != nil {
-			if aerr, ok := vpcErr.(awserr.Error); ok {
-				switch aerr.Code() {
-				case "PendingVerification", "OptInRequired", "DependencyViolation":
-					continue
-				default:
-					vpcErrMsg := fmt.Sprintf("Error while attempting to delete VPC: %s", vpcIDtoDelete)
-					controllerutils.LogAwsError(reqLogger, vpcErrMsg, vpcErr, vpcErr)
-					return vpcErr
-				}
-			}
+// deleteVpc deletes a vpc and returns err
+func deleteVpc(reqLogger logr.Logger, client awsclient.Client, vpcIDtoDelete string) error {
+	_, vpcErr := client.DeleteVpc(&ec2.DeleteVpcInput{
+		VpcId: aws.String(vpcIDtoDelete),
+	})
+	if vpcErr != nil {
+		if _, ok := vpcErr.(awserr.Error); !ok {
 			return awsv1alpha1.ErrFailedAWSTypecast
 		}
-		return nil
+		if !isRegionStepPending(vpcErr) {
+			vpcErrMsg := fmt.Sprintf("Error while attempting to delete VPC: %s", vpcIDtoDelete)
+			controllerutils.LogAwsError(reqLogger, vpcErrMsg, vpcErr, vpcErr)
+		}
+		return vpcErr
 	}
-	return awsv1alpha1.ErrFailedToDeleteVpc
+	return nil
 }
 
 // cleanFedrampInitializationResources removes all hanging fedramp resources
@@ -467,213 +469,103 @@ func createSubnet(reqLogger logr.Logger, client awsclient.Client, account *awsv1
 
 	result, subnetErr := client.CreateSubnet(input)
 	if subnetErr != nil {
+		var subnetID string
+		if result != nil && result.Subnet != nil {
+			subnetID = aws.StringValue(result.Subnet.SubnetId)
+		}
 		if aerr, ok := subnetErr.(awserr.Error); ok {
 			switch aerr.Code() {
 			default:
-				subnetCreateFailed := fmt.Sprintf("Error while attempting to create subnet: %v", *result.Subnet.SubnetId)
+				subnetCreateFailed := fmt.Sprintf("Error while attempting to create subnet in vpc: %s", vpcID)
 				controllerutils.LogAwsError(reqLogger, subnetCreateFailed, subnetErr, subnetErr)
-				return *result.Subnet.SubnetId, subnetErr
+				return subnetID, subnetErr
 			}
 		}
-		return *result.Subnet.SubnetId, awsv1alpha1.ErrFailedAWSTypecast
+		return subnetID, awsv1alpha1.ErrFailedAWSTypecast
 	}
 	return *result.Subnet.SubnetId, nil
 }
 
 // deleteSubnet takes in subnetID and returns err
 func deleteSubnet(reqLogger logr.Logger, client awsclient.Client, subnetToDelete string) error {
-	totalWait := controllerutils.WaitTime * 60
-	currentWait := 1
-	for totalWait > 0 {
-		currentWait = currentWait * 2
-		if currentWait > totalWait {
-			currentWait = totalWait
-		}
-		totalWait -= currentWait
-		time.Sleep(time.Duration(currentWait) * time.Second)
-
-		_, subnetErr := client.DeleteSubnet(&ec2.DeleteSubnetInput{
-			SubnetId: aws.String(subnetToDelete),
-		})
-		if subnetErr != nil {
-			if aerr, ok := subnetErr.(awserr.Error); ok {
-				switch aerr.Code() {
-				case "PendingVerification", "OptInRequired", "DependencyViolation":
-					continue
-				default:
-					subnetDeleteErr := fmt.Sprintf("Error while attempting to delete subnet: %s", subnetToDelete)
-					controllerutils.LogAwsError(reqLogger, subnetDeleteErr, subnetErr, subnetErr)
-					return subnetErr
-				}
-			}
+	_, subnetErr := client.DeleteSubnet(&ec2.DeleteSubnetInput{
+		SubnetId: aws.String(subnetToDelete),
+	})
+	if subnetErr != nil {
+		if _, ok := subnetErr.(awserr.Error); !ok {
 			return awsv1alpha1.ErrFailedAWSTypecast
 		}
-		// No error found, subnet deleted, return nil
-		return nil
-	}
-	// Timeout occurred, return err
-	return awsv1alpha1.ErrFailedToDeleteSubnet
-}
-
-// BuildAndDestroyEC2Instances runs an ec2 instance and terminates it
-func (r *AccountReconciler) BuildAndDestroyEC2Instances(
-	reqLogger logr.Logger,
-	account *awsv1alpha1.Account,
-	awsClient awsclient.Client,
-	instanceInfo awsv1alpha1.AmiSpec,
-	managedTags []awsclient.AWSTag,
-	customerTags []awsclient.AWSTag,
-	kmsKeyId string) error {
-	instanceID, err := CreateEC2Instance(reqLogger, account, awsClient, instanceInfo, managedTags, customerTags, kmsKeyId)
-	if err != nil {
-		// Terminate instance id if it exists
-		if instanceID != "" {
-			// Log instance id of instance that will be terminated
-			reqLogger.Error(err, fmt.Sprintf("Early termination of instance with ID: %s", instanceID))
-			termErr := TerminateEC2Instance(reqLogger, awsClient, instanceID)
-			if termErr != nil {
-				controllerutils.LogAwsError(reqLogger, "AWS error while attempting to terminate instance", nil, termErr)
-			}
-		}
-		return err
-	}
-
-	// Wait till instance is running
-	var DescError error
-	totalWait := controllerutils.WaitTime * 60
-	currentWait := 1
-	// Double the wait time until we reach totalWait seconds
-	for totalWait > 0 {
-		currentWait = currentWait * 2
-		if currentWait > totalWait {
-			currentWait = totalWait
-		}
-		totalWait -= currentWait
-		time.Sleep(time.Duration(currentWait) * time.Second)
-		var code int
-		code, DescError = DescribeEC2Instances(reqLogger, awsClient, instanceID)
-		if code == 16 { // 16 represents a successful region initialization
-			reqLogger.Info(fmt.Sprintf("EC2 Instance: %s Running", instanceID))
-			break
-		} else if code == 401 { // 401 represents an UnauthorizedOperation error
-			// Missing permission to perform operations, account needs to fail
-			reqLogger.Error(DescError, fmt.Sprintf("Missing required permissions for account %s", account.Name))
-			return err
-		}
-
-	}
-
-	if DescError != nil {
-		// Log an error and make sure that instance is terminated
-		DescErrorMsg := fmt.Sprintf("Could not get EC2 instance state, terminating instance %s", instanceID)
-
-		if DescError, ok := err.(awserr.Error); ok {
-			DescErrorMsg = fmt.Sprintf("Could not get EC2 instance state: %s, terminating instance %s", DescError.Code(), instanceID)
+		if !isRegionStepPending(subnetErr) {
+			subnetDeleteErr := fmt.Sprintf("Error while attempting to delete subnet: %s", subnetToDelete)
+			controllerutils.LogAwsError(reqLogger, subnetDeleteErr, subnetErr, subnetErr)
 		}
-
-		reqLogger.Error(DescError, DescErrorMsg)
-	}
-
-	// Terminate Instance
-	reqLogger.Info(fmt.Sprintf("Terminating EC2 Instance: %s", instanceID))
-
-	err = TerminateEC2Instance(reqLogger, awsClient, instanceID)
-	if err != nil {
-		return err
+		return subnetErr
 	}
-
-	reqLogger.Info(fmt.Sprintf("EC2 Instance: %s Terminated", instanceID))
-
 	return nil
 }
 
 // CreateEC2Instance creates ec2 instance and returns its instance ID
-func CreateEC2Instance(reqLogger logr.Logger, account *awsv1alpha1.Account, client awsclient.Client, instanceInfo awsv1alpha1.AmiSpec, managedTags []awsclient.AWSTag, customerTags []awsclient.AWSTag, customerKmsKeyId string) (string, error) {
-
-	// Retain instance id
-	var timeoutInstanceID string
-
-	// Loop until an EC2 instance is created or timeout.
-	totalWait := controllerutils.WaitTime * 60
-	currentWait := 1
-	for totalWait > 0 {
-		currentWait = currentWait * 2
-		if currentWait > totalWait {
-			currentWait = totalWait
-		}
-		totalWait -= currentWait
-		time.Sleep(time.Duration(currentWait) * time.Second)
-		tags := awsclient.AWSTags.BuildTags(account, managedTags, customerTags).GetEC2Tags()
-
-		ebsBlockDeviceSetup := &ec2.EbsBlockDevice{
-			VolumeSize:          aws.Int64(10),
-			DeleteOnTermination: aws.Bool(true),
-			Encrypted:           aws.Bool(true),
-		}
-		if customerKmsKeyId != "" {
-			ebsBlockDeviceSetup.KmsKeyId = aws.String(customerKmsKeyId)
-		}
-		// Specify the details of the instance that you want to create.
-		input := &ec2.RunInstancesInput{
-			ImageId:      aws.String(instanceInfo.Ami),
-			InstanceType: aws.String(instanceInfo.InstanceType),
-			MinCount:     aws.Int64(1),
-			MaxCount:     aws.Int64(1),
-			TagSpecifications: []*ec2.TagSpecification{
-				{
-					ResourceType: &awsv1alpha1.InstanceResourceType,
-					Tags:         tags,
-				},
-				{
-					ResourceType: &awsv1alpha1.VolumeResourceType,
-					Tags:         tags,
-				},
+// The instance is launched in the subnet when one is given, as fedramp accounts have no default VPC.
+func CreateEC2Instance(reqLogger logr.Logger, account *awsv1alpha1.Account, client awsclient.Client, instanceInfo awsv1alpha1.AmiSpec, managedTags []awsclient.AWSTag, customerTags []awsclient.AWSTag, customerKmsKeyId string, subnetID string) (string, error) {
+	tags := awsclient.AWSTags.BuildTags(account, managedTags, customerTags).GetEC2Tags()
+
+	ebsBlockDeviceSetup := &ec2.EbsBlockDevice{
+		VolumeSize:          aws.Int64(10),
+		DeleteOnTermination: aws.Bool(true),
+		Encrypted:           aws.Bool(true),
+	}
+	if customerKmsKeyId != "" {
+		ebsBlockDeviceSetup.KmsKeyId = aws.String(customerKmsKeyId)
+	}
+	// Specify the details of the instance that you want to create.
+	input := &ec2.RunInstancesInput{
+		ImageId:      aws.String(instanceInfo.Ami),
+		InstanceType: aws.String(instanceInfo.InstanceType),
+		MinCount:     aws.Int64(1),
+		MaxCount:     aws.Int64(1),
+		TagSpecifications: []*ec2.TagSpecification{
+			{
+				ResourceType: &awsv1alpha1.InstanceResourceType,
+				Tags:         tags,
 			},
-			// We specify block devices mainly to enable EBS encryption
-			BlockDeviceMappings: []*ec2.BlockDeviceMapping{
-				{
-					DeviceName: aws.String("/dev/sda1"),
-					Ebs:        ebsBlockDeviceSetup,
-				},
+			{
+				ResourceType: &awsv1alpha1.VolumeResourceType,
+				Tags:         tags,
 			},
-		}
+		},
+		// We specify block devices mainly to enable EBS encryption
+		BlockDeviceMappings: []*ec2.BlockDeviceMapping{
+			{
+				DeviceName: aws.String("/dev/sda1"),
+				Ebs:        ebsBlockDeviceSetup,
+			},
+		},
+	}
 
-		// If fedramp, create subnet and set value for RunInstancesInput
-		if config.IsFedramp() {
-			subnetID, err := createSubnet(reqLogger, client, account, managedTags, customerTags, sampleCIDR, sampleVPCID)
-			if err != nil {
-				subnetErr := fmt.Sprintf("Error while trying to create subnet: %s", subnetID)
-				controllerutils.LogAwsError(reqLogger, subnetErr, nil, err)
-			}
-			input.SubnetId = aws.String(subnetID)
-		}
-		runResult, runErr := client.RunInstances(input)
+	if subnetID != "" {
+		input.SubnetId = aws.String(subnetID)
+	}
+	runResult, runErr := client.RunInstances(input)
 
-		// Return on unexpected errors:
-		if runErr != nil {
-			if aerr, ok := runErr.(awserr.Error); ok {
-				// We want to ensure that we don't leave any instances around when there is an error
-				// possible that there is no instance here
-				if len(runResult.Instances) > 0 {
-					timeoutInstanceID = *runResult.Instances[0].InstanceId
-				}
-				switch aerr.Code() {
-				case "PendingVerification", "OptInRequired":
-					continue
-				default:
-					controllerutils.LogAwsError(reqLogger, "Failed while trying to create EC2 instance", runErr, runErr)
-					return timeoutInstanceID, runErr
-				}
-			}
-			return timeoutInstanceID, awsv1alpha1.ErrFailedAWSTypecast
+	// Return on errors:
+	if runErr != nil {
+		// We want to ensure that we don't leave any instances around when there is an error
+		// possible that there is no instance here
+		var instanceID string
+		if runResult != nil && len(runResult.Instances) > 0 {
+			instanceID = aws.StringValue(runResult.Instances[0].InstanceId)
 		}
-
-		// No error was found, instance is running, return instance id
-		return *runResult.Instances[0].InstanceId, nil
+		if _, ok := runErr.(awserr.Error); !ok {
+			return instanceID, awsv1alpha1.ErrFailedAWSTypecast
+		}
+		if !isRegionStepPending(runErr) {
+			controllerutils.LogAwsError(reqLogger, "Failed while trying to create EC2 instance", runErr, runErr)
+		}
+		return instanceID, runErr
 	}
 
-	// Timeout occurred, return instance id and timeout error
-	return timeoutInstanceID, awsv1alpha1.ErrCreateEC2Instance
+	// No error was found, instance is running, return instance id
+	return *runResult.Instances[0].InstanceId, nil
 }
 
 // DescribeEC2Instances returns the InstanceState code
@@ -711,6 +603,29 @@ func DescribeEC2Instances(reqLogger logr.Logger, client awsclient.Client, instan
 	return int(*result.InstanceStatuses[0].InstanceState.Code), nil
 }
 
+// ec2InstanceTerminated returns true once the EC2 instance is terminated, or is gone
+func ec2InstanceTerminated(reqLogger logr.Logger, client awsclient.Client, instanceID string) (bool, error) {
+	result, err := client.DescribeInstances(&ec2.DescribeInstancesInput{
+		InstanceIds: aws.StringSlice([]string{instanceID}),
+	})
+	if err != nil {
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == "InvalidInstanceID.NotFound" {
+			return true, nil
+		}
+		controllerutils.LogAwsError(reqLogger, "New AWS Error while describing EC2 instance", nil, err)
+		return false, err
+	}
+
+	for _, reservation := range result.Reservations {
+		for _, instance := range reservation.Instances {
+			if instance.State != nil && aws.StringValue(instance.State.Name) != ec2.InstanceStateNameTerminated {
+				return false, nil
+			}
+		}
+	}
+	return true, nil
+}
+
 // TerminateEC2Instance terminates the ec2 instance from the instanceID provided
 func TerminateEC2Instance(reqLogger logr.Logger, client awsclient.Client, instanceID string) error {
 	_, err := client.TerminateInstances(&ec2.TerminateInstancesInput{
@@ -736,284 +651,6 @@ func ListEC2InstanceStatus(reqLogger logr.Logger, client awsclient.Client) (*ec2
 	return result, nil
 }
 
-// getDesiredVCPUValue retrieves the desired quota information from the operator configmap and converts it to a float64
-func (r *AccountReconciler) getDesiredVCPUValue(reqLogger logr.Logger) (float64, error) {
-	var err error
-	var vCPUQuota float64
-
-	configMap, err := controllerutils.GetOperatorConfigMap(r.Client)
-	v, ok := configMap.Data["quota.vcpu"]
-	if !ok {
-		err = awsv1alpha1.ErrInvalidConfigMap
-	}
-	if err != nil {
-		reqLogger.Info("Failed getting desired vCPU quota from configmap data, defaulting quota to 0")
-		return vCPUQuota, err
-	}
-
-	vCPUQuota, err = strconv.ParseFloat(v, 64)
-	if err != nil {
-		reqLogger.Info("Failed converting vCPU quota from configmap string to float64, defaulting quota to 0")
-		return vCPUQuota, err
-	}
-
-	return vCPUQuota, nil
-}
-
-// getVCPUQUota returns the current set vCPU quota for the region
-func vCPUQuotaNeedsIncrease(client awsclient.Client, desiredQuota float64) (bool, error) {
-	var result *servicequotas.GetServiceQuotaOutput
-
-	// Default is 1/10 of a second, but any retries we need to make should be delayed a few seconds
-	// This also defaults to an exponential backoff, so we only need to try ~5 times, default is 10
-	retry.DefaultDelay = 3 * time.Second
-	retry.DefaultAttempts = uint(5)
-	err := retry.Do(
-		func() (err error) {
-			// Get the current existing quota setting
-			result, err = client.GetServiceQuota(
-				&servicequotas.GetServiceQuotaInput{
-					QuotaCode:   aws.String(vCPUQuotaCode),
-					ServiceCode: aws.String(vCPUServiceCode),
-				},
-			)
-			return err
-		},
-
-		// Retry if we receive some specific errors: access denied, rate limit or server-side error
-		retry.RetryIf(func(err error) bool {
-			if aerr, ok := err.(awserr.Error); ok {
-				switch aerr.Code() {
-				// AccessDenied may indicate the BYOCAdminAccess role has not yet propagated
-				case "AccessDeniedException":
-					return true
-				case "ServiceException":
-					return true
-				case "TooManyRequestsException":
-					return true
-				// Can be caused by the client token not yet propagated
-				case "UnrecognizedClientException":
-					return true
-				}
-			}
-			// Otherwise, do not retry
-			return false
-		}),
-	)
-
-	// Regardless of errors, if we got the result for the actual quota,
-	// then compare it to the desired quota.
-	if result.Quota != nil {
-		if *result.Quota.Value < desiredQuota {
-			return true, err
-		}
-	}
-
-	// Otherwise return false (doesn't need increase) and any errors
-	return false, err
-}
-
-// setRegionVCPUQuota sets the AWS quota limit for vCPUs in the region
-// This just sends the request, and checks that it was submitted, and does not wait
-func setVCPUQuota(client awsclient.Client, desiredQuota float64) (string, error) {
-	// Request a service quota increase for vCPU quota
-	var result *servicequotas.RequestServiceQuotaIncreaseOutput
-	var alreadySubmitted bool
-
-	// Default is 1/10 of a second, but any retries we need to make should be delayed a few seconds
-	// This also defaults to an exponential backoff, so we only need to try ~5 times, default is 10
-	retry.DefaultDelay = 3 * time.Second
-	retry.DefaultAttempts = uint(5)
-	err := retry.Do(
-		func() (err error) {
-			result, err = client.RequestServiceQuotaIncrease(
-				&servicequotas.RequestServiceQuotaIncreaseInput{
-					DesiredValue: aws.Float64(desiredQuota),
-					ServiceCode:  aws.String(vCPUServiceCode),
-					QuotaCode:    aws.String(vCPUQuotaCode),
-				})
-			if err != nil {
-				if aerr, ok := err.(awserr.Error); ok {
-					if aerr.Code() == "ResourceAlreadyExistsException" {
-						// This error means a request has already been submitted, and we do not have the CaseID, but
-						// we should also *not* return an error - this is a no-op.
-						alreadySubmitted = true
-						return nil
-					}
-				}
-			}
-			return err
-		},
-
-		retry.RetryIf(func(err error) bool {
-			if aerr, ok := err.(awserr.Error); ok {
-				switch aerr.Code() {
-				// AccessDenied may indicate the BYOCAdminAccess role has not yet propagated
-				case "AccessDeniedException":
-					return true
-				case "TooManyRequestsException":
-					// Retry
-					return true
-				case "ServiceException":
-					// Retry
-					return true
-				// Can be caused by the client token not yet propagated
-				case "UnrecognizedClientException":
-					return true
-				}
-			}
-			// Otherwise, do not retry
-			return false
-		}),
-	)
-
-	// If the attempt to submit a request returns "ResourceAlreadyExistsException"
-	// then a request has already been submitted, since we first polled. No further action.
-	if alreadySubmitted {
-		return "RequestAlreadyExists", nil
-	}
-
-	// Otherwise, if there is an error, return the error to be handled
-	if err != nil {
-		return "", err
-	}
-
-	if (servicequotas.RequestServiceQuotaIncreaseOutput{}) == *result {
-		err := fmt.Errorf("returned RequestServiceQuotaIncreaseOutput is nil")
-		return "", err
-	}
-
-	if (servicequotas.RequestedServiceQuotaChange{}) == *result.RequestedQuota {
-		err := fmt.Errorf("returned RequestedServiceQuotasIncreaseOutput field RequestedServiceQuotaChange is nil")
-		return "", err
-	}
-
-	err = retry.Do(
-		func() (err error) {
-			// If we were returned a Case ID, then the request was submitted
-			var nilString *string
-			if result.RequestedQuota.CaseId == nilString {
-				err := fmt.Errorf("returned CaseID is nil")
-				return err
-
-			}
-			return nil
-		},
-	)
-
-	if err != nil {
-		return "", err
-	}
-
-	return *result.RequestedQuota.CaseId, nil
-
-}
-
-// checkQuotaRequestHistory checks to see if a request for a quota increase has already been submitted
-// This is not ideal, as each region has to check the history, since we have to initialize by region
-// Ideally this would happen outside the region-specific init, but this requires the awsclient for the
-// specific region.
-func checkQuotaRequestHistory(awsClient awsclient.Client, vCPUQuota float64) (string, error) {
-	var err error
-	var nextToken *string
-	var caseID string
-
-	// Default is 1/10 of a second, but any retries we need to make should be delayed a few seconds
-	// This also defaults to an exponential backoff, so we only need to try ~5 times, default is 10
-	retry.DefaultDelay = 3 * time.Second
-	retry.DefaultAttempts = uint(5)
-
-	for {
-		// This returns with pagination, so we have to iterate over the pagination data
-
-		var result *servicequotas.ListRequestedServiceQuotaChangeHistoryByQuotaOutput
-		var err error
-		var submitted bool
-
-		err = retry.Do(
-			func() (err error) {
-				// Get a (possibly paginated) list of quota change requests by quota
-				result, err = awsClient.ListRequestedServiceQuotaChangeHistoryByQuota(
-					&servicequotas.ListRequestedServiceQuotaChangeHistoryByQuotaInput{
-						NextToken:   nextToken,
-						ServiceCode: aws.String(vCPUServiceCode),
-						QuotaCode:   aws.String(vCPUQuotaCode),
-					},
-				)
-				return err
-			},
-
-			// Retry if we receive some specific errors: access denied, rate limit or server-side error
-			retry.RetryIf(func(err error) bool {
-				if aerr, ok := err.(awserr.Error); ok {
-					switch aerr.Code() {
-					// AccessDenied may indicate the BYOCAdminAccess role has not yet propagated
-					case "AccessDeniedException":
-						return true
-					case "ServiceException":
-						return true
-					case "TooManyRequestsException":
-						return true
-					// Can be caused by the client token not yet propagated
-					case "UnrecognizedClientException":
-						return true
-					}
-				}
-				// Otherwise, do not retry
-				return false
-			}),
-		)
-
-		if err != nil {
-			// Return an error if retrieving the change history fails
-			return "", err
-		}
-
-		// Check all the returned requests to see if one matches the quota increase we'd request
-		// If so, it's already been submitted
-		for _, change := range result.RequestedQuotas {
-			if changeRequestMatches(change, vCPUQuota) {
-				submitted = true
-				caseID = *change.CaseId
-				break
-			}
-		}
-
-		// If request has already been submitted, then break out
-		if submitted {
-			break
-		}
-
-		// If NextToken is empty, no more to try.  Break out
-		if result.NextToken == nil {
-			break
-		}
-
-		// Set NextToken to retrieve the next page and loop again
-		nextToken = result.NextToken
-	}
-
-	return caseID, err
-
-}
-
-// changeRequestMatches returns true if the QuotaCode, ServiceCode and desired value match
-func changeRequestMatches(change *servicequotas.RequestedServiceQuotaChange, quota float64) bool {
-	if *change.ServiceCode != vCPUServiceCode {
-		return false
-	}
-
-	if *change.QuotaCode != vCPUQuotaCode {
-		return false
-	}
-
-	if *change.DesiredValue != quota {
-		return false
-	}
-
-	return true
-}
-
 // cleanRegion will remove all hanging account creation t2.micro instances running in the current region
 func cleanRegion(client awsclient.Client, logger logr.Logger, accountName string, region string) (bool, error) {
 	cleaned := false
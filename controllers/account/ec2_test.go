@@ -5,7 +5,6 @@ import (
 	"testing"
 	"time"
 
-	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -20,8 +19,8 @@ import (
 	"github.com/ravitri/aws-account-operator/pkg/testutils"
 	"github.com/stretchr/testify/assert"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
 )
 
 type testRunInstanceInputBuilder struct {
@@ -200,6 +199,12 @@ func TestDeleteSubnet(t *testing.T) {
 			ReturnError: nil,
 			ExpectError: false,
 		},
+		{
+			Name:        "Returns dependency violations to be retried later",
+			SubnetID:    "subnet-1234",
+			ReturnError: awserr.New("DependencyViolation", "subnet in use", nil),
+			ExpectError: true,
+		},
 	}
 
 	for _, test := range tests {
@@ -313,11 +318,22 @@ func TestCreateEC2Instance(t *testing.T) {
 			},
 			instanceOutputError: awserr.New("Test", "Test", fmt.Errorf("Test")),
 		}, "", true},
+		{"Returns without retrying while the region is pending", args{
+			reqLogger:           testutils.NewTestLogger().Logger(),
+			account:             &newTestAccountBuilder().acct,
+			client:              mockAWSClient,
+			instanceInfo:        instanceInfo,
+			managedTags:         []awsclient.AWSTag{},
+			customerTags:        []awsclient.AWSTag{},
+			customerKmsKeyId:    "",
+			instanceInput:       &newTestRunInstanceInputBuilder().instanceInput,
+			instanceOutputError: awserr.New("OptInRequired", "region not enabled", nil),
+		}, "", true},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockAWSClient.EXPECT().RunInstances(tt.args.instanceInput).MinTimes(1).MaxTimes(1).Return(tt.args.instanceOutput, tt.args.instanceOutputError)
-			got, err := CreateEC2Instance(tt.args.reqLogger, tt.args.account, tt.args.client, tt.args.instanceInfo, tt.args.managedTags, tt.args.customerTags, tt.args.customerKmsKeyId)
+			got, err := CreateEC2Instance(tt.args.reqLogger, tt.args.account, tt.args.client, tt.args.instanceInfo, tt.args.managedTags, tt.args.customerTags, tt.args.customerKmsKeyId, "")
 			if (err != nil) != tt.wantErr {
 				t.Errorf("CreateEC2Instance() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -335,89 +351,161 @@ func TestReconcileAccount_InitializeSupportedRegions(t *testing.T) {
 
 	mockAWSBuilder := mock.NewMockIBuilder(ctrl)
 	mockAWSClient := mock.NewMockClient(ctrl)
-	mockAWSBuilder.EXPECT().GetClient(gomock.Any(), gomock.Any(), gomock.Any()).Return(mockAWSClient, nil)
-	mockAWSClient.EXPECT().DescribeInstances(gomock.Any()).Return(&ec2.DescribeInstancesOutput{}, nil).MinTimes(2).MaxTimes(3)
-	mockAWSClient.EXPECT().RunInstances(gomock.Any()).Return(&ec2.Reservation{
-		Groups: []*ec2.GroupIdentifier{},
-		Instances: []*ec2.Instance{
-			{
-				InstanceId: aws.String("1"),
+	mockAWSBuilder.EXPECT().GetClient(gomock.Any(), gomock.Any(), gomock.Any()).Return(mockAWSClient, nil).Times(2)
+	gomock.InOrder(
+		mockAWSClient.EXPECT().DescribeInstances(gomock.Any()).Return(&ec2.DescribeInstancesOutput{}, nil).Times(2),
+		mockAWSClient.EXPECT().RunInstances(gomock.Any()).Return(&ec2.Reservation{
+			Groups: []*ec2.GroupIdentifier{},
+			Instances: []*ec2.Instance{
+				{
+					InstanceId: aws.String("1"),
+				},
 			},
-		},
-		OwnerId:       aws.String("red-hat"),
-		RequesterId:   aws.String("aao"),
-		ReservationId: aws.String("1"),
-	}, nil)
-	mockAWSClient.EXPECT().DescribeInstanceStatus(gomock.Any()).Return(&ec2.DescribeInstanceStatusOutput{
-		InstanceStatuses: []*ec2.InstanceStatus{
-			{
-				InstanceState: &ec2.InstanceState{
-					Code: aws.Int64(16),
-					Name: aws.String("Running"),
+			OwnerId:       aws.String("red-hat"),
+			RequesterId:   aws.String("aao"),
+			ReservationId: aws.String("1"),
+		}, nil),
+		mockAWSClient.EXPECT().DescribeInstanceStatus(gomock.Any()).Return(&ec2.DescribeInstanceStatusOutput{
+			InstanceStatuses: []*ec2.InstanceStatus{
+				{
+					InstanceState: &ec2.InstanceState{
+						Code: aws.Int64(16),
+						Name: aws.String("Running"),
+					},
 				},
 			},
+		}, nil),
+		mockAWSClient.EXPECT().TerminateInstances(gomock.Any()).Return(&ec2.TerminateInstancesOutput{}, nil),
+	)
+
+	reqLogger := testutils.NewTestLogger()
+	account := &awsv1alpha1.Account{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      TestAccountName,
+			Namespace: TestAccountNamespace,
+		},
+		Status: awsv1alpha1.AccountStatus{
+			Regions: newRegionStatuses([]awsv1alpha1.AwsRegions{{Name: "us-east-1"}}),
 		},
-	}, nil)
-	mockAWSClient.EXPECT().TerminateInstances(gomock.Any()).Return(&ec2.TerminateInstancesOutput{}, nil)
-	type fields struct {
-		Client           client.Client
-		scheme           *runtime.Scheme
-		awsClientBuilder awsclient.IBuilder
-		shardName        string
 	}
-	type args struct {
-		reqLogger  testutils.TestLogger
-		account    *awsv1alpha1.Account
-		regions    []awsv1alpha1.AwsRegions
-		creds      *sts.AssumeRoleOutput
-		regionAMIs map[string]awsv1alpha1.AmiSpec
+	creds := &sts.AssumeRoleOutput{
+		AssumedRoleUser: &sts.AssumedRoleUser{},
+		Credentials: &sts.Credentials{
+			AccessKeyId:     aws.String("123456"),
+			Expiration:      &time.Time{},
+			SecretAccessKey: aws.String("123456"),
+			SessionToken:    aws.String("123456"),
+		},
+		PackedPolicySize: new(int64),
+	}
+	r := &AccountReconciler{
+		Client:           fake.NewClientBuilder().Build(),
+		Scheme:           scheme.Scheme,
+		awsClientBuilder: mockAWSBuilder,
+		shardName:        "test",
+		recorder:         &record.FakeRecorder{},
 	}
+
+	// The first step launches the instance
+	r.InitializeSupportedRegions(reqLogger.Logger(), account, creds, map[string]awsv1alpha1.AmiSpec{})
+	assert.Contains(t, reqLogger.Messages(), "Could not retrieve account claim for account.")
+	assert.Equal(t, awsv1alpha1.RegionInstanceLaunched, account.Status.Regions[0].Phase)
+	assert.Equal(t, "1", account.Status.Regions[0].InstanceID)
+	assert.False(t, account.RegionsInitialized())
+
+	// The next one terminates it once it runs
+	r.InitializeSupportedRegions(reqLogger.Logger(), account, creds, map[string]awsv1alpha1.AmiSpec{})
+	assert.Equal(t, awsv1alpha1.RegionInstanceTerminated, account.Status.Regions[0].Phase)
+	assert.True(t, account.RegionsInitialized())
+
+	// Done regions are left alone
+	r.InitializeSupportedRegions(reqLogger.Logger(), account, creds, map[string]awsv1alpha1.AmiSpec{})
+}
+
+func TestLaunchRegionInstancePending(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockAWSClient := mock.NewMockClient(ctrl)
+	mockAWSClient.EXPECT().DescribeInstances(gomock.Any()).Return(&ec2.DescribeInstancesOutput{}, nil).Times(2)
+	mockAWSClient.EXPECT().RunInstances(gomock.Any()).Return(nil, awserr.New("PendingVerification", "account pending verification", nil))
+
+	region := &awsv1alpha1.AccountRegionStatus{Name: "us-east-1"}
+	setRegionPhase(region, awsv1alpha1.RegionPending, "Region initialization pending")
+	r := &AccountReconciler{recorder: &record.FakeRecorder{}}
+
+	// The launch is taken again in the next reconcile
+	err := r.launchRegionInstance(testutils.NewTestLogger().Logger(), &newTestAccountBuilder().acct, mockAWSClient, region, awsv1alpha1.AmiSpec{}, nil, nil, nil, "", false)
+	assert.NoError(t, err)
+	assert.Equal(t, awsv1alpha1.RegionPending, region.Phase)
+	assert.Contains(t, region.Message, "PendingVerification")
+	assert.Empty(t, region.InstanceID)
+}
+
+func TestTerminateRegionInstance(t *testing.T) {
 	tests := []struct {
-		name   string
-		fields fields
-		args   args
+		name          string
+		launchedSince time.Duration
+		code          int64
+		network       *awsv1alpha1.AccountRegionNetwork
+		expectedPhase awsv1alpha1.AccountRegionPhase
+		terminate     bool
 	}{
-		{"Log failure to retrieve KMS Key from claim.",
-			fields{
-				Client:           fake.NewClientBuilder().Build(),
-				scheme:           scheme.Scheme,
-				awsClientBuilder: mockAWSBuilder,
-				shardName:        "test",
-			}, args{
-				reqLogger: testutils.NewTestLogger(),
-				account: &awsv1alpha1.Account{
-					ObjectMeta: metav1.ObjectMeta{
-						Name:      TestAccountName,
-						Namespace: TestAccountNamespace,
-					},
-				},
-				regions: []awsv1alpha1.AwsRegions{
-					{
-						Name: "us-east-1",
-					}},
-				creds: &sts.AssumeRoleOutput{
-					AssumedRoleUser: &sts.AssumedRoleUser{},
-					Credentials: &sts.Credentials{
-						AccessKeyId:     aws.String("123456"),
-						Expiration:      &time.Time{},
-						SecretAccessKey: aws.String("123456"),
-						SessionToken:    aws.String("123456"),
-					},
-					PackedPolicySize: new(int64),
-				},
-				regionAMIs: map[string]awsv1alpha1.AmiSpec{},
-			}},
+		{
+			name:          "Waits for the instance to run",
+			launchedSince: time.Minute,
+			code:          0,
+			expectedPhase: awsv1alpha1.RegionInstanceLaunched,
+		},
+		{
+			name:          "Terminates running instances",
+			launchedSince: time.Minute,
+			code:          16,
+			expectedPhase: awsv1alpha1.RegionInstanceTerminated,
+			terminate:     true,
+		},
+		{
+			name:          "Waits for the instance to terminate before deleting its network",
+			launchedSince: time.Minute,
+			code:          16,
+			network:       &awsv1alpha1.AccountRegionNetwork{VpcID: "vpc-123", SubnetID: "subnet-123"},
+			expectedPhase: awsv1alpha1.RegionInstanceTerminating,
+			terminate:     true,
+		},
+		{
+			name:          "Terminates instances that never ran",
+			launchedSince: regionInstanceWaitTime + time.Minute,
+			code:          0,
+			expectedPhase: awsv1alpha1.RegionInstanceTerminated,
+			terminate:     true,
+		},
 	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			r := &AccountReconciler{
-				Client:           tt.fields.Client,
-				Scheme:           tt.fields.scheme,
-				awsClientBuilder: tt.fields.awsClientBuilder,
-				shardName:        tt.fields.shardName,
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockAWSClient := mock.NewMockClient(ctrl)
+			mockAWSClient.EXPECT().DescribeInstanceStatus(gomock.Any()).Return(&ec2.DescribeInstanceStatusOutput{
+				InstanceStatuses: []*ec2.InstanceStatus{
+					{InstanceState: &ec2.InstanceState{Code: aws.Int64(test.code)}},
+				},
+			}, nil)
+			if test.terminate {
+				mockAWSClient.EXPECT().TerminateInstances(&ec2.TerminateInstancesInput{
+					InstanceIds: aws.StringSlice([]string{"i-123"}),
+				}).Return(&ec2.TerminateInstancesOutput{}, nil)
+			}
+
+			region := &awsv1alpha1.AccountRegionStatus{
+				Name:               "us-east-1",
+				Phase:              awsv1alpha1.RegionInstanceLaunched,
+				InstanceID:         "i-123",
+				Network:            test.network,
+				LastTransitionTime: metav1.NewTime(time.Now().Add(-test.launchedSince)),
 			}
-			r.InitializeSupportedRegions(tt.args.reqLogger.Logger(), tt.args.account, tt.args.regions, tt.args.creds, tt.args.regionAMIs)
-			assert.Contains(t, tt.args.reqLogger.Messages(), "Could not retrieve account claim for account.")
+			r := &AccountReconciler{recorder: &record.FakeRecorder{}}
+			err := r.terminateRegionInstance(testutils.NewTestLogger().Logger(), &newTestAccountBuilder().acct, mockAWSClient, region)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectedPhase, region.Phase)
 		})
 	}
 }
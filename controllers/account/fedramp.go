@@ -0,0 +1,110 @@
+package account
+
+import (
+	"fmt"
+
+	"github.com/go-logr/logr"
+	awsv1alpha1 "github.com/ravitri/aws-account-operator/api/v1alpha1"
+	"github.com/ravitri/aws-account-operator/pkg/awsclient"
+	controllerutils "github.com/ravitri/aws-account-operator/pkg/utils"
+)
+
+// createFedrampNetwork creates the parts of the network a fedramp region is initialized in that are
+// missing, as fedramp accounts have no default VPC: the VPC, then a subnet in it. Every part is recorded
+// in the network once created, so that it can be deleted and a pending step resumes where it stopped.
+func createFedrampNetwork(reqLogger logr.Logger, client awsclient.Client, account *awsv1alpha1.Account, network *awsv1alpha1.AccountRegionNetwork, managedTags []awsclient.AWSTag, customTags []awsclient.AWSTag) error {
+	if network.VpcID == "" {
+		vpcID, err := createVpc(reqLogger, client, account, managedTags, customTags)
+		network.VpcID = vpcID
+		if err != nil {
+			return err
+		}
+		if vpcID == "" {
+			return awsv1alpha1.ErrFailedToCreateVpc
+		}
+	}
+	if network.SubnetID == "" {
+		subnetID, err := createSubnet(reqLogger, client, account, managedTags, customTags, sampleCIDR, network.VpcID)
+		network.SubnetID = subnetID
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteFedrampNetwork deletes the subnet, then the VPC, of a fedramp region network. Deleted parts
+// are removed from the network, so a pending deletion resumes where it stopped.
+func deleteFedrampNetwork(reqLogger logr.Logger, client awsclient.Client, network *awsv1alpha1.AccountRegionNetwork) error {
+	if network.SubnetID != "" {
+		err := deleteSubnet(reqLogger, client, network.SubnetID)
+		if err != nil {
+			return err
+		}
+		network.SubnetID = ""
+	}
+	if network.VpcID == "" {
+		return nil
+	}
+	err := deleteVpc(reqLogger, client, network.VpcID)
+	if err != nil {
+		return err
+	}
+	network.VpcID = ""
+	return nil
+}
+
+// deleteRegionNetwork deletes the fedramp network of the region once its EC2 instance, if any, is
+// terminated, as the network can't be deleted while the instance uses it. It returns true once the
+// network is deleted, and false while it waits for the instance or for a pending deletion.
+func deleteRegionNetwork(reqLogger logr.Logger, client awsclient.Client, region *awsv1alpha1.AccountRegionStatus) (bool, error) {
+	if region.Network == nil {
+		return true, nil
+	}
+	if region.InstanceID != "" {
+		terminated, err := ec2InstanceTerminated(reqLogger, client, region.InstanceID)
+		if err != nil || !terminated {
+			return false, err
+		}
+	}
+
+	err := deleteFedrampNetwork(reqLogger, client, region.Network)
+	if isRegionStepPending(err) {
+		reqLogger.Info("fedramp network deletion pending", "region", region.Name, "reason", err.Error())
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	region.Network = nil
+	return true, nil
+}
+
+// deleteTerminatedInstanceNetwork deletes the fedramp network of a region whose EC2 instance is
+// terminating, and records the region as initialized once it is deleted
+func (r *AccountReconciler) deleteTerminatedInstanceNetwork(reqLogger logr.Logger, account *awsv1alpha1.Account, client awsclient.Client, region *awsv1alpha1.AccountRegionStatus) error {
+	deleted, err := deleteRegionNetwork(reqLogger, client, region)
+	if err != nil {
+		deleteResourcesErr := fmt.Sprintf("Unable to delete fedramp network in region: %s", region.Name)
+		controllerutils.LogAwsError(reqLogger, deleteResourcesErr, nil, err)
+		r.setRegionFailed(account, region, err, "RegionCleanupFailed", deleteResourcesErr)
+		return err
+	}
+	if !deleted {
+		return nil
+	}
+	r.setRegionInitialized(account, region, fmt.Sprintf("EC2 instance created and terminated successfully in region: %s", region.Name))
+	return nil
+}
+
+// deleteFailedRegionNetwork deletes the fedramp network of a region that failed to initialize. Errors
+// are only logged and the network forgotten, the tag based cleanup of the next initialization removes
+// what is left.
+func deleteFailedRegionNetwork(reqLogger logr.Logger, client awsclient.Client, region *awsv1alpha1.AccountRegionStatus) {
+	_, err := deleteRegionNetwork(reqLogger, client, region)
+	if err != nil {
+		deleteErr := fmt.Sprintf("Unable to delete fedramp network in region: %s", region.Name)
+		controllerutils.LogAwsError(reqLogger, deleteErr, nil, err)
+		region.Network = nil
+	}
+}
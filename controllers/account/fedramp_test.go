@@ -0,0 +1,251 @@
+package account
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/golang/mock/gomock"
+	awsv1alpha1 "github.com/ravitri/aws-account-operator/api/v1alpha1"
+	"github.com/ravitri/aws-account-operator/pkg/awsclient/mock"
+	"github.com/ravitri/aws-account-operator/pkg/testutils"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/client-go/tools/record"
+)
+
+// expectFedrampNetwork sets the mock client up to create a VPC and a subnet in it
+func expectFedrampNetwork(mockAWSClient *mock.MockClient, vpcID, subnetID string) {
+	mockAWSClient.EXPECT().CreateVpc(gomock.Any()).Return(&ec2.CreateVpcOutput{
+		Vpc: &ec2.Vpc{VpcId: aws.String(vpcID)},
+	}, nil)
+	mockAWSClient.EXPECT().CreateSubnet(gomock.Any()).DoAndReturn(func(input *ec2.CreateSubnetInput) (*ec2.CreateSubnetOutput, error) {
+		if aws.StringValue(input.VpcId) != vpcID {
+			return nil, awserr.New("InvalidVpcID.NotFound", "unexpected vpc", nil)
+		}
+		return &ec2.CreateSubnetOutput{Subnet: &ec2.Subnet{SubnetId: aws.String(subnetID)}}, nil
+	})
+}
+
+func TestCreateFedrampNetwork(t *testing.T) {
+	tests := []struct {
+		name            string
+		network         *awsv1alpha1.AccountRegionNetwork
+		vpcErr          error
+		subnetErr       error
+		expectedNetwork *awsv1alpha1.AccountRegionNetwork
+		expectErr       bool
+		expectPending   bool
+	}{
+		{
+			name:            "Creates a subnet in a VPC of its own",
+			network:         &awsv1alpha1.AccountRegionNetwork{},
+			expectedNetwork: &awsv1alpha1.AccountRegionNetwork{VpcID: "vpc-123", SubnetID: "subnet-123"},
+		},
+		{
+			name:            "Records the VPC when the subnet fails",
+			network:         &awsv1alpha1.AccountRegionNetwork{},
+			subnetErr:       awserr.New("InvalidParameterValue", "invalid cidr", nil),
+			expectedNetwork: &awsv1alpha1.AccountRegionNetwork{VpcID: "vpc-123"},
+			expectErr:       true,
+		},
+		{
+			name:            "Waits for the region to be enabled",
+			network:         &awsv1alpha1.AccountRegionNetwork{},
+			vpcErr:          awserr.New("OptInRequired", "region not enabled", nil),
+			expectedNetwork: &awsv1alpha1.AccountRegionNetwork{},
+			expectErr:       true,
+			expectPending:   true,
+		},
+		{
+			name:            "Resumes with the subnet of a recorded VPC",
+			network:         &awsv1alpha1.AccountRegionNetwork{VpcID: "vpc-123"},
+			expectedNetwork: &awsv1alpha1.AccountRegionNetwork{VpcID: "vpc-123", SubnetID: "subnet-123"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockAWSClient := mock.NewMockClient(ctrl)
+
+			if test.network.VpcID == "" {
+				if test.vpcErr != nil {
+					mockAWSClient.EXPECT().CreateVpc(gomock.Any()).Return(nil, test.vpcErr)
+				} else {
+					mockAWSClient.EXPECT().CreateVpc(gomock.Any()).Return(&ec2.CreateVpcOutput{
+						Vpc: &ec2.Vpc{VpcId: aws.String("vpc-123")},
+					}, nil)
+				}
+			}
+			if test.vpcErr == nil {
+				if test.subnetErr != nil {
+					mockAWSClient.EXPECT().CreateSubnet(gomock.Any()).Return(nil, test.subnetErr)
+				} else {
+					mockAWSClient.EXPECT().CreateSubnet(gomock.Any()).Return(&ec2.CreateSubnetOutput{
+						Subnet: &ec2.Subnet{SubnetId: aws.String("subnet-123")},
+					}, nil)
+				}
+			}
+
+			account := newTestAccountBuilder().acct
+			err := createFedrampNetwork(testutils.NewTestLogger().Logger(), mockAWSClient, &account, test.network, nil, nil)
+			if test.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, test.expectPending, isRegionStepPending(err))
+			assert.Equal(t, test.expectedNetwork, test.network)
+		})
+	}
+}
+
+func TestCreateFedrampNetworkConcurrently(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Regions initialize concurrently, each must get the network it created
+	regions := []awsv1alpha1.AccountRegionStatus{{Name: "us-gov-east-1"}, {Name: "us-gov-west-1"}}
+	clients := []*mock.MockClient{mock.NewMockClient(ctrl), mock.NewMockClient(ctrl)}
+	expectFedrampNetwork(clients[0], "vpc-east", "subnet-east")
+	expectFedrampNetwork(clients[1], "vpc-west", "subnet-west")
+
+	account := newTestAccountBuilder().acct
+	var wg sync.WaitGroup
+	for i := range regions {
+		wg.Add(1)
+		go func(region *awsv1alpha1.AccountRegionStatus, client *mock.MockClient) {
+			defer wg.Done()
+			region.Network = &awsv1alpha1.AccountRegionNetwork{}
+			err := createFedrampNetwork(testutils.NewTestLogger().Logger(), client, &account, region.Network, nil, nil)
+			assert.NoError(t, err)
+		}(&regions[i], clients[i])
+	}
+	wg.Wait()
+
+	assert.Equal(t, &awsv1alpha1.AccountRegionNetwork{VpcID: "vpc-east", SubnetID: "subnet-east"}, regions[0].Network)
+	assert.Equal(t, &awsv1alpha1.AccountRegionNetwork{VpcID: "vpc-west", SubnetID: "subnet-west"}, regions[1].Network)
+}
+
+func TestDeleteFedrampNetwork(t *testing.T) {
+	tests := []struct {
+		name    string
+		network *awsv1alpha1.AccountRegionNetwork
+	}{
+		{
+			name:    "Deletes the subnet then the VPC",
+			network: &awsv1alpha1.AccountRegionNetwork{VpcID: "vpc-123", SubnetID: "subnet-123"},
+		},
+		{
+			name:    "Deletes a VPC without subnet",
+			network: &awsv1alpha1.AccountRegionNetwork{VpcID: "vpc-123"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockAWSClient := mock.NewMockClient(ctrl)
+
+			var calls []*gomock.Call
+			if test.network.SubnetID != "" {
+				calls = append(calls, mockAWSClient.EXPECT().DeleteSubnet(&ec2.DeleteSubnetInput{
+					SubnetId: aws.String(test.network.SubnetID),
+				}).Return(&ec2.DeleteSubnetOutput{}, nil))
+			}
+			calls = append(calls, mockAWSClient.EXPECT().DeleteVpc(&ec2.DeleteVpcInput{
+				VpcId: aws.String(test.network.VpcID),
+			}).Return(&ec2.DeleteVpcOutput{}, nil))
+			gomock.InOrder(calls...)
+
+			err := deleteFedrampNetwork(testutils.NewTestLogger().Logger(), mockAWSClient, test.network)
+			assert.NoError(t, err)
+			assert.Equal(t, &awsv1alpha1.AccountRegionNetwork{}, test.network)
+		})
+	}
+}
+
+func TestDeleteTerminatedInstanceNetwork(t *testing.T) {
+	tests := []struct {
+		name            string
+		instanceState   string
+		vpcErr          error
+		expectedPhase   awsv1alpha1.AccountRegionPhase
+		expectedNetwork *awsv1alpha1.AccountRegionNetwork
+	}{
+		{
+			name:            "Waits for the instance to terminate",
+			instanceState:   ec2.InstanceStateNameShuttingDown,
+			expectedPhase:   awsv1alpha1.RegionInstanceTerminating,
+			expectedNetwork: &awsv1alpha1.AccountRegionNetwork{VpcID: "vpc-123", SubnetID: "subnet-123"},
+		},
+		{
+			name:          "Deletes the network of terminated instances",
+			instanceState: ec2.InstanceStateNameTerminated,
+			expectedPhase: awsv1alpha1.RegionInstanceTerminated,
+		},
+		{
+			name:            "Resumes a pending VPC deletion on requeue",
+			instanceState:   ec2.InstanceStateNameTerminated,
+			vpcErr:          awserr.New("DependencyViolation", "vpc has dependencies", nil),
+			expectedPhase:   awsv1alpha1.RegionInstanceTerminating,
+			expectedNetwork: &awsv1alpha1.AccountRegionNetwork{VpcID: "vpc-123"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockAWSClient := mock.NewMockClient(ctrl)
+			mockAWSClient.EXPECT().DescribeInstances(&ec2.DescribeInstancesInput{
+				InstanceIds: aws.StringSlice([]string{"i-123"}),
+			}).Return(&ec2.DescribeInstancesOutput{
+				Reservations: []*ec2.Reservation{{
+					Instances: []*ec2.Instance{{
+						InstanceId: aws.String("i-123"),
+						State:      &ec2.InstanceState{Name: aws.String(test.instanceState)},
+					}},
+				}},
+			}, nil)
+			if test.instanceState == ec2.InstanceStateNameTerminated {
+				mockAWSClient.EXPECT().DeleteSubnet(gomock.Any()).Return(&ec2.DeleteSubnetOutput{}, nil)
+				mockAWSClient.EXPECT().DeleteVpc(gomock.Any()).Return(&ec2.DeleteVpcOutput{}, test.vpcErr)
+			}
+
+			region := &awsv1alpha1.AccountRegionStatus{
+				Name:       "us-gov-east-1",
+				Phase:      awsv1alpha1.RegionInstanceTerminating,
+				InstanceID: "i-123",
+				Network:    &awsv1alpha1.AccountRegionNetwork{VpcID: "vpc-123", SubnetID: "subnet-123"},
+			}
+			r := &AccountReconciler{recorder: &record.FakeRecorder{}}
+			err := r.deleteTerminatedInstanceNetwork(testutils.NewTestLogger().Logger(), &newTestAccountBuilder().acct, mockAWSClient, region)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectedPhase, region.Phase)
+			assert.Equal(t, test.expectedNetwork, region.Network)
+		})
+	}
+}
+
+func TestDeleteFailedRegionNetwork(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockAWSClient := mock.NewMockClient(ctrl)
+	mockAWSClient.EXPECT().DescribeInstances(gomock.Any()).Return(nil, awserr.New("InvalidInstanceID.NotFound", "instance not found", nil))
+	mockAWSClient.EXPECT().DeleteVpc(&ec2.DeleteVpcInput{VpcId: aws.String("vpc-123")}).Return(&ec2.DeleteVpcOutput{}, nil)
+
+	// A failed region is only done once its network is deleted
+	region := &awsv1alpha1.AccountRegionStatus{
+		Name:       "us-gov-east-1",
+		Phase:      awsv1alpha1.RegionFailed,
+		InstanceID: "i-123",
+		Network:    &awsv1alpha1.AccountRegionNetwork{VpcID: "vpc-123"},
+	}
+	assert.False(t, region.IsDone())
+
+	deleteFailedRegionNetwork(testutils.NewTestLogger().Logger(), mockAWSClient, region)
+	assert.Nil(t, region.Network)
+	assert.True(t, region.IsDone())
+}
@@ -7,12 +7,12 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
-// GenerateAccountCR returns new account CR struct
-func GenerateAccountCR(namespace string) *awsv1alpha1.Account {
+// GenerateAccountCR returns new account CR struct, named after the prefix
+func GenerateAccountCR(namespace string, namePrefix string) *awsv1alpha1.Account {
 
 	uuid := utils.GenerateShortUID()
 
-	accountName := GenerateAccountCRName(uuid)
+	accountName := GenerateAccountCRName(namePrefix, uuid)
 
 	return &awsv1alpha1.Account{
 		ObjectMeta: metav1.ObjectMeta{
@@ -29,8 +29,10 @@ func GenerateAccountCR(namespace string) *awsv1alpha1.Account {
 	}
 }
 
-// GenerateAccountCRName return a formatted Account CR name
-func GenerateAccountCRName(uuid string) string {
-	emailID := awsv1alpha1.EmailID
-	return fmt.Sprintf("%s-%s", emailID, uuid)
+// GenerateAccountCRName return a formatted Account CR name, EmailID is the default prefix
+func GenerateAccountCRName(namePrefix string, uuid string) string {
+	if namePrefix == "" {
+		namePrefix = awsv1alpha1.EmailID
+	}
+	return fmt.Sprintf("%s-%s", namePrefix, uuid)
 }
@@ -65,7 +65,7 @@ func (r *AccountReconciler) CreateSecret(reqLogger logr.Logger, account *awsv1al
 	createErr := r.Client.Create(context.TODO(), secret)
 	if createErr != nil {
 		failedToCreateUserSecretMsg := fmt.Sprintf("Failed to create secret %s", secret.Name)
-		utils.SetAccountStatus(account, failedToCreateUserSecretMsg, awsv1alpha1.AccountFailed, "Failed")
+		r.setAccountStatus(account, failedToCreateUserSecretMsg, awsv1alpha1.AccountFailed, "Failed")
 		err := r.Client.Status().Update(context.TODO(), account)
 		if err != nil {
 			return err
@@ -362,7 +362,7 @@ func (r *AccountReconciler) BuildIAMUser(reqLogger logr.Logger, awsClient awscli
 	}
 
 	// Get list of managed tags.
-	managedTags := r.getManagedTags(reqLogger)
+	managedTags := r.getManagedTags(reqLogger, account)
 	customTags := r.getCustomTags(reqLogger, account)
 
 	// Create IAM user in AWS if it doesn't exist
@@ -841,7 +841,7 @@ func (r *AccountReconciler) ValidateIAMSecret(reqLogger logr.Logger, awsClient a
 	}
 
 	// Get list of managed tags.
-	managedTags := r.getManagedTags(reqLogger)
+	managedTags := r.getManagedTags(reqLogger, account)
 	customTags := r.getCustomTags(reqLogger, account)
 
 	var iamAccessKeyOutput *iam.CreateAccessKeyOutput
@@ -896,6 +896,25 @@ func (r *AccountReconciler) ValidateIAMSecret(reqLogger logr.Logger, awsClient a
 }
 
 func (r *AccountReconciler) updateIAMUserSecret(reqLogger logr.Logger, account *awsv1alpha1.Account, secretName types.NamespacedName, createAccessKeyOutput *iam.CreateAccessKeyOutput) error {
+	updateErr := r.writeIAMUserSecret(account, secretName, createAccessKeyOutput)
+	if updateErr != nil {
+		failedToUpdateUserSecretMsg := fmt.Sprintf("Failed to update secret %s", secretName.Name)
+		r.setAccountStatus(account, failedToUpdateUserSecretMsg, awsv1alpha1.AccountFailed, "Failed")
+		err := r.Client.Status().Update(context.TODO(), account)
+		if err != nil {
+			reqLogger.Error(updateErr, failedToUpdateUserSecretMsg)
+			return err
+		}
+		reqLogger.Info(failedToUpdateUserSecretMsg)
+		return updateErr
+	}
+	reqLogger.Info(fmt.Sprintf("Updated secret %s", secretName.Name))
+	return nil
+}
+
+// writeIAMUserSecret replaces the content of the IAM user secret with the access key, without
+// touching the account status
+func (r *AccountReconciler) writeIAMUserSecret(account *awsv1alpha1.Account, secretName types.NamespacedName, createAccessKeyOutput *iam.CreateAccessKeyOutput) error {
 
 	// Fill in the secret data
 	userSecretData := map[string][]byte{
@@ -912,19 +931,5 @@ func (r *AccountReconciler) updateIAMUserSecret(reqLogger logr.Logger, account *
 		return err
 	}
 
-	// Update secret
-	updateErr := r.Client.Update(context.TODO(), iamUserSecret)
-	if updateErr != nil {
-		failedToUpdateUserSecretMsg := fmt.Sprintf("Failed to update secret %s", iamUserSecret.Name)
-		utils.SetAccountStatus(account, failedToUpdateUserSecretMsg, awsv1alpha1.AccountFailed, "Failed")
-		err := r.Client.Status().Update(context.TODO(), account)
-		if err != nil {
-			reqLogger.Error(updateErr, failedToUpdateUserSecretMsg)
-			return err
-		}
-		reqLogger.Info(failedToUpdateUserSecretMsg)
-		return updateErr
-	}
-	reqLogger.Info(fmt.Sprintf("Updated secret %s", iamUserSecret.Name))
-	return nil
+	return r.Client.Update(context.TODO(), iamUserSecret)
 }
@@ -23,6 +23,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
 )
 
 func init() {
@@ -54,8 +55,9 @@ func TestIAMCreateSecret(t *testing.T) {
 	defer mocks.mockCtrl.Finish()
 
 	r := AccountReconciler{
-		Client: mocks.fakeKubeClient,
-		Scheme: scheme.Scheme,
+		Client:   mocks.fakeKubeClient,
+		Scheme:   scheme.Scheme,
+		recorder: &record.FakeRecorder{},
 	}
 
 	nullLogger := testutils.NewTestLogger().Logger()
@@ -508,8 +510,9 @@ func TestBuildIAMUser(t *testing.T) {
 	}).Return(&iam.AttachUserPolicyOutput{}, nil)
 
 	r := AccountReconciler{
-		Client: mocks.fakeKubeClient,
-		Scheme: scheme.Scheme,
+		Client:   mocks.fakeKubeClient,
+		Scheme:   scheme.Scheme,
+		recorder: &record.FakeRecorder{},
 	}
 
 	nullLogger := testutils.NewTestLogger().Logger()
@@ -695,8 +698,9 @@ func TestRotateIAMAccessKeys(t *testing.T) {
 	expectedAccessKeyId := "expectedAccessKeyID"
 
 	r := AccountReconciler{
-		Client: mocks.fakeKubeClient,
-		Scheme: scheme.Scheme,
+		Client:   mocks.fakeKubeClient,
+		Scheme:   scheme.Scheme,
+		recorder: &record.FakeRecorder{},
 	}
 	iamUser := iam.User{
 		UserName: &expectedUsername,
@@ -832,8 +836,9 @@ func TestCreateIAMUserSecret(t *testing.T) {
 	defer mocks.mockCtrl.Finish()
 
 	r := AccountReconciler{
-		Client: mocks.fakeKubeClient,
-		Scheme: scheme.Scheme,
+		Client:   mocks.fakeKubeClient,
+		Scheme:   scheme.Scheme,
+		recorder: &record.FakeRecorder{},
 	}
 
 	createAccessKeyOutput := iam.CreateAccessKeyOutput{
@@ -867,8 +872,9 @@ func TestDoesSecretExist(t *testing.T) {
 	mocks := setupDefaultMocks(t, localObjects)
 
 	r := AccountReconciler{
-		Client: mocks.fakeKubeClient,
-		Scheme: scheme.Scheme,
+		Client:   mocks.fakeKubeClient,
+		Scheme:   scheme.Scheme,
+		recorder: &record.FakeRecorder{},
 	}
 
 	namespace := types.NamespacedName{
@@ -1006,8 +1012,9 @@ func TestValidateIAMSecret(t *testing.T) {
 	)
 
 	r := AccountReconciler{
-		Client: mocks.fakeKubeClient,
-		Scheme: scheme.Scheme,
+		Client:   mocks.fakeKubeClient,
+		Scheme:   scheme.Scheme,
+		recorder: &record.FakeRecorder{},
 	}
 
 	nullLogger := testutils.NewTestLogger().Logger()
@@ -1070,6 +1077,7 @@ func TestIsKubeSecretValid(t *testing.T) {
 		Client:           mocks.fakeKubeClient,
 		Scheme:           scheme.Scheme,
 		awsClientBuilder: mockIBuilder,
+		recorder:         &record.FakeRecorder{},
 	}
 
 	mockIBuilder.EXPECT().GetClient(controllerName,
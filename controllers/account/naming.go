@@ -0,0 +1,144 @@
+package account
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/organizations"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	awsv1alpha1 "github.com/ravitri/aws-account-operator/api/v1alpha1"
+	"github.com/ravitri/aws-account-operator/pkg/awsclient"
+	"github.com/ravitri/aws-account-operator/pkg/utils"
+)
+
+const (
+	// accountNamePrefixConfigMapKey is the prefix of the generated Account CR names
+	accountNamePrefixConfigMapKey = "account.name-prefix"
+	// accountEmailTemplateConfigMapKey is the template of the root email of the AWS accounts
+	accountEmailTemplateConfigMapKey = "account.email-template"
+	// accountNameTemplateConfigMapKey is the template of the name of the AWS accounts
+	accountNameTemplateConfigMapKey = "account.name-template"
+	// accountUniqueCheckConfigMapKey checks the email and name of new AWS accounts against the organization
+	accountUniqueCheckConfigMapKey = "account.unique-check"
+
+	// defaultAccountEmailTemplate plus-addresses the emails of the EmailID mailbox, e.g. osd-creds-mgmt+abc123@redhat.com
+	defaultAccountEmailTemplate = "{{.Prefix}}+{{.Suffix}}@redhat.com"
+	// defaultAccountNameTemplate names the AWS accounts after their Account CR
+	defaultAccountNameTemplate = "{{.Name}}"
+)
+
+// accountNameData is what the email and name templates are executed with. For the Account
+// osd-creds-mgmt-abc123 Name is osd-creds-mgmt-abc123, Prefix osd-creds-mgmt and Suffix abc123.
+type accountNameData struct {
+	Name   string
+	Prefix string
+	Suffix string
+}
+
+// newAccountNameData splits the Account CR name on its last dash. A name without dash is both the
+// prefix and the suffix.
+func newAccountNameData(name string) accountNameData {
+	data := accountNameData{Name: name, Prefix: name, Suffix: name}
+	if i := strings.LastIndex(name, "-"); i >= 0 {
+		data.Prefix = name[:i]
+		data.Suffix = name[i+1:]
+	}
+	return data
+}
+
+// GetAccountNamePrefix returns the prefix of the Account CR names from the operator configmap, or EmailID
+func GetAccountNamePrefix(kubeClient client.Client) string {
+	configMap, err := utils.GetOperatorConfigMap(kubeClient)
+	if err != nil || configMap.Data[accountNamePrefixConfigMapKey] == "" {
+		return awsv1alpha1.EmailID
+	}
+	return configMap.Data[accountNamePrefixConfigMapKey]
+}
+
+// formatAccountEmail returns the root email of the AWS account of the Account CR with the given name
+func formatAccountEmail(name string, emailTemplate string) (string, error) {
+	if emailTemplate == "" {
+		emailTemplate = defaultAccountEmailTemplate
+	}
+	email, err := executeNameTemplate(name, emailTemplate)
+	if err != nil {
+		return "", err
+	}
+	if strings.Count(email, "@") != 1 {
+		return "", fmt.Errorf("email template %q produced %q, which is not an email address", emailTemplate, email)
+	}
+	return email, nil
+}
+
+// formatAccountName returns the name of the AWS account of the Account CR with the given name
+func formatAccountName(name string, nameTemplate string) (string, error) {
+	if nameTemplate == "" {
+		nameTemplate = defaultAccountNameTemplate
+	}
+	return executeNameTemplate(name, nameTemplate)
+}
+
+// executeNameTemplate executes the text template with the parts of the Account CR name
+func executeNameTemplate(name string, text string) (string, error) {
+	tmpl, err := template.New("account").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", err
+	}
+	var out bytes.Buffer
+	if err := tmpl.Execute(&out, newAccountNameData(name)); err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(out.String()), nil
+}
+
+// getAccountEmailAndName returns the root email and the name of the AWS account of the Account from
+// the templates of the operator configmap
+func getAccountEmailAndName(account *awsv1alpha1.Account, configMap *corev1.ConfigMap) (string, string, error) {
+	email, err := formatAccountEmail(account.Name, configMap.Data[accountEmailTemplateConfigMapKey])
+	if err != nil {
+		return "", "", err
+	}
+	name, err := formatAccountName(account.Name, configMap.Data[accountNameTemplateConfigMapKey])
+	if err != nil {
+		return "", "", err
+	}
+	return email, name, nil
+}
+
+// accountUniqueCheckEnabled returns true if new AWS accounts must be checked against the organization first
+func accountUniqueCheckEnabled(configMap *corev1.ConfigMap) bool {
+	enabled, err := strconv.ParseBool(configMap.Data[accountUniqueCheckConfigMapKey])
+	return err == nil && enabled
+}
+
+// checkAccountUnique looks for an account of the organization that already has the email or the name,
+// and returns why the new account would conflict with it, or an empty string
+func checkAccountUnique(reqLogger logr.Logger, awsClient awsclient.Client, email string, name string) (string, error) {
+	input := &organizations.ListAccountsInput{}
+	for {
+		output, err := awsClient.ListAccounts(input)
+		if err != nil {
+			utils.LogAwsError(reqLogger, "failed listing the accounts of the organization", nil, err)
+			return "", err
+		}
+		for _, account := range output.Accounts {
+			if strings.EqualFold(aws.StringValue(account.Email), email) {
+				return fmt.Sprintf("AWS account %s already has the email %s", aws.StringValue(account.Id), email), nil
+			}
+			if aws.StringValue(account.Name) == name {
+				return fmt.Sprintf("AWS account %s already has the name %s", aws.StringValue(account.Id), name), nil
+			}
+		}
+		if output.NextToken == nil {
+			return "", nil
+		}
+		input.NextToken = output.NextToken
+	}
+}
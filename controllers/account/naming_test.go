@@ -0,0 +1,137 @@
+package account
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/organizations"
+	"github.com/golang/mock/gomock"
+	"github.com/ravitri/aws-account-operator/pkg/awsclient/mock"
+	"github.com/ravitri/aws-account-operator/pkg/testutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatAccountEmail(t *testing.T) {
+	tests := []struct {
+		name          string
+		accountName   string
+		emailTemplate string
+		expected      string
+		expectErr     bool
+	}{
+		{
+			name:        "Plus-addresses the default mailbox",
+			accountName: "osd-creds-mgmt-abc123",
+			expected:    "osd-creds-mgmt+abc123@redhat.com",
+		},
+		{
+			name:        "Uses the whole name without dash",
+			accountName: "abc123",
+			expected:    "abc123+abc123@redhat.com",
+		},
+		{
+			name:          "Supports another domain",
+			accountName:   "osd-creds-mgmt-abc123",
+			emailTemplate: "aws+{{.Suffix}}@example.com",
+			expected:      "aws+abc123@example.com",
+		},
+		{
+			name:          "Supports catch-all aliases",
+			accountName:   "osd-creds-mgmt-abc123",
+			emailTemplate: "{{.Name}}@accounts.example.com",
+			expected:      "osd-creds-mgmt-abc123@accounts.example.com",
+		},
+		{
+			name:          "Rejects templates that are not emails",
+			accountName:   "osd-creds-mgmt-abc123",
+			emailTemplate: "{{.Name}}",
+			expectErr:     true,
+		},
+		{
+			name:          "Rejects unknown fields",
+			accountName:   "osd-creds-mgmt-abc123",
+			emailTemplate: "{{.Domain}}@example.com",
+			expectErr:     true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			email, err := formatAccountEmail(test.accountName, test.emailTemplate)
+			if test.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, email)
+		})
+	}
+}
+
+func TestFormatAccountName(t *testing.T) {
+	name, err := formatAccountName("osd-creds-mgmt-abc123", "")
+	assert.NoError(t, err)
+	assert.Equal(t, "osd-creds-mgmt-abc123", name)
+
+	name, err = formatAccountName("osd-creds-mgmt-abc123", "example-{{.Suffix}}")
+	assert.NoError(t, err)
+	assert.Equal(t, "example-abc123", name)
+}
+
+func TestGenerateAccountCRName(t *testing.T) {
+	assert.Equal(t, "osd-creds-mgmt-abc123", GenerateAccountCRName("", "abc123"))
+	assert.Equal(t, "example-abc123", GenerateAccountCRName("example", "abc123"))
+}
+
+func TestCheckAccountUnique(t *testing.T) {
+	tests := []struct {
+		name           string
+		email          string
+		accName        string
+		expectConflict bool
+	}{
+		{
+			name:    "Passes new emails and names",
+			email:   "osd-creds-mgmt+new@redhat.com",
+			accName: "osd-creds-mgmt-new",
+		},
+		{
+			name:           "Catches emails in use on any page",
+			email:          "OSD-creds-mgmt+second@redhat.com",
+			accName:        "osd-creds-mgmt-new",
+			expectConflict: true,
+		},
+		{
+			name:           "Catches names in use",
+			email:          "osd-creds-mgmt+new@redhat.com",
+			accName:        "osd-creds-mgmt-first",
+			expectConflict: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockAWSClient := mock.NewMockClient(ctrl)
+
+			mockAWSClient.EXPECT().ListAccounts(&organizations.ListAccountsInput{}).Return(&organizations.ListAccountsOutput{
+				Accounts: []*organizations.Account{{
+					Id:    aws.String("111111111111"),
+					Email: aws.String("osd-creds-mgmt+first@redhat.com"),
+					Name:  aws.String("osd-creds-mgmt-first"),
+				}},
+				NextToken: aws.String("page2"),
+			}, nil)
+			mockAWSClient.EXPECT().ListAccounts(&organizations.ListAccountsInput{NextToken: aws.String("page2")}).Return(&organizations.ListAccountsOutput{
+				Accounts: []*organizations.Account{{
+					Id:    aws.String("222222222222"),
+					Email: aws.String("osd-creds-mgmt+second@redhat.com"),
+					Name:  aws.String("osd-creds-mgmt-second"),
+				}},
+			}, nil).MaxTimes(1)
+
+			conflict, err := checkAccountUnique(testutils.NewTestLogger().Logger(), mockAWSClient, test.email, test.accName)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectConflict, conflict != "")
+		})
+	}
+}
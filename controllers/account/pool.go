@@ -0,0 +1,80 @@
+package account
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+
+	awsv1alpha1 "github.com/ravitri/aws-account-operator/api/v1alpha1"
+	"github.com/ravitri/aws-account-operator/config"
+	"github.com/ravitri/aws-account-operator/pkg/awsclient"
+)
+
+// getAccountTemplate returns the AccountTemplate of the AccountPool the account was created by.
+// Accounts that don't belong to a pool, or whose pool is gone, get nil and use the configmap defaults.
+func (r *AccountReconciler) getAccountTemplate(reqLogger logr.Logger, account *awsv1alpha1.Account) *awsv1alpha1.AccountTemplate {
+	poolName := account.GetAccountPoolName()
+	if poolName == "" {
+		return nil
+	}
+
+	accountPool := &awsv1alpha1.AccountPool{}
+	err := r.Client.Get(context.TODO(), types.NamespacedName{Namespace: account.Namespace, Name: poolName}, accountPool)
+	if err != nil {
+		if !k8serr.IsNotFound(err) {
+			reqLogger.Error(err, "failed getting accountpool, using configmap defaults", "accountpool", poolName)
+		}
+		return nil
+	}
+	return &accountPool.Spec.AccountTemplate
+}
+
+// isFedrampAccount returns whether the account is initialized the fedramp way,
+// which the pool of the account can override
+func (r *AccountReconciler) isFedrampAccount(reqLogger logr.Logger, account *awsv1alpha1.Account) bool {
+	template := r.getAccountTemplate(reqLogger, account)
+	if template != nil && template.Fedramp != nil {
+		return *template.Fedramp
+	}
+	return config.IsFedramp()
+}
+
+// templateRegionAMIs returns the regions of a pool template keyed the same way as getRegionAMIs
+func templateRegionAMIs(template *awsv1alpha1.AccountTemplate) map[string]awsv1alpha1.AmiSpec {
+	regionAMIs := make(map[string]awsv1alpha1.AmiSpec, len(template.Regions))
+	for _, region := range template.Regions {
+		regionAMIs[region.Name] = awsv1alpha1.AmiSpec{
+			Ami:          region.Ami,
+			InstanceType: region.InstanceType,
+		}
+	}
+	return regionAMIs
+}
+
+// filterRegions keeps the regions that have an AMI in regionAMIs
+func filterRegions(regions []awsv1alpha1.AwsRegions, regionAMIs map[string]awsv1alpha1.AmiSpec) []awsv1alpha1.AwsRegions {
+	var filtered []awsv1alpha1.AwsRegions
+	for _, region := range regions {
+		if _, ok := regionAMIs[region.Name]; ok {
+			filtered = append(filtered, region)
+		}
+	}
+	return filtered
+}
+
+// mergeTags returns the tags with the overrides applied on top, an override wins over a tag with the same key
+func mergeTags(tags []awsclient.AWSTag, overrides []awsclient.AWSTag) []awsclient.AWSTag {
+	merged := []awsclient.AWSTag{}
+	overridden := map[string]bool{}
+	for _, tag := range overrides {
+		overridden[tag.Key] = true
+	}
+	for _, tag := range tags {
+		if !overridden[tag.Key] {
+			merged = append(merged, tag)
+		}
+	}
+	return append(merged, overrides...)
+}
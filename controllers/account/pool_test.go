@@ -0,0 +1,110 @@
+package account
+
+import (
+	"fmt"
+	"testing"
+
+	apis "github.com/ravitri/aws-account-operator/api"
+	awsv1alpha1 "github.com/ravitri/aws-account-operator/api/v1alpha1"
+	"github.com/ravitri/aws-account-operator/pkg/awsclient"
+	"github.com/ravitri/aws-account-operator/pkg/testutils"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+)
+
+func TestGetManagedTags_AccountPool(t *testing.T) {
+	err := apis.AddToScheme(scheme.Scheme)
+	if err != nil {
+		fmt.Printf("failed adding to scheme in pool_test.go")
+	}
+
+	configMap := &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      awsv1alpha1.DefaultConfigMap,
+			Namespace: awsv1alpha1.AccountCrNamespace,
+		},
+		Data: map[string]string{
+			awsv1alpha1.ManagedTagsConfigMapKey: "owner=osd\nenv=prod",
+		},
+	}
+	accountPool := &awsv1alpha1.AccountPool{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "fedramp-pool",
+			Namespace: TestAccountNamespace,
+		},
+		Spec: awsv1alpha1.AccountPoolSpec{
+			PoolSize: 1,
+			AccountTemplate: awsv1alpha1.AccountTemplate{
+				ManagedTags: "env=fedramp\nteam=gov",
+			},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		labels   map[string]string
+		expected []awsclient.AWSTag
+	}{
+		{
+			name:   "Account without a pool gets the configmap tags",
+			labels: map[string]string{},
+			expected: []awsclient.AWSTag{
+				{Key: "owner", Value: "osd"},
+				{Key: "env", Value: "prod"},
+			},
+		},
+		{
+			name:   "Pool tags are added and win over the configmap tags",
+			labels: map[string]string{awsv1alpha1.AccountPoolLabel: "fedramp-pool"},
+			expected: []awsclient.AWSTag{
+				{Key: "owner", Value: "osd"},
+				{Key: "env", Value: "fedramp"},
+				{Key: "team", Value: "gov"},
+			},
+		},
+		{
+			name:   "Account of a deleted pool gets the configmap tags",
+			labels: map[string]string{awsv1alpha1.AccountPoolLabel: "deleted-pool"},
+			expected: []awsclient.AWSTag{
+				{Key: "owner", Value: "osd"},
+				{Key: "env", Value: "prod"},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			account := newTestAccountBuilder().acct
+			account.Labels = test.labels
+
+			mocks := setupDefaultMocks(t, []runtime.Object{configMap, accountPool})
+			defer mocks.mockCtrl.Finish()
+
+			r := AccountReconciler{
+				Client:   mocks.fakeKubeClient,
+				Scheme:   scheme.Scheme,
+				recorder: &record.FakeRecorder{},
+			}
+
+			got := r.getManagedTags(testutils.NewTestLogger().Logger(), &account)
+			assert.Equal(t, test.expected, got)
+		})
+	}
+}
+
+func TestFilterRegions(t *testing.T) {
+	template := &awsv1alpha1.AccountTemplate{
+		Regions: []awsv1alpha1.RegionAMI{
+			{Name: "us-east-1", Ami: "ami-1", InstanceType: "t3.micro"},
+			{Name: "eu-west-1", Ami: "ami-2", InstanceType: "t3.micro"},
+		},
+	}
+	enabled := []awsv1alpha1.AwsRegions{{Name: "us-east-1"}, {Name: "us-west-2"}, {Name: "eu-west-1"}}
+
+	regionAMIs := templateRegionAMIs(template)
+	assert.Equal(t, awsv1alpha1.AmiSpec{Ami: "ami-2", InstanceType: "t3.micro"}, regionAMIs["eu-west-1"])
+	assert.Equal(t, []awsv1alpha1.AwsRegions{{Name: "us-east-1"}, {Name: "eu-west-1"}}, filterRegions(enabled, regionAMIs))
+}
@@ -0,0 +1,615 @@
+package account
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/servicequotas"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	retry "github.com/avast/retry-go"
+	awsv1alpha1 "github.com/ravitri/aws-account-operator/api/v1alpha1"
+	"github.com/ravitri/aws-account-operator/pkg/awsclient"
+	controllerutils "github.com/ravitri/aws-account-operator/pkg/utils"
+)
+
+const (
+	// quotasConfigMapKey lists the service quotas increased in the regions of new accounts, one
+	// serviceCode:quotaCode:desiredValue[:region,region...] per line
+	quotasConfigMapKey = "quotas"
+	// vCPUQuotaConfigMapKey is the desired value of the vCPU quota, increased in every region
+	// unless the quotas list sets it
+	vCPUQuotaConfigMapKey = "quota.vcpu"
+	// quotaPollInterval is the wait between two checks of the pending quota increase requests
+	quotaPollInterval = 10 * time.Minute
+	// quotasHoldClaimsConfigMapKey keeps new accounts out of the claimable set until their quota
+	// increase requests are answered
+	quotasHoldClaimsConfigMapKey = "quotas.hold-claims"
+)
+
+// serviceQuota is a service quota to increase in the regions of new accounts
+type serviceQuota struct {
+	ServiceCode  string
+	QuotaCode    string
+	DesiredValue int64
+	// Regions the quota is increased in, every region when empty
+	Regions []string
+}
+
+// appliesTo returns true if the quota is increased in the region
+func (q serviceQuota) appliesTo(region string) bool {
+	if len(q.Regions) == 0 {
+		return true
+	}
+	for _, r := range q.Regions {
+		if r == region {
+			return true
+		}
+	}
+	return false
+}
+
+// isVCPUQuota returns true for the EC2 standard instances vCPU quota
+func (q serviceQuota) isVCPUQuota() bool {
+	return q.ServiceCode == vCPUServiceCode && q.QuotaCode == vCPUQuotaCode
+}
+
+// getServiceQuotas returns the service quotas of the operator configmap. The vCPU quota of
+// quota.vcpu is added when the quotas list doesn't set it. Returns no quotas on any configmap failure.
+func (r *AccountReconciler) getServiceQuotas(reqLogger logr.Logger) []serviceQuota {
+	configMap, err := controllerutils.GetOperatorConfigMap(r.Client)
+	if err != nil {
+		reqLogger.Error(err, "failed getting the service quotas from the configmap")
+		return nil
+	}
+
+	quotas := parseServiceQuotas(reqLogger, configMap.Data[quotasConfigMapKey])
+	for _, quota := range quotas {
+		if quota.isVCPUQuota() {
+			return quotas
+		}
+	}
+
+	v, ok := configMap.Data[vCPUQuotaConfigMapKey]
+	if !ok {
+		return quotas
+	}
+	vCPUQuota, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+	if err != nil || vCPUQuota <= 0 {
+		reqLogger.Info("skipping invalid vCPU quota of the configmap", vCPUQuotaConfigMapKey, v)
+		return quotas
+	}
+	return append(quotas, serviceQuota{
+		ServiceCode:  vCPUServiceCode,
+		QuotaCode:    vCPUQuotaCode,
+		DesiredValue: int64(vCPUQuota),
+	})
+}
+
+// parseServiceQuotas turns the serviceCode:quotaCode:desiredValue[:region,region...] lines of the
+// configmap into service quotas, skipping malformed lines
+func parseServiceQuotas(reqLogger logr.Logger, quotasString string) []serviceQuota {
+	quotas := []serviceQuota{}
+	for _, line := range strings.Split(quotasString, "\n") {
+		line = strings.ReplaceAll(line, " ", "")
+		if line == "" {
+			continue
+		}
+		fields := strings.Split(line, ":")
+		if len(fields) != 3 && len(fields) != 4 {
+			reqLogger.Info("skipping malformed configmap quota, expected serviceCode:quotaCode:desiredValue[:regions]", "line", line)
+			continue
+		}
+		desiredValue, err := strconv.ParseInt(fields[2], 10, 64)
+		if fields[0] == "" || fields[1] == "" || err != nil || desiredValue <= 0 {
+			reqLogger.Info("skipping malformed configmap quota, expected serviceCode:quotaCode:desiredValue[:regions]", "line", line)
+			continue
+		}
+		quota := serviceQuota{ServiceCode: fields[0], QuotaCode: fields[1], DesiredValue: desiredValue}
+		if len(fields) == 4 && fields[3] != "" {
+			quota.Regions = strings.Split(fields[3], ",")
+		}
+		quotas = append(quotas, quota)
+	}
+	return quotas
+}
+
+// requestQuotaIncreases requests the increase of the quotas of the region that are below their
+// desired value, unless an increase was already requested, and records the outcome of every quota
+// in the region status. Quota failures don't fail the region.
+func (r *AccountReconciler) requestQuotaIncreases(reqLogger logr.Logger, account *awsv1alpha1.Account, awsClient awsclient.Client, region *awsv1alpha1.AccountRegionStatus, quotas []serviceQuota) {
+	for _, quota := range quotas {
+		if !quota.appliesTo(region.Name) {
+			continue
+		}
+		// The region is only launched again after a failure, quotas that were handled then are done
+		if previous := getRegionQuotaStatus(region, quota); previous != nil && previous.DesiredValue == quota.DesiredValue && previous.Phase != awsv1alpha1.QuotaFailed {
+			continue
+		}
+
+		status := ensureQuota(reqLogger, awsClient, region.Name, quota)
+		setRegionQuotaStatus(region, status)
+		r.recordQuotaEvent(account, region, status)
+	}
+}
+
+// recordQuotaEvent records an Event for the phase of a quota of the region
+func (r *AccountReconciler) recordQuotaEvent(account *awsv1alpha1.Account, region *awsv1alpha1.AccountRegionStatus, status awsv1alpha1.AccountQuotaStatus) {
+	if status.ServiceCode == vCPUServiceCode && status.QuotaCode == vCPUQuotaCode && status.CaseID != "" {
+		region.QuotaCaseID = status.CaseID
+	}
+	switch status.Phase {
+	case awsv1alpha1.QuotaIncreaseRequested:
+		r.recorder.Eventf(account, corev1.EventTypeNormal, controllerutils.EventReasonQuotaIncreaseRequested, "Quota %s/%s increase to %d requested in region %s: %s", status.ServiceCode, status.QuotaCode, status.DesiredValue, region.Name, status.Message)
+	case awsv1alpha1.QuotaIncreaseGranted:
+		r.recorder.Eventf(account, corev1.EventTypeNormal, controllerutils.EventReasonQuotaIncreaseGranted, "Quota %s/%s increase to %d granted in region %s", status.ServiceCode, status.QuotaCode, status.DesiredValue, region.Name)
+	case awsv1alpha1.QuotaIncreaseDenied:
+		r.recorder.Eventf(account, corev1.EventTypeWarning, controllerutils.EventReasonQuotaIncreaseDenied, "Quota %s/%s increase to %d denied in region %s: %s", status.ServiceCode, status.QuotaCode, status.DesiredValue, region.Name, status.Message)
+	case awsv1alpha1.QuotaFailed:
+		r.recorder.Eventf(account, corev1.EventTypeWarning, controllerutils.EventReasonQuotaIncreaseFailed, "Quota %s/%s increase to %d failed in region %s: %s", status.ServiceCode, status.QuotaCode, status.DesiredValue, region.Name, status.Message)
+	}
+}
+
+// handleQuotaIncreases polls the pending quota increase requests of the account, records their
+// outcome and the QuotaIncreaseRequested condition, and requeues until they are all answered
+func (r *AccountReconciler) handleQuotaIncreases(reqLogger logr.Logger, account *awsv1alpha1.Account, awsSetupClient awsclient.Client) (reconcile.Result, error) {
+	// Failing to poll doesn't hold up the rest of the reconcile, the requests are polled again later
+	creds, err := r.getQuotaCredentials(reqLogger, account, awsSetupClient)
+	if err != nil {
+		reqLogger.Error(err, "failed assuming role to poll the quota increase requests")
+	}
+
+	for i := range account.Status.Regions {
+		region := &account.Status.Regions[i]
+		if err != nil || !regionHasPendingQuotas(region) {
+			continue
+		}
+		awsClient, clientErr := r.awsClientBuilder.GetClient(controllerName, r.Client, awsclient.NewAwsClientInput{
+			AwsCredsSecretIDKey:     *creds.Credentials.AccessKeyId,
+			AwsCredsSecretAccessKey: *creds.Credentials.SecretAccessKey,
+			AwsToken:                *creds.Credentials.SessionToken,
+			AwsRegion:               region.Name,
+		})
+		if clientErr != nil {
+			reqLogger.Error(clientErr, "failed building an AWS client to poll the quota increase requests", "region", region.Name)
+			continue
+		}
+		for j := range region.Quotas {
+			if region.Quotas[j].Phase != awsv1alpha1.QuotaIncreaseRequested {
+				continue
+			}
+			status := region.Quotas[j]
+			pollQuotaIncrease(reqLogger.WithValues("region", region.Name), awsClient, &status)
+			if status.Phase != awsv1alpha1.QuotaIncreaseRequested {
+				r.recordQuotaEvent(account, region, status)
+			}
+			setRegionQuotaStatus(region, status)
+		}
+	}
+
+	r.setQuotaIncreaseCondition(account)
+	err = r.statusUpdate(account)
+	if err != nil {
+		reqLogger.Error(err, "failed updating the quota increase requests of the account")
+		return reconcile.Result{}, err
+	}
+	if account.HasPendingQuotaIncreases() {
+		return reconcile.Result{RequeueAfter: quotaPollInterval}, nil
+	}
+	return reconcile.Result{}, nil
+}
+
+// getQuotaCredentials returns credentials in the account to poll its quota increase requests with
+func (r *AccountReconciler) getQuotaCredentials(reqLogger logr.Logger, account *awsv1alpha1.Account, awsSetupClient awsclient.Client) (*sts.AssumeRoleOutput, error) {
+	if account.IsSTS() {
+		accountClaim, err := r.getAccountClaim(account)
+		if err != nil {
+			return nil, err
+		}
+		_, creds, err := r.getSTSClient(reqLogger, accountClaim, awsSetupClient)
+		return creds, err
+	}
+	_, creds, err := r.assumeRole(reqLogger, account, awsSetupClient, getAssumeRole(account), "")
+	return creds, err
+}
+
+// pollQuotaIncrease records the state of the increase request of a pending quota. Requests that were
+// already open when the increase was requested are looked up in the change history. Errors are
+// recorded in the message of the quota, which stays pending.
+func pollQuotaIncrease(reqLogger logr.Logger, awsClient awsclient.Client, status *awsv1alpha1.AccountQuotaStatus) {
+	quota := serviceQuota{ServiceCode: status.ServiceCode, QuotaCode: status.QuotaCode, DesiredValue: status.DesiredValue}
+	logger := reqLogger.WithValues("serviceCode", quota.ServiceCode, "quotaCode", quota.QuotaCode)
+
+	var change *servicequotas.RequestedServiceQuotaChange
+	var err error
+	if status.RequestID == "" {
+		change, err = checkQuotaRequestHistory(awsClient, quota)
+	} else {
+		var output *servicequotas.GetRequestedServiceQuotaChangeOutput
+		output, err = awsClient.GetRequestedServiceQuotaChange(&servicequotas.GetRequestedServiceQuotaChangeInput{
+			RequestId: aws.String(status.RequestID),
+		})
+		if err == nil {
+			change = output.RequestedQuota
+		}
+	}
+	if err != nil {
+		logger.Error(err, "failed polling the quota increase request", "requestID", status.RequestID)
+		status.Message = fmt.Sprintf("Unable to poll the increase request: %s", err)
+		return
+	}
+
+	if change != nil {
+		applyQuotaChange(status, change)
+	}
+	// A closed case, or a request that can't be found, may still have raised the quota
+	if change == nil || aws.StringValue(change.Status) == servicequotas.RequestStatusCaseClosed {
+		increaseRequired, err := quotaNeedsIncrease(awsClient, quota)
+		if err != nil {
+			logger.Error(err, "failed retrieving current quota from AWS")
+			return
+		}
+		if !increaseRequired {
+			status.Phase = awsv1alpha1.QuotaIncreaseGranted
+			status.Message = "Increase approved"
+		}
+	}
+	logger.Info("polled the quota increase request", "requestID", status.RequestID, "phase", status.Phase)
+}
+
+// setQuotaIncreaseCondition sets the QuotaIncreaseRequested condition of an account with quota
+// increase requests: true while any of them is pending, then false with the Granted or Denied reason
+func (r *AccountReconciler) setQuotaIncreaseCondition(account *awsv1alpha1.Account) {
+	var pending, granted, denied []string
+	for _, region := range account.Status.Regions {
+		for _, quota := range region.Quotas {
+			name := fmt.Sprintf("%s/%s in %s", quota.ServiceCode, quota.QuotaCode, region.Name)
+			switch quota.Phase {
+			case awsv1alpha1.QuotaIncreaseRequested:
+				pending = append(pending, name)
+			case awsv1alpha1.QuotaIncreaseGranted:
+				granted = append(granted, name)
+			case awsv1alpha1.QuotaIncreaseDenied:
+				denied = append(denied, name)
+			}
+		}
+	}
+	if len(pending)+len(granted)+len(denied) == 0 {
+		return
+	}
+
+	status, reason := corev1.ConditionFalse, "Granted"
+	message := fmt.Sprintf("Quota increases granted: %s", strings.Join(granted, ", "))
+	switch {
+	case len(pending) > 0:
+		status, reason = corev1.ConditionTrue, "Pending"
+		message = fmt.Sprintf("Quota increases pending: %s", strings.Join(pending, ", "))
+	case len(denied) > 0:
+		reason = "Denied"
+		message = fmt.Sprintf("Quota increases denied: %s", strings.Join(denied, ", "))
+	}
+	account.Status.Conditions = controllerutils.SetAccountCondition(
+		account.Status.Conditions,
+		awsv1alpha1.AccountQuotaIncreaseRequested,
+		status,
+		reason,
+		message,
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+		account.Spec.BYOC,
+	)
+}
+
+// quotaPollDue returns true if the pending quota increase requests of the account were last polled
+// more than quotaPollInterval ago
+func quotaPollDue(account *awsv1alpha1.Account) bool {
+	if !account.HasPendingQuotaIncreases() {
+		return false
+	}
+	condition := account.GetCondition(awsv1alpha1.AccountQuotaIncreaseRequested)
+	if condition == nil {
+		return true
+	}
+	return time.Since(condition.LastProbeTime.Time) >= quotaPollInterval
+}
+
+// holdClaimsForQuotas returns true if new accounts are kept out of the claimable set until their
+// quota increase requests are answered
+func holdClaimsForQuotas(configMap *corev1.ConfigMap) bool {
+	if configMap == nil {
+		return false
+	}
+	hold, err := strconv.ParseBool(configMap.Data[quotasHoldClaimsConfigMapKey])
+	return err == nil && hold
+}
+
+// regionHasPendingQuotas returns true if a quota increase requested in the region wasn't answered yet
+func regionHasPendingQuotas(region *awsv1alpha1.AccountRegionStatus) bool {
+	for _, quota := range region.Quotas {
+		if quota.Phase == awsv1alpha1.QuotaIncreaseRequested {
+			return true
+		}
+	}
+	return false
+}
+
+// ensureQuota checks the quota, and requests its increase when it's below the desired value and no
+// matching request was made yet
+func ensureQuota(reqLogger logr.Logger, awsClient awsclient.Client, regionName string, quota serviceQuota) awsv1alpha1.AccountQuotaStatus {
+	status := awsv1alpha1.AccountQuotaStatus{
+		ServiceCode:  quota.ServiceCode,
+		QuotaCode:    quota.QuotaCode,
+		DesiredValue: quota.DesiredValue,
+	}
+	logger := reqLogger.WithValues("region", regionName, "serviceCode", quota.ServiceCode, "quotaCode", quota.QuotaCode)
+
+	increaseRequired, err := quotaNeedsIncrease(awsClient, quota)
+	if err != nil {
+		logger.Error(err, "failed retrieving current quota from AWS")
+		status.Phase = awsv1alpha1.QuotaFailed
+		status.Message = fmt.Sprintf("Unable to get the quota: %s", err)
+		return status
+	}
+	if !increaseRequired {
+		status.Phase = awsv1alpha1.QuotaSufficient
+		return status
+	}
+
+	logger.Info("quota increase required")
+	change, err := checkQuotaRequestHistory(awsClient, quota)
+	if err != nil {
+		logger.Error(err, "failed retrieving quota change history")
+		status.Phase = awsv1alpha1.QuotaFailed
+		status.Message = fmt.Sprintf("Unable to list the quota change history: %s", err)
+		return status
+	}
+
+	if change != nil {
+		logger.Info("found matching quota change request", "requestID", aws.StringValue(change.Id), "caseID", aws.StringValue(change.CaseId))
+	} else {
+		logger.Info("submitting quota increase request")
+		change, err = submitQuotaIncreaseRequest(awsClient, quota)
+		if err != nil {
+			logger.Error(err, "failed requesting quota increase")
+			status.Phase = awsv1alpha1.QuotaFailed
+			status.Message = fmt.Sprintf("Unable to request the quota increase: %s", err)
+			return status
+		}
+	}
+
+	if change == nil {
+		// The request is looked up in the change history when it's polled
+		status.Phase = awsv1alpha1.QuotaIncreaseRequested
+		status.Message = "An increase request is already open"
+		return status
+	}
+	applyQuotaChange(&status, change)
+	logger.Info("quota increase request submitted successfully", "requestID", status.RequestID, "caseID", status.CaseID)
+	return status
+}
+
+// applyQuotaChange records the state of the increase request of a quota in its status
+func applyQuotaChange(status *awsv1alpha1.AccountQuotaStatus, change *servicequotas.RequestedServiceQuotaChange) {
+	status.RequestID = aws.StringValue(change.Id)
+	status.CaseID = aws.StringValue(change.CaseId)
+	switch aws.StringValue(change.Status) {
+	case servicequotas.RequestStatusApproved:
+		status.Phase = awsv1alpha1.QuotaIncreaseGranted
+		status.Message = "Increase approved"
+	case servicequotas.RequestStatusDenied:
+		status.Phase = awsv1alpha1.QuotaIncreaseDenied
+		status.Message = "Increase denied"
+	case servicequotas.RequestStatusCaseClosed:
+		status.Phase = awsv1alpha1.QuotaIncreaseDenied
+		status.Message = fmt.Sprintf("Case %s closed without the increase", status.CaseID)
+	default:
+		status.Phase = awsv1alpha1.QuotaIncreaseRequested
+		status.Message = "Increase pending"
+		if status.CaseID != "" {
+			status.Message = fmt.Sprintf("Case %s", status.CaseID)
+		}
+	}
+}
+
+// getRegionQuotaStatus returns the status of the quota in the region, nil if it has none
+func getRegionQuotaStatus(region *awsv1alpha1.AccountRegionStatus, quota serviceQuota) *awsv1alpha1.AccountQuotaStatus {
+	for i := range region.Quotas {
+		if region.Quotas[i].ServiceCode == quota.ServiceCode && region.Quotas[i].QuotaCode == quota.QuotaCode {
+			return &region.Quotas[i]
+		}
+	}
+	return nil
+}
+
+// setRegionQuotaStatus records the status of a quota in the region, keeping the transition time
+// when its phase didn't change
+func setRegionQuotaStatus(region *awsv1alpha1.AccountRegionStatus, status awsv1alpha1.AccountQuotaStatus) {
+	status.LastTransitionTime = metav1.Now()
+	previous := getRegionQuotaStatus(region, serviceQuota{ServiceCode: status.ServiceCode, QuotaCode: status.QuotaCode})
+	if previous == nil {
+		region.Quotas = append(region.Quotas, status)
+		return
+	}
+	if previous.Phase == status.Phase {
+		status.LastTransitionTime = previous.LastTransitionTime
+	}
+	*previous = status
+}
+
+// isRetryableQuotaError returns true for the servicequotas errors worth retrying: access denied,
+// rate limit or server-side error
+func isRetryableQuotaError(err error) bool {
+	if aerr, ok := err.(awserr.Error); ok {
+		switch aerr.Code() {
+		// AccessDenied may indicate the BYOCAdminAccess role has not yet propagated
+		case "AccessDeniedException":
+			return true
+		case "ServiceException":
+			return true
+		case "TooManyRequestsException":
+			return true
+		// Can be caused by the client token not yet propagated
+		case "UnrecognizedClientException":
+			return true
+		}
+	}
+	// Otherwise, do not retry
+	return false
+}
+
+// quotaNeedsIncrease returns true if the current value of the quota in the region is below the desired one
+func quotaNeedsIncrease(client awsclient.Client, quota serviceQuota) (bool, error) {
+	var result *servicequotas.GetServiceQuotaOutput
+
+	// Default is 1/10 of a second, but any retries we need to make should be delayed a few seconds
+	// This also defaults to an exponential backoff, so we only need to try ~5 times, default is 10
+	err := retry.Do(
+		func() (err error) {
+			// Get the current existing quota setting
+			result, err = client.GetServiceQuota(
+				&servicequotas.GetServiceQuotaInput{
+					QuotaCode:   aws.String(quota.QuotaCode),
+					ServiceCode: aws.String(quota.ServiceCode),
+				},
+			)
+			return err
+		},
+		retry.Delay(3*time.Second),
+		retry.Attempts(5),
+		retry.RetryIf(isRetryableQuotaError),
+	)
+	if err != nil {
+		return false, err
+	}
+
+	if result.Quota == nil || result.Quota.Value == nil {
+		return false, fmt.Errorf("quota %s/%s has no value", quota.ServiceCode, quota.QuotaCode)
+	}
+	return *result.Quota.Value < float64(quota.DesiredValue), nil
+}
+
+// submitQuotaIncreaseRequest requests the increase of the quota to its desired value in the region
+// and returns the request. This just sends the request, and checks that it was submitted, and does
+// not wait. No request is returned when one is already open.
+func submitQuotaIncreaseRequest(client awsclient.Client, quota serviceQuota) (*servicequotas.RequestedServiceQuotaChange, error) {
+	var result *servicequotas.RequestServiceQuotaIncreaseOutput
+	var alreadySubmitted bool
+
+	err := retry.Do(
+		func() (err error) {
+			result, err = client.RequestServiceQuotaIncrease(
+				&servicequotas.RequestServiceQuotaIncreaseInput{
+					DesiredValue: aws.Float64(float64(quota.DesiredValue)),
+					ServiceCode:  aws.String(quota.ServiceCode),
+					QuotaCode:    aws.String(quota.QuotaCode),
+				})
+			if err != nil {
+				if aerr, ok := err.(awserr.Error); ok {
+					if aerr.Code() == "ResourceAlreadyExistsException" {
+						// This error means a request has already been submitted, and we do not have the CaseID, but
+						// we should also *not* return an error - this is a no-op.
+						alreadySubmitted = true
+						return nil
+					}
+				}
+			}
+			return err
+		},
+		retry.Delay(3*time.Second),
+		retry.Attempts(5),
+		retry.RetryIf(isRetryableQuotaError),
+	)
+
+	// If the attempt to submit a request returns "ResourceAlreadyExistsException"
+	// then a request has already been submitted, since we first polled. No further action.
+	if alreadySubmitted {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if result == nil || result.RequestedQuota == nil {
+		return nil, fmt.Errorf("returned RequestServiceQuotaIncreaseOutput has no RequestedQuota")
+	}
+	// If we were returned a request ID, then the request was submitted
+	if result.RequestedQuota.Id == nil {
+		return nil, fmt.Errorf("returned request ID is nil")
+	}
+	return result.RequestedQuota, nil
+}
+
+// checkQuotaRequestHistory returns the request for the increase of the quota to its desired value
+// that was already submitted, nil if there is none.
+// This is not ideal, as each region has to check the history, since we have to initialize by region
+// Ideally this would happen outside the region-specific init, but this requires the awsclient for the
+// specific region.
+func checkQuotaRequestHistory(awsClient awsclient.Client, quota serviceQuota) (*servicequotas.RequestedServiceQuotaChange, error) {
+	var nextToken *string
+
+	for {
+		// This returns with pagination, so we have to iterate over the pagination data
+		var result *servicequotas.ListRequestedServiceQuotaChangeHistoryByQuotaOutput
+
+		err := retry.Do(
+			func() (err error) {
+				// Get a (possibly paginated) list of quota change requests by quota
+				result, err = awsClient.ListRequestedServiceQuotaChangeHistoryByQuota(
+					&servicequotas.ListRequestedServiceQuotaChangeHistoryByQuotaInput{
+						NextToken:   nextToken,
+						ServiceCode: aws.String(quota.ServiceCode),
+						QuotaCode:   aws.String(quota.QuotaCode),
+					},
+				)
+				return err
+			},
+			retry.Delay(3*time.Second),
+			retry.Attempts(5),
+			retry.RetryIf(isRetryableQuotaError),
+		)
+		if err != nil {
+			// Return an error if retrieving the change history fails
+			return nil, err
+		}
+
+		// Check all the returned requests to see if one matches the quota increase we'd request
+		// If so, it's already been submitted
+		for _, change := range result.RequestedQuotas {
+			if changeRequestMatches(change, quota) {
+				return change, nil
+			}
+		}
+
+		// If NextToken is empty, no more to try
+		if result.NextToken == nil {
+			return nil, nil
+		}
+
+		// Set NextToken to retrieve the next page and loop again
+		nextToken = result.NextToken
+	}
+}
+
+// changeRequestMatches returns true if the QuotaCode, ServiceCode and desired value match
+func changeRequestMatches(change *servicequotas.RequestedServiceQuotaChange, quota serviceQuota) bool {
+	if aws.StringValue(change.ServiceCode) != quota.ServiceCode {
+		return false
+	}
+
+	if aws.StringValue(change.QuotaCode) != quota.QuotaCode {
+		return false
+	}
+
+	if aws.Float64Value(change.DesiredValue) != float64(quota.DesiredValue) {
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,290 @@
+package account
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/servicequotas"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+
+	awsv1alpha1 "github.com/ravitri/aws-account-operator/api/v1alpha1"
+	"github.com/ravitri/aws-account-operator/pkg/awsclient/mock"
+	"github.com/ravitri/aws-account-operator/pkg/testutils"
+)
+
+func TestParseServiceQuotas(t *testing.T) {
+	quotas := parseServiceQuotas(testutils.NewTestLogger().Logger(), `
+ec2:L-0263D0A3:10
+ vpc : L-FE5A380F : 10 : us-east-1,us-west-2
+vpc:L-F678F1CE
+vpc:L-F678F1CE:ten
+vpc::10
+`)
+	assert.Equal(t, []serviceQuota{
+		{ServiceCode: "ec2", QuotaCode: "L-0263D0A3", DesiredValue: 10},
+		{ServiceCode: "vpc", QuotaCode: "L-FE5A380F", DesiredValue: 10, Regions: []string{"us-east-1", "us-west-2"}},
+	}, quotas)
+	assert.True(t, quotas[0].appliesTo("eu-west-1"))
+	assert.True(t, quotas[1].appliesTo("us-west-2"))
+	assert.False(t, quotas[1].appliesTo("eu-west-1"))
+}
+
+func TestEnsureQuota(t *testing.T) {
+	quota := serviceQuota{ServiceCode: "vpc", QuotaCode: "L-F678F1CE", DesiredValue: 10}
+	getQuotaInput := &servicequotas.GetServiceQuotaInput{ServiceCode: aws.String("vpc"), QuotaCode: aws.String("L-F678F1CE")}
+	historyInput := &servicequotas.ListRequestedServiceQuotaChangeHistoryByQuotaInput{ServiceCode: aws.String("vpc"), QuotaCode: aws.String("L-F678F1CE")}
+	currentQuota := func(value float64) *servicequotas.GetServiceQuotaOutput {
+		return &servicequotas.GetServiceQuotaOutput{Quota: &servicequotas.ServiceQuota{Value: aws.Float64(value)}}
+	}
+
+	tests := []struct {
+		name              string
+		setupMocks        func(*mock.MockClient)
+		expectedPhase     awsv1alpha1.AccountQuotaPhase
+		expectedRequestID string
+		expectedCaseID    string
+	}{
+		{
+			name: "Quota already at the desired value",
+			setupMocks: func(m *mock.MockClient) {
+				m.EXPECT().GetServiceQuota(getQuotaInput).Return(currentQuota(10), nil)
+			},
+			expectedPhase: awsv1alpha1.QuotaSufficient,
+		},
+		{
+			name: "Increase already requested",
+			setupMocks: func(m *mock.MockClient) {
+				m.EXPECT().GetServiceQuota(getQuotaInput).Return(currentQuota(5), nil)
+				m.EXPECT().ListRequestedServiceQuotaChangeHistoryByQuota(historyInput).Return(&servicequotas.ListRequestedServiceQuotaChangeHistoryByQuotaOutput{
+					RequestedQuotas: []*servicequotas.RequestedServiceQuotaChange{
+						{ServiceCode: aws.String("vpc"), QuotaCode: aws.String("L-F678F1CE"), DesiredValue: aws.Float64(8), CaseId: aws.String("111")},
+					},
+					NextToken: aws.String("next"),
+				}, nil)
+				m.EXPECT().ListRequestedServiceQuotaChangeHistoryByQuota(&servicequotas.ListRequestedServiceQuotaChangeHistoryByQuotaInput{
+					ServiceCode: aws.String("vpc"), QuotaCode: aws.String("L-F678F1CE"), NextToken: aws.String("next"),
+				}).Return(&servicequotas.ListRequestedServiceQuotaChangeHistoryByQuotaOutput{
+					RequestedQuotas: []*servicequotas.RequestedServiceQuotaChange{
+						{ServiceCode: aws.String("vpc"), QuotaCode: aws.String("L-F678F1CE"), DesiredValue: aws.Float64(10), Id: aws.String("req-222"), CaseId: aws.String("222"), Status: aws.String(servicequotas.RequestStatusCaseOpened)},
+					},
+				}, nil)
+			},
+			expectedPhase:     awsv1alpha1.QuotaIncreaseRequested,
+			expectedRequestID: "req-222",
+			expectedCaseID:    "222",
+		},
+		{
+			name: "Increase already denied",
+			setupMocks: func(m *mock.MockClient) {
+				m.EXPECT().GetServiceQuota(getQuotaInput).Return(currentQuota(5), nil)
+				m.EXPECT().ListRequestedServiceQuotaChangeHistoryByQuota(historyInput).Return(&servicequotas.ListRequestedServiceQuotaChangeHistoryByQuotaOutput{
+					RequestedQuotas: []*servicequotas.RequestedServiceQuotaChange{
+						{ServiceCode: aws.String("vpc"), QuotaCode: aws.String("L-F678F1CE"), DesiredValue: aws.Float64(10), Id: aws.String("req-222"), Status: aws.String(servicequotas.RequestStatusDenied)},
+					},
+				}, nil)
+			},
+			expectedPhase:     awsv1alpha1.QuotaIncreaseDenied,
+			expectedRequestID: "req-222",
+		},
+		{
+			name: "Increase requested",
+			setupMocks: func(m *mock.MockClient) {
+				m.EXPECT().GetServiceQuota(getQuotaInput).Return(currentQuota(5), nil)
+				m.EXPECT().ListRequestedServiceQuotaChangeHistoryByQuota(historyInput).Return(&servicequotas.ListRequestedServiceQuotaChangeHistoryByQuotaOutput{}, nil)
+				m.EXPECT().RequestServiceQuotaIncrease(&servicequotas.RequestServiceQuotaIncreaseInput{
+					ServiceCode: aws.String("vpc"), QuotaCode: aws.String("L-F678F1CE"), DesiredValue: aws.Float64(10),
+				}).Return(&servicequotas.RequestServiceQuotaIncreaseOutput{
+					RequestedQuota: &servicequotas.RequestedServiceQuotaChange{Id: aws.String("req-333"), Status: aws.String(servicequotas.RequestStatusPending)},
+				}, nil)
+			},
+			expectedPhase:     awsv1alpha1.QuotaIncreaseRequested,
+			expectedRequestID: "req-333",
+		},
+		{
+			name: "Increase request already open",
+			setupMocks: func(m *mock.MockClient) {
+				m.EXPECT().GetServiceQuota(getQuotaInput).Return(currentQuota(5), nil)
+				m.EXPECT().ListRequestedServiceQuotaChangeHistoryByQuota(historyInput).Return(&servicequotas.ListRequestedServiceQuotaChangeHistoryByQuotaOutput{}, nil)
+				m.EXPECT().RequestServiceQuotaIncrease(gomock.Any()).Return(nil, awserr.New("ResourceAlreadyExistsException", "already exists", nil))
+			},
+			expectedPhase: awsv1alpha1.QuotaIncreaseRequested,
+		},
+		{
+			name: "Quota can't be retrieved",
+			setupMocks: func(m *mock.MockClient) {
+				m.EXPECT().GetServiceQuota(getQuotaInput).Return(nil, awserr.New("NoSuchResourceException", "no such quota", nil))
+			},
+			expectedPhase: awsv1alpha1.QuotaFailed,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockAWSClient := mock.NewMockClient(ctrl)
+			test.setupMocks(mockAWSClient)
+
+			status := ensureQuota(testutils.NewTestLogger().Logger(), mockAWSClient, "us-east-1", quota)
+			assert.Equal(t, test.expectedPhase, status.Phase)
+			assert.Equal(t, test.expectedRequestID, status.RequestID)
+			assert.Equal(t, test.expectedCaseID, status.CaseID)
+			assert.Equal(t, int64(10), status.DesiredValue)
+		})
+	}
+}
+
+func TestRequestQuotaIncreases(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockAWSClient := mock.NewMockClient(ctrl)
+
+	quotas := []serviceQuota{
+		{ServiceCode: vCPUServiceCode, QuotaCode: vCPUQuotaCode, DesiredValue: 200},
+		{ServiceCode: "vpc", QuotaCode: "L-F678F1CE", DesiredValue: 10},
+		{ServiceCode: "vpc", QuotaCode: "L-FE5A380F", DesiredValue: 10, Regions: []string{"us-west-2"}},
+	}
+	// The vpc quota was already found sufficient, and the last one isn't increased in the region
+	region := &awsv1alpha1.AccountRegionStatus{
+		Name:  "us-east-1",
+		Phase: awsv1alpha1.RegionFailed,
+		Quotas: []awsv1alpha1.AccountQuotaStatus{
+			{ServiceCode: "vpc", QuotaCode: "L-F678F1CE", DesiredValue: 10, Phase: awsv1alpha1.QuotaSufficient},
+		},
+	}
+	mockAWSClient.EXPECT().GetServiceQuota(gomock.Any()).Return(&servicequotas.GetServiceQuotaOutput{
+		Quota: &servicequotas.ServiceQuota{Value: aws.Float64(64)},
+	}, nil)
+	mockAWSClient.EXPECT().ListRequestedServiceQuotaChangeHistoryByQuota(gomock.Any()).Return(&servicequotas.ListRequestedServiceQuotaChangeHistoryByQuotaOutput{}, nil)
+	mockAWSClient.EXPECT().RequestServiceQuotaIncrease(gomock.Any()).Return(&servicequotas.RequestServiceQuotaIncreaseOutput{
+		RequestedQuota: &servicequotas.RequestedServiceQuotaChange{Id: aws.String("req-1"), CaseId: aws.String("1234567890"), Status: aws.String(servicequotas.RequestStatusCaseOpened)},
+	}, nil)
+
+	account := newTestAccountBuilder().acct
+	r := AccountReconciler{recorder: record.NewFakeRecorder(5)}
+	r.requestQuotaIncreases(testutils.NewTestLogger().Logger(), &account, mockAWSClient, region, quotas)
+
+	assert.Len(t, region.Quotas, 2)
+	assert.Equal(t, awsv1alpha1.QuotaSufficient, region.Quotas[0].Phase)
+	assert.Equal(t, awsv1alpha1.QuotaIncreaseRequested, region.Quotas[1].Phase)
+	assert.Equal(t, "1234567890", region.Quotas[1].CaseID)
+	assert.Equal(t, "1234567890", region.QuotaCaseID)
+}
+
+func TestPollQuotaIncrease(t *testing.T) {
+	getChangeInput := &servicequotas.GetRequestedServiceQuotaChangeInput{RequestId: aws.String("req-1")}
+	changeOutput := func(status string) *servicequotas.GetRequestedServiceQuotaChangeOutput {
+		return &servicequotas.GetRequestedServiceQuotaChangeOutput{
+			RequestedQuota: &servicequotas.RequestedServiceQuotaChange{Id: aws.String("req-1"), CaseId: aws.String("111"), Status: aws.String(status)},
+		}
+	}
+
+	tests := []struct {
+		name          string
+		requestID     string
+		setupMocks    func(*mock.MockClient)
+		expectedPhase awsv1alpha1.AccountQuotaPhase
+	}{
+		{
+			name:      "Request approved",
+			requestID: "req-1",
+			setupMocks: func(m *mock.MockClient) {
+				m.EXPECT().GetRequestedServiceQuotaChange(getChangeInput).Return(changeOutput(servicequotas.RequestStatusApproved), nil)
+			},
+			expectedPhase: awsv1alpha1.QuotaIncreaseGranted,
+		},
+		{
+			name:      "Request still pending",
+			requestID: "req-1",
+			setupMocks: func(m *mock.MockClient) {
+				m.EXPECT().GetRequestedServiceQuotaChange(getChangeInput).Return(changeOutput(servicequotas.RequestStatusCaseOpened), nil)
+			},
+			expectedPhase: awsv1alpha1.QuotaIncreaseRequested,
+		},
+		{
+			name:      "Case closed without the increase",
+			requestID: "req-1",
+			setupMocks: func(m *mock.MockClient) {
+				m.EXPECT().GetRequestedServiceQuotaChange(getChangeInput).Return(changeOutput(servicequotas.RequestStatusCaseClosed), nil)
+				m.EXPECT().GetServiceQuota(gomock.Any()).Return(&servicequotas.GetServiceQuotaOutput{
+					Quota: &servicequotas.ServiceQuota{Value: aws.Float64(5)},
+				}, nil)
+			},
+			expectedPhase: awsv1alpha1.QuotaIncreaseDenied,
+		},
+		{
+			name: "Request without ID already raised the quota",
+			setupMocks: func(m *mock.MockClient) {
+				m.EXPECT().ListRequestedServiceQuotaChangeHistoryByQuota(gomock.Any()).Return(&servicequotas.ListRequestedServiceQuotaChangeHistoryByQuotaOutput{}, nil)
+				m.EXPECT().GetServiceQuota(gomock.Any()).Return(&servicequotas.GetServiceQuotaOutput{
+					Quota: &servicequotas.ServiceQuota{Value: aws.Float64(10)},
+				}, nil)
+			},
+			expectedPhase: awsv1alpha1.QuotaIncreaseGranted,
+		},
+		{
+			name:      "Request can't be polled",
+			requestID: "req-1",
+			setupMocks: func(m *mock.MockClient) {
+				m.EXPECT().GetRequestedServiceQuotaChange(getChangeInput).Return(nil, awserr.New("NoSuchResourceException", "no such request", nil))
+			},
+			expectedPhase: awsv1alpha1.QuotaIncreaseRequested,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockAWSClient := mock.NewMockClient(ctrl)
+			test.setupMocks(mockAWSClient)
+
+			status := &awsv1alpha1.AccountQuotaStatus{
+				ServiceCode:  "vpc",
+				QuotaCode:    "L-F678F1CE",
+				DesiredValue: 10,
+				Phase:        awsv1alpha1.QuotaIncreaseRequested,
+				RequestID:    test.requestID,
+			}
+			pollQuotaIncrease(testutils.NewTestLogger().Logger(), mockAWSClient, status)
+			assert.Equal(t, test.expectedPhase, status.Phase)
+		})
+	}
+}
+
+func TestSetQuotaIncreaseCondition(t *testing.T) {
+	account := newTestAccountBuilder().acct
+	account.Status.Regions = []awsv1alpha1.AccountRegionStatus{
+		{Name: "us-east-1", Quotas: []awsv1alpha1.AccountQuotaStatus{
+			{ServiceCode: "vpc", QuotaCode: "L-F678F1CE", Phase: awsv1alpha1.QuotaIncreaseRequested},
+		}},
+		{Name: "us-east-2", Quotas: []awsv1alpha1.AccountQuotaStatus{
+			{ServiceCode: "vpc", QuotaCode: "L-F678F1CE", Phase: awsv1alpha1.QuotaIncreaseDenied},
+		}},
+	}
+	r := AccountReconciler{recorder: record.NewFakeRecorder(5)}
+
+	r.setQuotaIncreaseCondition(&account)
+	condition := account.GetCondition(awsv1alpha1.AccountQuotaIncreaseRequested)
+	assert.NotNil(t, condition)
+	assert.Equal(t, corev1.ConditionTrue, condition.Status)
+	assert.Equal(t, "Pending", condition.Reason)
+	assert.True(t, account.HasPendingQuotaIncreases())
+	assert.False(t, quotaPollDue(&account))
+
+	account.Status.Regions[0].Quotas[0].Phase = awsv1alpha1.QuotaIncreaseGranted
+	r.setQuotaIncreaseCondition(&account)
+	condition = account.GetCondition(awsv1alpha1.AccountQuotaIncreaseRequested)
+	assert.Equal(t, corev1.ConditionFalse, condition.Status)
+	assert.Equal(t, "Denied", condition.Reason)
+	assert.Contains(t, condition.Message, "us-east-2")
+	assert.False(t, account.HasPendingQuotaIncreases())
+}
+
+func TestHoldClaimsForQuotas(t *testing.T) {
+	assert.False(t, holdClaimsForQuotas(nil))
+	assert.False(t, holdClaimsForQuotas(&corev1.ConfigMap{Data: map[string]string{}}))
+	assert.True(t, holdClaimsForQuotas(&corev1.ConfigMap{Data: map[string]string{quotasHoldClaimsConfigMapKey: "true"}}))
+}
@@ -0,0 +1,203 @@
+package account
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	awsv1alpha1 "github.com/ravitri/aws-account-operator/api/v1alpha1"
+	"github.com/ravitri/aws-account-operator/config"
+	"github.com/ravitri/aws-account-operator/pkg/awsclient"
+	"github.com/ravitri/aws-account-operator/pkg/utils"
+)
+
+const (
+	// regionInitPollInterval is the wait between two steps of the region initialization
+	regionInitPollInterval = 10 * time.Second
+	// regionInstanceWaitTime is how long the EC2 instance of a region gets to start running before
+	// it is terminated anyway
+	regionInstanceWaitTime = utils.WaitTime * time.Minute
+	// regionsMaxFailuresConfigMapKey is the number, or percentage, of regions that can fail to initialize
+	// before the account fails
+	regionsMaxFailuresConfigMapKey = "regions.max-failures"
+	// regionsCatalogConfigMapKey names the RegionCatalog the regions are initialized from, instead of the
+	// regions of the configmap
+	regionsCatalogConfigMapKey = "regions.catalog"
+)
+
+// newRegionStatuses returns the pending statuses of the regions to initialize
+func newRegionStatuses(regions []awsv1alpha1.AwsRegions) []awsv1alpha1.AccountRegionStatus {
+	statuses := make([]awsv1alpha1.AccountRegionStatus, 0, len(regions))
+	for _, region := range regions {
+		status := awsv1alpha1.AccountRegionStatus{Name: region.Name}
+		setRegionPhase(&status, awsv1alpha1.RegionPending, "Region initialization pending")
+		statuses = append(statuses, status)
+	}
+	return statuses
+}
+
+// setRegionPhase moves the region to the phase
+func setRegionPhase(region *awsv1alpha1.AccountRegionStatus, phase awsv1alpha1.AccountRegionPhase, message string) {
+	region.Phase = phase
+	region.Message = message
+	region.LastTransitionTime = metav1.Now()
+}
+
+// setRegionMessage records why the region waits, without changing its phase
+func setRegionMessage(region *awsv1alpha1.AccountRegionStatus, message string) {
+	region.Message = message
+}
+
+// setRegionInitialized records the region as initialized
+func (r *AccountReconciler) setRegionInitialized(account *awsv1alpha1.Account, region *awsv1alpha1.AccountRegionStatus, message string) {
+	setRegionPhase(region, awsv1alpha1.RegionInstanceTerminated, message)
+	r.recorder.Event(account, corev1.EventTypeNormal, utils.EventReasonRegionInitialized, message)
+}
+
+// setRegionFailed records the region as failed, for the code of the AWS error or the given reason
+func (r *AccountReconciler) setRegionFailed(account *awsv1alpha1.Account, region *awsv1alpha1.AccountRegionStatus, err error, reason string, message string) {
+	region.Reason = reason
+	if aerr, ok := err.(awserr.Error); ok {
+		region.Reason = aerr.Code()
+	}
+	setRegionPhase(region, awsv1alpha1.RegionFailed, message)
+	r.recorder.Eventf(account, corev1.EventTypeWarning, utils.EventReasonRegionInitializationFailed, "Region %s: %s", region.Name, message)
+}
+
+// failedRegions returns the names of the regions of the account that failed to initialize
+func failedRegions(account *awsv1alpha1.Account) []string {
+	var failed []string
+	for _, region := range account.Status.Regions {
+		if region.Phase == awsv1alpha1.RegionFailed {
+			failed = append(failed, region.Name)
+		}
+	}
+	return failed
+}
+
+// getRegionAMIs returns the AMIs the regions of the account are initialized with, from its pool, the
+// RegionCatalog named in the configmap, or the regions of the configmap
+func (r *AccountReconciler) getRegionAMIs(reqLogger logr.Logger, account *awsv1alpha1.Account, configMap *corev1.ConfigMap) (map[string]awsv1alpha1.AmiSpec, error) {
+	// Pools can bring their own regions and AMIs
+	if template := r.getAccountTemplate(reqLogger, account); template != nil && len(template.Regions) > 0 {
+		return templateRegionAMIs(template), nil
+	}
+
+	if catalogName := configMap.Data[regionsCatalogConfigMapKey]; catalogName != "" {
+		catalog := &awsv1alpha1.RegionCatalog{}
+		err := r.Client.Get(context.TODO(), types.NamespacedName{Name: catalogName}, catalog)
+		if err != nil {
+			reqLogger.Error(err, "failed getting the region catalog", "regioncatalog", catalogName)
+			return nil, err
+		}
+		return catalogRegionAMIs(reqLogger, catalog, config.GetPartition()), nil
+	}
+
+	stringRegions, ok := configMap.Data["regions"]
+	if !ok {
+		reqLogger.Error(awsv1alpha1.ErrInvalidConfigMap, "failed getting regions from configmap data")
+		return nil, awsv1alpha1.ErrInvalidConfigMap
+	}
+	return processConfigMapRegions(reqLogger, stringRegions), nil
+}
+
+// catalogRegionAMIs returns the enabled regions of the catalog in the partition, with the AMI for the
+// architecture of their instance type. AMIs last verified not to exist are left out.
+func catalogRegionAMIs(reqLogger logr.Logger, catalog *awsv1alpha1.RegionCatalog, partition string) map[string]awsv1alpha1.AmiSpec {
+	regionAMIs := make(map[string]awsv1alpha1.AmiSpec, len(catalog.Spec.Regions))
+	for _, region := range catalog.Spec.Regions {
+		if !region.IsEnabled() || region.GetPartition() != partition {
+			continue
+		}
+		ami := region.GetAMI(region.GetArchitecture())
+		if status := catalog.GetAMIStatus(region.Name, ami); status != nil && status.LastVerifiedTime != nil && !status.Exists {
+			reqLogger.Info("skipping region whose AMI doesn't exist", "region", region.Name, "ami", ami, "regioncatalog", catalog.Name)
+			continue
+		}
+		regionAMIs[region.Name] = awsv1alpha1.AmiSpec{
+			Ami:          ami,
+			InstanceType: region.InstanceType,
+		}
+	}
+	return regionAMIs
+}
+
+// regionInitCredentials returns the credentials the regions of the account are initialized with
+func (r *AccountReconciler) regionInitCredentials(reqLogger logr.Logger, account *awsv1alpha1.Account, awsSetupClient awsclient.Client) (*sts.AssumeRoleOutput, error) {
+	if account.Spec.ManualSTSMode {
+		accountClaim, err := r.getAccountClaim(account)
+		if err != nil {
+			reqLogger.Error(err, "unable to get accountclaim for sts account")
+			return nil, err
+		}
+		_, creds, err := r.getSTSClient(reqLogger, accountClaim, awsSetupClient)
+		return creds, err
+	}
+	_, creds, err := r.assumeRole(reqLogger, account, awsSetupClient, getAssumeRole(account), "")
+	return creds, err
+}
+
+// regionFailureTolerance returns how many of the regions can fail to initialize before the account fails.
+// The operator configmap sets a number or a percentage of the regions, rounded down. By default the
+// account only fails when every region does.
+func regionFailureTolerance(reqLogger logr.Logger, configMap *corev1.ConfigMap, regions int) int {
+	defaultTolerance := regions - 1
+	value, ok := configMap.Data[regionsMaxFailuresConfigMapKey]
+	if !ok || value == "" {
+		return defaultTolerance
+	}
+	maxFailures := intstr.Parse(value)
+	tolerance, err := intstr.GetScaledValueFromIntOrPercent(&maxFailures, regions, false)
+	if err != nil || tolerance < 0 {
+		reqLogger.Info("invalid region failure tolerance in configmap, failing accounts only when every region fails", regionsMaxFailuresConfigMapKey, value)
+		return defaultTolerance
+	}
+	return tolerance
+}
+
+// finishRegionInit moves the account out of InitializingRegions once every region is done. The account
+// fails when more regions failed than the configmap tolerates. CCS accounts only initialize the regions
+// of their claim, and fail when any of them does.
+func (r *AccountReconciler) finishRegionInit(reqLogger logr.Logger, account *awsv1alpha1.Account, configMap *corev1.ConfigMap) (reconcile.Result, error) {
+	failed := failedRegions(account)
+	tolerance := 0
+	if !account.IsBYOC() {
+		tolerance = regionFailureTolerance(reqLogger, configMap, len(account.Status.Regions))
+	}
+	if len(failed) > tolerance {
+		return r.setAccountFailed(
+			reqLogger,
+			account,
+			awsv1alpha1.AccountCreationFailed,
+			"RegionInitializationFailed",
+			fmt.Sprintf("Account %s failed to initialize regions %v, more than the %d tolerated", account.Name, failed, tolerance),
+			AccountFailed,
+		)
+	}
+	if len(failed) > 0 {
+		reqLogger.Info("some regions failed to initialize, within the tolerated failures", "failedRegions", failed)
+	}
+	reqLogger.Info("Successfully completed initializing desired regions")
+
+	if account.IsBYOC() {
+		r.setAccountStatus(account, "BYOC Account Ready", awsv1alpha1.AccountReady, AccountReady)
+	} else if account.GetCondition(awsv1alpha1.AccountReady) != nil {
+		msg := "Account support case already resolved; Account Ready"
+		r.setAccountStatus(account, msg, awsv1alpha1.AccountReady, AccountReady)
+		reqLogger.Info(msg)
+	} else {
+		msg := "Account pending AWS limits verification"
+		r.setAccountStatus(account, msg, awsv1alpha1.AccountPendingVerification, AccountPendingVerification)
+		reqLogger.Info(msg)
+	}
+	return reconcile.Result{}, r.statusUpdate(account)
+}
@@ -0,0 +1,130 @@
+package account
+
+import (
+	"fmt"
+	"testing"
+
+	apis "github.com/ravitri/aws-account-operator/api"
+	awsv1alpha1 "github.com/ravitri/aws-account-operator/api/v1alpha1"
+	"github.com/ravitri/aws-account-operator/pkg/testutils"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+)
+
+func TestRegionFailureTolerance(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		expected int
+	}{
+		{name: "Tolerates all but one failed region by default", value: "", expected: 9},
+		{name: "Tolerates a number of regions", value: "2", expected: 2},
+		{name: "Tolerates a percentage of the regions", value: "25%", expected: 2},
+		{name: "Falls back to the default on invalid values", value: "-1", expected: 9},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			configMap := &v1.ConfigMap{Data: map[string]string{regionsMaxFailuresConfigMapKey: test.value}}
+			assert.Equal(t, test.expected, regionFailureTolerance(testutils.NewTestLogger().Logger(), configMap, 10))
+		})
+	}
+}
+
+func TestFinishRegionInit(t *testing.T) {
+	err := apis.AddToScheme(scheme.Scheme)
+	if err != nil {
+		fmt.Printf("failed adding apis to scheme in account controller test")
+	}
+
+	tests := []struct {
+		name          string
+		byoc          bool
+		maxFailures   string
+		expectedState string
+	}{
+		{
+			name:          "Pool accounts tolerate failed regions by default",
+			expectedState: AccountPendingVerification,
+		},
+		{
+			name:          "Pool accounts fail past the configured tolerance",
+			maxFailures:   "0",
+			expectedState: AccountFailed,
+		},
+		{
+			name:          "CCS accounts fail when any region of their claim does",
+			byoc:          true,
+			maxFailures:   "1",
+			expectedState: AccountFailed,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			account := newTestAccountBuilder().BYOC(test.byoc).WithState(awsv1alpha1.AccountInitializingRegions).acct
+			account.Status.Regions = []awsv1alpha1.AccountRegionStatus{
+				{Name: "us-east-1", Phase: awsv1alpha1.RegionInstanceTerminated},
+				{Name: "us-east-2", Phase: awsv1alpha1.RegionFailed, Reason: "InstanceLaunchFailed"},
+			}
+
+			mocks := setupDefaultMocks(t, []runtime.Object{&account})
+			defer mocks.mockCtrl.Finish()
+			r := AccountReconciler{Client: mocks.fakeKubeClient, Scheme: scheme.Scheme, recorder: &record.FakeRecorder{}}
+
+			configMap := &v1.ConfigMap{Data: map[string]string{regionsMaxFailuresConfigMapKey: test.maxFailures}}
+			_, err := r.finishRegionInit(testutils.NewTestLogger().Logger(), &account, configMap)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectedState, account.Status.State)
+		})
+	}
+}
+
+func TestCatalogRegionAMIs(t *testing.T) {
+	disabled := false
+	lastVerified := metav1.Now()
+	catalog := &awsv1alpha1.RegionCatalog{
+		Spec: awsv1alpha1.RegionCatalogSpec{
+			Regions: []awsv1alpha1.CatalogRegion{
+				{
+					Name:         "us-east-1",
+					InstanceType: "t4g.micro",
+					Architecture: awsv1alpha1.ArchitectureArm64,
+					AMIs: []awsv1alpha1.ArchitectureAMI{
+						{Architecture: awsv1alpha1.ArchitectureX8664, ID: "ami-000db10762d0c4c05"},
+						{Architecture: awsv1alpha1.ArchitectureArm64, ID: "ami-0123456789abcdef0"},
+					},
+				},
+				{
+					Name:         "us-east-2",
+					InstanceType: "t2.micro",
+					Enabled:      &disabled,
+					AMIs:         []awsv1alpha1.ArchitectureAMI{{Architecture: awsv1alpha1.ArchitectureX8664, ID: "ami-094720ddca649952f"}},
+				},
+				{
+					Name:         "us-west-1",
+					InstanceType: "t2.micro",
+					AMIs:         []awsv1alpha1.ArchitectureAMI{{Architecture: awsv1alpha1.ArchitectureX8664, ID: "ami-04642fc8fca1e8e67"}},
+				},
+				{
+					Name:         "us-gov-west-1",
+					Partition:    awsv1alpha1.PartitionAWSUSGov,
+					InstanceType: "t2.micro",
+					AMIs:         []awsv1alpha1.ArchitectureAMI{{Architecture: awsv1alpha1.ArchitectureX8664, ID: "ami-0fedcba9876543210"}},
+				},
+			},
+		},
+		Status: awsv1alpha1.RegionCatalogStatus{
+			AMIs: []awsv1alpha1.CatalogAMIStatus{
+				{Region: "us-west-1", ID: "ami-04642fc8fca1e8e67", Exists: false, LastVerifiedTime: &lastVerified},
+			},
+		},
+	}
+
+	regionAMIs := catalogRegionAMIs(testutils.NewTestLogger().Logger(), catalog, awsv1alpha1.PartitionAWS)
+	assert.Equal(t, map[string]awsv1alpha1.AmiSpec{
+		"us-east-1": {Ami: "ami-0123456789abcdef0", InstanceType: "t4g.micro"},
+	}, regionAMIs)
+}
@@ -0,0 +1,84 @@
+package account
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/organizations"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+
+	awsv1alpha1 "github.com/ravitri/aws-account-operator/api/v1alpha1"
+	"github.com/ravitri/aws-account-operator/pkg/awsclient"
+	"github.com/ravitri/aws-account-operator/pkg/utils"
+)
+
+// retireAccount parks or closes the AWS account of a surplus Account its pool deleted to scale down.
+// Accounts that have ever been claimed are never retired, whatever their annotations say.
+func (r *AccountReconciler) retireAccount(reqLogger logr.Logger, awsSetupClient awsclient.Client, account *awsv1alpha1.Account) error {
+	action := awsv1alpha1.ScaleDownAction(account.GetAnnotations()[awsv1alpha1.RetireAnnotation])
+	if action == "" || action == awsv1alpha1.ScaleDownRetain {
+		return nil
+	}
+	if account.IsBYOC() || !account.HasAwsAccountID() || !account.HasNeverBeenClaimed() || account.Spec.ClaimLink != "" {
+		reqLogger.Info("account is not a never-claimed pool account, not retiring it", "action", action)
+		return nil
+	}
+
+	accountID := account.Spec.AwsAccountID
+	var msg string
+	switch action {
+	case awsv1alpha1.ScaleDownPark:
+		holdingOU := account.GetAnnotations()[awsv1alpha1.HoldingOUAnnotation]
+		if holdingOU == "" {
+			reqLogger.Info("no holding OU to park the account in, not retiring it")
+			return nil
+		}
+		if err := parkAccount(awsSetupClient, accountID, holdingOU); err != nil {
+			utils.LogAwsError(reqLogger, "failed parking AWS account", nil, err)
+			return err
+		}
+		msg = fmt.Sprintf("AWS account %s parked in OU %s", accountID, holdingOU)
+	case awsv1alpha1.ScaleDownClose:
+		_, err := awsSetupClient.CloseAccount(&awsclient.CloseAccountInput{AccountId: aws.String(accountID)})
+		if err != nil {
+			if aerr, ok := err.(awserr.Error); !ok || aerr.Code() != "AccountAlreadyClosedException" {
+				utils.LogAwsError(reqLogger, "failed closing AWS account", nil, err)
+				return err
+			}
+		}
+		msg = fmt.Sprintf("AWS account %s closed", accountID)
+	default:
+		reqLogger.Info("unknown retire action, not retiring account", "action", action)
+		return nil
+	}
+
+	reqLogger.Info(msg)
+	r.recorder.Event(account, corev1.EventTypeNormal, utils.EventReasonAccountRetired, msg)
+	return nil
+}
+
+// parkAccount moves the AWS account from its current parent to the holding OU
+func parkAccount(client awsclient.Client, accountID string, holdingOU string) error {
+	parents, err := client.ListParents(&organizations.ListParentsInput{
+		ChildId: aws.String(accountID),
+	})
+	if err != nil {
+		return err
+	}
+	if len(parents.Parents) == 0 {
+		return fmt.Errorf("no parent found for AWS account %s", accountID)
+	}
+
+	sourceID := aws.StringValue(parents.Parents[0].Id)
+	if sourceID == holdingOU {
+		return nil
+	}
+	_, err = client.MoveAccount(&organizations.MoveAccountInput{
+		AccountId:           aws.String(accountID),
+		SourceParentId:      aws.String(sourceID),
+		DestinationParentId: aws.String(holdingOU),
+	})
+	return err
+}
@@ -0,0 +1,76 @@
+package account
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/organizations"
+	"github.com/golang/mock/gomock"
+	awsv1alpha1 "github.com/ravitri/aws-account-operator/api/v1alpha1"
+	"github.com/ravitri/aws-account-operator/pkg/awsclient"
+	"github.com/ravitri/aws-account-operator/pkg/awsclient/mock"
+	"github.com/ravitri/aws-account-operator/pkg/testutils"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/client-go/tools/record"
+)
+
+func TestRetireAccount(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		claimed     bool
+		setupMocks  func(*mock.MockClientMockRecorder)
+	}{
+		{
+			name: "Parks the account in the holding OU",
+			annotations: map[string]string{
+				awsv1alpha1.RetireAnnotation:    string(awsv1alpha1.ScaleDownPark),
+				awsv1alpha1.HoldingOUAnnotation: "ou-ab12-holding",
+			},
+			setupMocks: func(r *mock.MockClientMockRecorder) {
+				r.ListParents(gomock.Any()).Return(&organizations.ListParentsOutput{
+					Parents: []*organizations.Parent{{Id: aws.String("r-ab12")}},
+				}, nil)
+				r.MoveAccount(&organizations.MoveAccountInput{
+					AccountId:           aws.String("123456789012"),
+					SourceParentId:      aws.String("r-ab12"),
+					DestinationParentId: aws.String("ou-ab12-holding"),
+				}).Return(&organizations.MoveAccountOutput{}, nil)
+			},
+		},
+		{
+			name:        "Closes the account",
+			annotations: map[string]string{awsv1alpha1.RetireAnnotation: string(awsv1alpha1.ScaleDownClose)},
+			setupMocks: func(r *mock.MockClientMockRecorder) {
+				r.CloseAccount(&awsclient.CloseAccountInput{AccountId: aws.String("123456789012")}).Return(&awsclient.CloseAccountOutput{}, nil)
+			},
+		},
+		{
+			name:        "Never retires an account that has been claimed",
+			annotations: map[string]string{awsv1alpha1.RetireAnnotation: string(awsv1alpha1.ScaleDownClose)},
+			claimed:     true,
+			setupMocks:  func(r *mock.MockClientMockRecorder) {},
+		},
+		{
+			name:       "Does nothing without the retire annotation",
+			setupMocks: func(r *mock.MockClientMockRecorder) {},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockAWSClient := mock.NewMockClient(ctrl)
+			test.setupMocks(mockAWSClient.EXPECT())
+
+			account := newTestAccountBuilder().acct
+			account.Spec.AwsAccountID = "123456789012"
+			account.Annotations = test.annotations
+			account.Status.Claimed = test.claimed
+
+			r := AccountReconciler{recorder: &record.FakeRecorder{}}
+			err := r.retireAccount(testutils.NewTestLogger().Logger(), mockAWSClient, &account)
+			assert.NoError(t, err)
+		})
+	}
+}
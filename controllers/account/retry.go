@@ -0,0 +1,193 @@
+package account
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	awsv1alpha1 "github.com/ravitri/aws-account-operator/api/v1alpha1"
+	"github.com/ravitri/aws-account-operator/pkg/utils"
+)
+
+const (
+	// AccountRetrying indicates a recoverable failure of the account is waiting to be retried
+	AccountRetrying = "Retrying"
+
+	// retryMaxAttemptsConfigMapKey is the number of retries of the recoverable failures of an account
+	retryMaxAttemptsConfigMapKey = "retry.max-attempts"
+	// defaultRetryMaxAttempts is the retry budget when the configmap doesn't set one
+	defaultRetryMaxAttempts = 5
+	// retryBaseDelay is the wait before the first retry, doubled on every following one
+	retryBaseDelay = time.Minute
+	// retryMaxDelay caps the wait between two retries
+	retryMaxDelay = time.Hour
+)
+
+// recoverableFailureReasons are the failure reasons worth retrying: timeouts, throttling and
+// transient AWS errors. Any other reason fails the account for good.
+var recoverableFailureReasons = map[string]bool{
+	"CreationTimeout":             true,
+	"RegionInitializationTimeout": true,
+	"AWSClientCreationFailed":     true,
+	"Throttling":                  true,
+	"ThrottlingException":         true,
+	"TooManyRequestsException":    true,
+	"RequestLimitExceeded":        true,
+	"ServiceUnavailable":          true,
+	"ServiceUnavailableException": true,
+	"InternalFailure":             true,
+	"RequestError":                true,
+	// Failure reasons of AWS account creation requests
+	"INTERNAL_FAILURE":                true,
+	"CONCURRENT_ACCOUNT_MODIFICATION": true,
+}
+
+// isRecoverableFailure returns true if a failure with the given reason can be retried
+func isRecoverableFailure(reason string) bool {
+	return recoverableFailureReasons[reason]
+}
+
+// retryBackoff returns the wait before the retry following the given number of attempts
+func retryBackoff(attempts int) time.Duration {
+	delay := retryBaseDelay
+	for i := 0; i < attempts && delay < retryMaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > retryMaxDelay {
+		return retryMaxDelay
+	}
+	return delay
+}
+
+// getRetryMaxAttempts returns the retry budget set in the operator configmap, or the default one
+func (r *AccountReconciler) getRetryMaxAttempts() int {
+	configMap, err := utils.GetOperatorConfigMap(r.Client)
+	if err != nil {
+		return defaultRetryMaxAttempts
+	}
+	maxAttempts, err := strconv.Atoi(configMap.Data[retryMaxAttemptsConfigMapKey])
+	if err != nil || maxAttempts < 0 {
+		return defaultRetryMaxAttempts
+	}
+	return maxAttempts
+}
+
+// recordFailure records the failure in the retry status of the account and returns true if it
+// must be retried, which is when the reason is recoverable and the retry budget isn't spent
+func recordFailure(account *awsv1alpha1.Account, reason string, maxAttempts int, now time.Time) bool {
+	retry := account.Status.Retry
+	if retry == nil {
+		retry = &awsv1alpha1.AccountRetryStatus{}
+		account.Status.Retry = retry
+	}
+	// A failure while retrying resumes from the same state as the previous one
+	if !account.IsRetrying() && !account.IsFailed() {
+		retry.ResumeState = resumeState(account.Status.State)
+	}
+	retry.LastFailureReason = reason
+	retry.LastFailureTime = metav1.NewTime(now)
+	retry.NextRetryTime = nil
+
+	if !isRecoverableFailure(reason) || retry.Attempts >= maxAttempts {
+		return false
+	}
+	next := metav1.NewTime(now.Add(retryBackoff(retry.Attempts)))
+	retry.NextRetryTime = &next
+	retry.Attempts++
+	return true
+}
+
+// resumeState returns the state to retry an account that failed in the given state from.
+// Region initialization is started again from Creating.
+func resumeState(state string) string {
+	if state == AccountInitializingRegions {
+		return AccountCreating
+	}
+	return state
+}
+
+// handleAccountRetrying resumes a retrying account once its backoff is over
+func (r *AccountReconciler) handleAccountRetrying(reqLogger logr.Logger, account *awsv1alpha1.Account) (reconcile.Result, error) {
+	if retry := account.Status.Retry; retry != nil && retry.NextRetryTime != nil {
+		if wait := time.Until(retry.NextRetryTime.Time); wait > 0 {
+			reqLogger.Info("account is waiting to be retried", "nextRetryTime", retry.NextRetryTime)
+			return reconcile.Result{RequeueAfter: wait}, nil
+		}
+	}
+	return reconcile.Result{Requeue: true}, r.resumeAccount(reqLogger, account)
+}
+
+// handleForceRetry retries a failed or retrying account right away when it has the force retry
+// annotation, resetting its retry budget. The annotation is removed either way.
+func (r *AccountReconciler) handleForceRetry(reqLogger logr.Logger, account *awsv1alpha1.Account) (reconcile.Result, error) {
+	annotations := account.GetAnnotations()
+	delete(annotations, awsv1alpha1.ForceRetryAnnotation)
+	account.SetAnnotations(annotations)
+	if err := r.Client.Update(context.TODO(), account); err != nil {
+		reqLogger.Error(err, "failed removing force retry annotation")
+		return reconcile.Result{}, err
+	}
+
+	switch {
+	case !account.IsFailed() && !account.IsRetrying():
+		reqLogger.Info("account is neither failed nor retrying, ignoring force retry")
+		return reconcile.Result{}, nil
+	case account.Status.Closure != nil:
+		reqLogger.Info("the AWS account is being closed, ignoring force retry")
+		return reconcile.Result{}, nil
+	}
+
+	if account.Status.Retry == nil {
+		// Accounts that failed before retries were recorded start over
+		account.Status.Retry = &awsv1alpha1.AccountRetryStatus{}
+		if account.HasAwsAccountID() && !account.IsBYOC() {
+			account.Status.Retry.ResumeState = AccountCreating
+		}
+	}
+	account.Status.Retry.Attempts = 0
+	reqLogger.Info("forcing retry of account")
+	return reconcile.Result{Requeue: true}, r.resumeAccount(reqLogger, account)
+}
+
+// resumeAccount puts the account back in the state it failed in. The Creating condition is
+// refreshed and InitializingRegions switched off, so their timeouts start over. Resuming the
+// creation polls the previous creation request again, as a request that timed out can still
+// succeed. Requests AWS reported as failed were already forgotten, so a new one is made.
+func (r *AccountReconciler) resumeAccount(reqLogger logr.Logger, account *awsv1alpha1.Account) error {
+	state := ""
+	msg := "Retrying account"
+	if retry := account.Status.Retry; retry != nil {
+		state = retry.ResumeState
+		retry.NextRetryTime = nil
+		msg = fmt.Sprintf("Retrying account after %s, attempt %d", retry.LastFailureReason, retry.Attempts)
+	}
+	if (state == AccountCreating || state == "") && account.Status.CreateAccountRequestID != "" {
+		now := metav1.Now()
+		account.Status.CreateAccountRequestTime = &now
+	}
+
+	account.Status.Conditions = utils.SetAccountCondition(
+		account.Status.Conditions,
+		awsv1alpha1.AccountInitializingRegions,
+		corev1.ConditionFalse,
+		AccountInitializingRegions,
+		msg,
+		utils.UpdateConditionAlways,
+		account.Spec.BYOC,
+	)
+	if state == AccountCreating {
+		r.setAccountStatus(account, msg, awsv1alpha1.AccountCreating, AccountCreating)
+	} else {
+		r.recorder.Event(account, corev1.EventTypeNormal, string(awsv1alpha1.AccountRetrying), msg)
+		account.Status.State = state
+	}
+
+	reqLogger.Info(msg, "state", state)
+	return r.statusUpdate(account)
+}
@@ -0,0 +1,178 @@
+package account
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/organizations"
+	apis "github.com/ravitri/aws-account-operator/api"
+	awsv1alpha1 "github.com/ravitri/aws-account-operator/api/v1alpha1"
+	"github.com/ravitri/aws-account-operator/pkg/testutils"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+)
+
+func TestRetryBackoff(t *testing.T) {
+	assert.Equal(t, time.Minute, retryBackoff(0))
+	assert.Equal(t, 4*time.Minute, retryBackoff(2))
+	assert.Equal(t, retryMaxDelay, retryBackoff(10))
+}
+
+func TestRecordFailure(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name                string
+		state               awsv1alpha1.AccountConditionType
+		reason              string
+		attempts            int
+		expectedRetry       bool
+		expectedResumeState string
+	}{
+		{
+			name:                "Retries a creation timeout",
+			state:               awsv1alpha1.AccountCreating,
+			reason:              "CreationTimeout",
+			expectedRetry:       true,
+			expectedResumeState: AccountCreating,
+		},
+		{
+			name:                "Retries region initialization from Creating",
+			state:               awsv1alpha1.AccountInitializingRegions,
+			reason:              "RegionInitializationTimeout",
+			expectedRetry:       true,
+			expectedResumeState: AccountCreating,
+		},
+		{
+			name:                "Fails for good once the retry budget is spent",
+			state:               awsv1alpha1.AccountCreating,
+			reason:              "Throttling",
+			attempts:            defaultRetryMaxAttempts,
+			expectedRetry:       false,
+			expectedResumeState: AccountCreating,
+		},
+		{
+			name:                "Fails for good on fatal reasons",
+			state:               awsv1alpha1.AccountCreating,
+			reason:              "AccessDenied",
+			expectedRetry:       false,
+			expectedResumeState: AccountCreating,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			account := newTestAccountBuilder().WithState(test.state).acct
+			account.Status.Retry = &awsv1alpha1.AccountRetryStatus{Attempts: test.attempts}
+
+			retry := recordFailure(&account, test.reason, defaultRetryMaxAttempts, now)
+			assert.Equal(t, test.expectedRetry, retry)
+			assert.Equal(t, test.expectedResumeState, account.Status.Retry.ResumeState)
+			assert.Equal(t, test.reason, account.Status.Retry.LastFailureReason)
+			if test.expectedRetry {
+				assert.Equal(t, test.attempts+1, account.Status.Retry.Attempts)
+				assert.Equal(t, now.Add(retryBackoff(test.attempts)).Unix(), account.Status.Retry.NextRetryTime.Unix())
+			} else {
+				assert.Nil(t, account.Status.Retry.NextRetryTime)
+			}
+		})
+	}
+}
+
+func TestHandleForceRetry(t *testing.T) {
+	err := apis.AddToScheme(scheme.Scheme)
+	if err != nil {
+		fmt.Printf("failed adding apis to scheme in account controller test")
+	}
+
+	account := newTestAccountBuilder().WithState(awsv1alpha1.AccountFailed).acct
+	account.Annotations = map[string]string{awsv1alpha1.ForceRetryAnnotation: "true"}
+	account.Status.Retry = &awsv1alpha1.AccountRetryStatus{
+		Attempts:          defaultRetryMaxAttempts,
+		LastFailureReason: "CreationTimeout",
+		ResumeState:       AccountCreating,
+	}
+	requestTime := metav1.Now()
+	account.Status.CreateAccountRequestID = "car-123"
+	account.Status.CreateAccountRequestTime = &requestTime
+
+	mocks := setupDefaultMocks(t, []runtime.Object{&account})
+	defer mocks.mockCtrl.Finish()
+	r := AccountReconciler{Client: mocks.fakeKubeClient, Scheme: scheme.Scheme, recorder: &record.FakeRecorder{}}
+
+	_, err = r.handleForceRetry(testutils.NewTestLogger().Logger(), &account)
+	assert.NoError(t, err)
+
+	updated := awsv1alpha1.Account{}
+	err = mocks.fakeKubeClient.Get(context.TODO(), types.NamespacedName{Name: TestAccountName, Namespace: TestAccountNamespace}, &updated)
+	assert.NoError(t, err)
+	assert.NotContains(t, updated.Annotations, awsv1alpha1.ForceRetryAnnotation)
+	assert.Equal(t, AccountCreating, updated.Status.State)
+	assert.Equal(t, 0, updated.Status.Retry.Attempts)
+	assert.Equal(t, "car-123", updated.Status.CreateAccountRequestID)
+	assert.True(t, updated.Status.CreateAccountRequestTime.After(requestTime.Add(-time.Second)))
+}
+
+func TestRetryPollsTimedOutCreationRequest(t *testing.T) {
+	err := apis.AddToScheme(scheme.Scheme)
+	if err != nil {
+		fmt.Printf("failed adding apis to scheme in account controller test")
+	}
+
+	account := newTestAccountBuilder().WithState(AccountRetrying).acct
+	nextRetryTime := metav1.NewTime(time.Now().Add(-time.Second))
+	account.Status.Retry = &awsv1alpha1.AccountRetryStatus{
+		Attempts:          1,
+		LastFailureReason: "CreationTimeout",
+		ResumeState:       AccountCreating,
+		NextRetryTime:     &nextRetryTime,
+	}
+	requestTime := metav1.NewTime(time.Now().Add(-(createPendTime + time.Minute)))
+	account.Status.CreateAccountRequestID = "car-123"
+	account.Status.CreateAccountRequestTime = &requestTime
+
+	mocks := setupDefaultMocks(t, []runtime.Object{&account})
+	defer mocks.mockCtrl.Finish()
+	r := AccountReconciler{Client: mocks.fakeKubeClient, Scheme: scheme.Scheme, recorder: &record.FakeRecorder{}}
+	nullLogger := testutils.NewTestLogger().Logger()
+
+	_, err = r.handleAccountRetrying(nullLogger, &account)
+	assert.NoError(t, err)
+	assert.Equal(t, AccountCreating, account.Status.State)
+
+	// The request made before the timeout succeeds, so no other account is requested
+	mocks.mockAWSClient.EXPECT().DescribeCreateAccountStatus(&organizations.DescribeCreateAccountStatusInput{
+		CreateAccountRequestId: aws.String("car-123"),
+	}).Return(
+		&organizations.DescribeCreateAccountStatusOutput{
+			CreateAccountStatus: &organizations.CreateAccountStatus{
+				State:     aws.String("SUCCEEDED"),
+				AccountId: aws.String("123456789012"),
+			},
+		},
+		nil,
+	)
+	awsAccountID, err := r.BuildAccount(nullLogger, mocks.mockAWSClient, &account)
+	assert.NoError(t, err)
+	assert.Equal(t, "123456789012", awsAccountID)
+}
+
+func TestSetAccountStatusReadyResetsRetry(t *testing.T) {
+	account := newTestAccountBuilder().WithState(awsv1alpha1.AccountPendingVerification).acct
+	account.Status.Retry = &awsv1alpha1.AccountRetryStatus{
+		Attempts:          2,
+		LastFailureReason: "RegionInitializationTimeout",
+		ResumeState:       AccountCreating,
+	}
+	r := AccountReconciler{recorder: &record.FakeRecorder{}}
+
+	r.setAccountStatus(&account, "Account ready to be claimed", awsv1alpha1.AccountReady, AccountReady)
+
+	assert.Equal(t, AccountReady, account.Status.State)
+	assert.Nil(t, account.Status.Retry)
+}
@@ -2,17 +2,20 @@ package accountclaim
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/go-logr/logr"
 	"github.com/ravitri/aws-account-operator/config"
 	"github.com/ravitri/aws-account-operator/controllers/account"
 	"github.com/ravitri/aws-account-operator/pkg/awsclient"
 	"github.com/ravitri/aws-account-operator/pkg/localmetrics"
+	"github.com/ravitri/aws-account-operator/pkg/totalaccountwatcher"
 	controllerutils "github.com/ravitri/aws-account-operator/pkg/utils"
 	corev1 "k8s.io/api/core/v1"
 	k8serr "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"time"
@@ -47,6 +50,8 @@ type AccountClaimReconciler struct {
 	client.Client
 	Scheme           *runtime.Scheme
 	awsClientBuilder awsclient.IBuilder
+	accountWatcher   totalaccountwatcher.AccountWatcherIface
+	recorder         record.EventRecorder
 }
 
 //+kubebuilder:rbac:groups=aws.managed.openshift.io,resources=accountclaims,verbs=get;list;watch;create;update;patch;delete
@@ -55,11 +60,12 @@ type AccountClaimReconciler struct {
 
 //go:generate mockgen -destination ./mock/cr-client.go -package mock sigs.k8s.io/controller-runtime/pkg/client Client
 // NewReconcileAccountClaim initializes ReconcileAccountClaim
-func NewAccountClaimReconciler(client client.Client, scheme *runtime.Scheme, awsClientBuilder awsclient.IBuilder) *AccountClaimReconciler {
+func NewAccountClaimReconciler(client client.Client, scheme *runtime.Scheme, awsClientBuilder awsclient.IBuilder, recorder record.EventRecorder) *AccountClaimReconciler {
 	return &AccountClaimReconciler{
 		Client:           client,
 		Scheme:           scheme,
 		awsClientBuilder: awsClientBuilder,
+		recorder:         recorder,
 	}
 }
 
@@ -106,7 +112,7 @@ func (r *AccountClaimReconciler) Reconcile(ctx context.Context, request ctrl.Req
 	}
 
 	if accountClaim.DeletionTimestamp != nil {
-		return reconcile.Result{}, r.handleAccountClaimDeletion(reqLogger, accountClaim)
+		return r.handleAccountClaimDeletion(reqLogger, accountClaim)
 	}
 
 	isCCS := accountClaim.Spec.BYOCAWSAccountID != ""
@@ -127,6 +133,12 @@ func (r *AccountClaimReconciler) Reconcile(ctx context.Context, request ctrl.Req
 		return reconcile.Result{}, nil
 	}
 
+	// Claims that timed out waiting for an account don't get one anymore
+	if claimTimedOut(accountClaim) {
+		reqLogger.Info(fmt.Sprintf("Claim %s timed out waiting for an account ignoring", accountClaim.ObjectMeta.Name))
+		return reconcile.Result{}, nil
+	}
+
 	if accountClaim.Status.State == "" {
 		message := "Attempting to claim account"
 		reqLogger.Info(message)
@@ -156,14 +168,41 @@ func (r *AccountClaimReconciler) Reconcile(ctx context.Context, request ctrl.Req
 		return reconcile.Result{}, err
 	}
 
+	// Only keep the accounts of the pools the claim can get an account from
+	pools, err := r.claimablePools(accountClaim)
+	if err != nil {
+		reqLogger.Error(err, "Unable to get the accountpools of the claim")
+		return reconcile.Result{}, err
+	}
+	allAccounts := accountList.Items
+	accountList.Items = filterAccountsByPool(accountList.Items, pools)
+
 	var unclaimedAccount *awsv1alpha1.Account
 
 	// Get an unclaimed account from the pool
 	if accountClaim.Spec.AccountLink == "" {
-		unclaimedAccount, err = getUnclaimedAccount(reqLogger, accountList, accountClaim)
-		if err != nil {
-			reqLogger.Error(err, "Unable to select an unclaimed account from the pool")
-			return reconcile.Result{}, err
+		// An account reserved by a previous reconcile that failed to link the claim back is linked
+		// right away, the claim already had its turn
+		unclaimedAccount = reservedAccount(allAccounts, accountClaim)
+		if unclaimedAccount != nil {
+			reqLogger.Info(fmt.Sprintf("Account %s is already reserved for the claim", unclaimedAccount.Name))
+		} else {
+			// Wait for the turn of the claim
+			scheduled, requeueAfter, err := r.scheduleClaim(reqLogger, accountClaim, allAccounts, accountList.Items)
+			if err != nil || !scheduled {
+				return reconcile.Result{RequeueAfter: requeueAfter}, err
+			}
+
+			unclaimedAccount, err = r.reserveAccount(reqLogger, accountList, accountClaim)
+			if k8serr.IsConflict(err) || k8serr.IsNotFound(err) {
+				reqLogger.Info("Every account the claim can get was reserved by another claim, retrying")
+				return reconcile.Result{Requeue: true}, nil
+			}
+			if err != nil {
+				reqLogger.Error(err, "Unable to select an unclaimed account from the pool")
+				r.recorder.Event(accountClaim, corev1.EventTypeWarning, controllerutils.EventReasonClaimFailed, err.Error())
+				return reconcile.Result{}, err
+			}
 		}
 	} else {
 		unclaimedAccount, err = r.getClaimedAccount(accountClaim.Spec.AccountLink, awsv1alpha1.AccountCrNamespace)
@@ -233,6 +272,7 @@ func (r *AccountClaimReconciler) Reconcile(ctx context.Context, request ctrl.Req
 	if accountClaim.Status.State != awsv1alpha1.ClaimStatusReady && accountClaim.Spec.AccountLink != "" {
 		// Set AccountClaim.Status.Conditions and AccountClaim.Status.State to Ready
 		setAccountClaimStatus(reqLogger, unclaimedAccount, accountClaim)
+		r.recorder.Eventf(accountClaim, corev1.EventTypeNormal, controllerutils.EventReasonClaimed, "Account claim fulfilled by %s", unclaimedAccount.Name)
 		return reconcile.Result{}, r.statusUpdate(reqLogger, accountClaim)
 	}
 
@@ -248,16 +288,26 @@ func (r *AccountClaimReconciler) setSupportRoleARNManagedOpenshift(reqLogger log
 	return nil
 }
 
-func (r *AccountClaimReconciler) handleAccountClaimDeletion(reqLogger logr.Logger, accountClaim *awsv1alpha1.AccountClaim) error {
+func (r *AccountClaimReconciler) handleAccountClaimDeletion(reqLogger logr.Logger, accountClaim *awsv1alpha1.AccountClaim) (reconcile.Result, error) {
 
 	if !controllerutils.Contains(accountClaim.GetFinalizers(), accountClaimFinalizer) {
-		return nil
+		return reconcile.Result{}, nil
 	}
 
 	// Only do AWS cleanup and account reset if accountLink is not empty
 	// We will not attempt AWS cleanup if the account is BYOC since we're not going to reuse these accounts
 	if accountClaim.Spec.AccountLink != "" {
 		err := r.finalizeAccountClaim(reqLogger, accountClaim)
+		// A cleanup dry run keeps the finalizer without failing the account, and is repeated until
+		// it's switched off and the claim is finalized for real
+		if errors.Is(err, awsv1alpha1.ErrAccountReuseCleanupDryRun) {
+			return reconcile.Result{RequeueAfter: cleanupDryRunRequeueAfter}, nil
+		}
+		// Resources AWS deletes asynchronously keep the ones depending on them around, the
+		// cleanup runs again once they're gone without failing the account
+		if errors.Is(err, awsv1alpha1.ErrAccountReuseCleanupPending) {
+			return reconcile.Result{RequeueAfter: cleanupPendingRequeueAfter}, nil
+		}
 		if err != nil {
 			// If the finalize/cleanup process fails for an account we don't want to return
 			// we will flag the account with the Failed Reuse condition, and with state = Failed
@@ -265,28 +315,28 @@ func (r *AccountClaimReconciler) handleAccountClaimDeletion(reqLogger logr.Logge
 			// First we want to see if this was an update race condition where the credentials rotator will update the CR while the finalizer is trying to run.  If that's the case, we want to requeue and retry, before outright failing the account.
 			if k8serr.IsConflict(err) {
 				reqLogger.Info("Account CR Modified during CR reset.")
-				return fmt.Errorf("account CR modified during reset: %w", err)
+				return reconcile.Result{}, fmt.Errorf("account CR modified during reset: %w", err)
 			}
 
 			// Get account claimed by deleted accountclaim
 			failedReusedAccount, accountErr := r.getClaimedAccount(accountClaim.Spec.AccountLink, awsv1alpha1.AccountCrNamespace)
 			if accountErr != nil {
 				reqLogger.Error(accountErr, "Failed to get claimed account")
-				return fmt.Errorf("failed to get claimed account: %w", err)
+				return reconcile.Result{}, fmt.Errorf("failed to get claimed account: %w", err)
 			}
 			// Update account status and add "Reuse Failed" condition
 			accountErr = r.resetAccountSpecStatus(reqLogger, failedReusedAccount, accountClaim, awsv1alpha1.AccountFailed, "Failed")
 			if accountErr != nil {
 				reqLogger.Error(accountErr, "Failed updating account status for failed reuse")
-				return fmt.Errorf("failed updating account status for failed reuse: %w", err)
+				return reconcile.Result{}, fmt.Errorf("failed updating account status for failed reuse: %w", err)
 			}
 
-			return err
+			return reconcile.Result{}, err
 		}
 	}
 
 	// Remove finalizer to unlock deletion of the accountClaim
-	return r.removeFinalizer(reqLogger, accountClaim, accountClaimFinalizer)
+	return reconcile.Result{}, r.removeFinalizer(reqLogger, accountClaim, accountClaimFinalizer)
 }
 
 func (r *AccountClaimReconciler) handleBYOCAccountClaim(reqLogger logr.Logger, accountClaim *awsv1alpha1.AccountClaim) (reconcile.Result, error) {
@@ -318,6 +368,7 @@ func (r *AccountClaimReconciler) handleBYOCAccountClaim(reqLogger logr.Logger, a
 				awsv1alpha1.InvalidAccountClaim,
 				awsv1alpha1.ClaimStatusError,
 			)
+			r.recorder.Event(accountClaim, corev1.EventTypeWarning, controllerutils.EventReasonInvalidClaim, errReason)
 			err := r.Client.Status().Update(context.TODO(), accountClaim)
 			if err != nil {
 				reqLogger.Error(err, "Failed to Update AccountClaim Status")
@@ -357,6 +408,7 @@ func (r *AccountClaimReconciler) handleBYOCAccountClaim(reqLogger logr.Logger, a
 				controllerutils.UpdateConditionNever,
 				accountClaim.Spec.BYOCAWSAccountID != "",
 			)
+			r.recorder.Eventf(accountClaim, corev1.EventTypeWarning, string(awsv1alpha1.CCSAccountClaimFailed), "CCS account %s failed", byocAccount.Name)
 			// Update the status on AccountClaim
 			return reconcile.Result{}, r.statusUpdate(reqLogger, accountClaim)
 		}
@@ -377,6 +429,7 @@ func (r *AccountClaimReconciler) handleBYOCAccountClaim(reqLogger logr.Logger, a
 			controllerutils.UpdateConditionNever,
 			accountClaim.Spec.BYOCAWSAccountID != "",
 		)
+		r.recorder.Eventf(accountClaim, corev1.EventTypeNormal, controllerutils.EventReasonClaimed, "Account claim fulfilled by CCS account %s", byocAccount.Name)
 		// Update the status on AccountClaim
 		return reconcile.Result{}, r.statusUpdate(reqLogger, accountClaim)
 	}
@@ -402,7 +455,7 @@ func (r *AccountClaimReconciler) handleBYOCAccountClaim(reqLogger logr.Logger, a
 
 func (r *AccountClaimReconciler) createAccountForBYOCClaim(accountClaim *awsv1alpha1.AccountClaim) error {
 	// Create a new account with BYOC flag
-	newAccount := account.GenerateAccountCR(awsv1alpha1.AccountCrNamespace)
+	newAccount := account.GenerateAccountCR(awsv1alpha1.AccountCrNamespace, account.GetAccountNamePrefix(r.Client))
 	populateBYOCSpec(newAccount, accountClaim)
 	controllerutils.AddFinalizer(newAccount, accountClaimFinalizer)
 
@@ -427,49 +480,6 @@ func (r *AccountClaimReconciler) getClaimedAccount(accountLink string, namespace
 	return account, nil
 }
 
-func getUnclaimedAccount(reqLogger logr.Logger, accountList *awsv1alpha1.AccountList, accountClaim *awsv1alpha1.AccountClaim) (*awsv1alpha1.Account, error) {
-	var unclaimedAccount awsv1alpha1.Account
-	var reusedAccount awsv1alpha1.Account
-	var unclaimedAccountFound = false
-	var reusedAccountFound = false
-	time.Sleep(1000 * time.Millisecond)
-
-	// Range through accounts and select the first one that doesn't have a claim link
-	for i, account := range accountList.Items {
-		if !account.Status.Claimed && account.Spec.ClaimLink == "" && account.Status.State == "Ready" {
-			// Check for a reused account with matching legalEntity
-			if account.Status.Reused {
-				if matchAccountForReuse(&accountList.Items[i], accountClaim) {
-					reusedAccountFound = true
-					reusedAccount = account
-					// if available we break the loop, reused account takes priority
-					break
-				}
-			} else {
-				// If account is not reused, and we didn't claim one yet, do it
-				if !unclaimedAccountFound {
-					unclaimedAccount = account
-					unclaimedAccountFound = true
-				}
-			}
-		}
-	}
-
-	// Give priority to reusing accounts instead of claiming
-	if reusedAccountFound {
-		reqLogger.Info(fmt.Sprintf("Reusing account: %s", reusedAccount.ObjectMeta.Name))
-		return &reusedAccount, nil
-	}
-	// Go for unclaimed accounts
-	if unclaimedAccountFound {
-		reqLogger.Info(fmt.Sprintf("Claiming account: %s", unclaimedAccount.ObjectMeta.Name))
-		return &unclaimedAccount, nil
-	}
-
-	// Neither unclaimed nor reused accounts found
-	return nil, fmt.Errorf("can't find a ready account to claim")
-}
-
 func (r *AccountClaimReconciler) createIAMSecret(reqLogger logr.Logger, accountClaim *awsv1alpha1.AccountClaim, unclaimedAccount *awsv1alpha1.Account) error {
 	// Get secret created by Account controller and copy it to the name/namespace combo that OCM is expecting
 	accountIAMUserSecret := &corev1.Secret{}
@@ -562,7 +572,19 @@ func setAccountClaimStatus(reqLogger logr.Logger, awsAccount *awsv1alpha1.Accoun
 		controllerutils.UpdateConditionNever,
 		awsAccountClaim.Spec.BYOCAWSAccountID != "",
 	)
+	if controllerutils.FindAccountClaimCondition(awsAccountClaim.Status.Conditions, awsv1alpha1.AccountClaimPending) != nil {
+		awsAccountClaim.Status.Conditions = controllerutils.SetAccountClaimCondition(
+			awsAccountClaim.Status.Conditions,
+			awsv1alpha1.AccountClaimPending,
+			corev1.ConditionFalse,
+			AccountClaimed,
+			message,
+			controllerutils.UpdateConditionIfReasonOrMessageChange,
+			awsAccountClaim.Spec.BYOCAWSAccountID != "",
+		)
+	}
 	awsAccountClaim.Status.State = awsv1alpha1.ClaimStatusReady
+	awsAccountClaim.Status.QueuePosition = 0
 	reqLogger.Info(fmt.Sprintf("Account %s condition status updated", awsAccountClaim.Name))
 }
 
@@ -613,6 +635,8 @@ func populateBYOCSpec(account *awsv1alpha1.Account, accountClaim *awsv1alpha1.Ac
 // SetupWithManager sets up the controller with the Manager.
 func (r *AccountClaimReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	r.awsClientBuilder = &awsclient.Builder{}
+	r.accountWatcher = totalaccountwatcher.TotalAccountWatcher
+	r.recorder = mgr.GetEventRecorderFor(controllerName)
 	maxReconciles, err := controllerutils.GetControllerMaxReconciles(controllerName)
 	if err != nil {
 		log.Error(err, "missing max reconciles for controller", "controller", controllerName)
@@ -8,6 +8,8 @@ import (
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/elb"
+	"github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/aws/aws-sdk-go/service/route53"
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/golang/mock/gomock"
@@ -16,11 +18,13 @@ import (
 	"github.com/ravitri/aws-account-operator/pkg/awsclient/mock"
 	"github.com/ravitri/aws-account-operator/pkg/localmetrics"
 	"github.com/ravitri/aws-account-operator/test/fixtures"
+	corev1 "k8s.io/api/core/v1"
 	k8serr "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -81,6 +85,7 @@ var _ = Describe("AccountClaim", func() {
 			awsClientBuilder: &mock.Builder{
 				MockController: ctrl,
 			},
+			recorder: &record.FakeRecorder{},
 		}
 	})
 
@@ -126,13 +131,24 @@ var _ = Describe("AccountClaim", func() {
 					},
 				}
 
-				objs = []runtime.Object{accountClaim, account}
+				configMap := &corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      awsv1alpha1.DefaultConfigMap,
+						Namespace: awsv1alpha1.AccountCrNamespace,
+					},
+					Data: map[string]string{},
+				}
+
+				objs = []runtime.Object{accountClaim, account, configMap}
 			})
 
 			It("should delete AccountClaim", func() {
 				r.Client = fake.NewClientBuilder().WithScheme(scheme.Scheme).WithRuntimeObjects(objs...).Build()
 
 				mockAWSClient := mock.GetMockClient(r.awsClientBuilder)
+				mockAWSClient.EXPECT().DescribeRegions(gomock.Any()).Return(&ec2.DescribeRegionsOutput{
+					Regions: []*ec2.Region{{RegionName: aws.String("us-east-1")}},
+				}, nil)
 				// Create empty empy aws responses.
 				lhzo := &route53.ListHostedZonesOutput{
 					HostedZones: []*route53.HostedZone{},
@@ -156,6 +172,7 @@ var _ = Describe("AccountClaim", func() {
 				mockAWSClient.EXPECT().DescribeVpcEndpointServiceConfigurations(gomock.Any()).Return(dvpcesco, nil)
 				mockAWSClient.EXPECT().DescribeSnapshots(gomock.Any()).Return(dso, nil)
 				mockAWSClient.EXPECT().DescribeVolumes(gomock.Any()).Return(dvo, nil)
+				expectNetworkCleanup(mockAWSClient, 1, nil)
 
 				// Confirm that the accountclaim exists from the client's perspective
 				ac := awsv1alpha1.AccountClaim{}
@@ -179,6 +196,29 @@ var _ = Describe("AccountClaim", func() {
 				Expect(acc.Status.Reused).To(BeTrue())
 			})
 
+			It("should clean up claims created without regions in the default region", func() {
+				accountClaim.Spec.Aws.Regions = nil
+				r.Client = fake.NewClientBuilder().WithScheme(scheme.Scheme).WithRuntimeObjects(objs...).Build()
+
+				mockAWSClient := mock.GetMockClient(r.awsClientBuilder)
+				mockAWSClient.EXPECT().DescribeRegions(gomock.Any()).Return(&ec2.DescribeRegionsOutput{
+					Regions: []*ec2.Region{{RegionName: aws.String("us-east-1")}},
+				}, nil)
+				mockAWSClient.EXPECT().ListHostedZones(gomock.Any()).Return(&route53.ListHostedZonesOutput{IsTruncated: aws.Bool(false)}, nil)
+				mockAWSClient.EXPECT().ListBuckets(gomock.Any()).Return(&s3.ListBucketsOutput{}, nil)
+				mockAWSClient.EXPECT().DescribeVpcEndpointServiceConfigurations(gomock.Any()).Return(&ec2.DescribeVpcEndpointServiceConfigurationsOutput{}, nil)
+				mockAWSClient.EXPECT().DescribeSnapshots(gomock.Any()).Return(&ec2.DescribeSnapshotsOutput{}, nil)
+				mockAWSClient.EXPECT().DescribeVolumes(gomock.Any()).Return(&ec2.DescribeVolumesOutput{}, nil)
+				expectNetworkCleanup(mockAWSClient, 1, nil)
+
+				_, err := r.Reconcile(context.TODO(), req)
+				Expect(err).ToNot(HaveOccurred())
+
+				ac := awsv1alpha1.AccountClaim{}
+				err = r.Client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: namespace}, &ac)
+				Expect(k8serr.IsNotFound(err)).To(BeTrue())
+			})
+
 			It("should retry on a conflict error", func() {
 				r.Client = &possiblyErroringFakeCtrlRuntimeClient{
 					fake.NewClientBuilder().WithScheme(scheme.Scheme).WithRuntimeObjects(objs...).Build(),
@@ -186,6 +226,9 @@ var _ = Describe("AccountClaim", func() {
 				}
 
 				mockAWSClient := mock.GetMockClient(r.awsClientBuilder)
+				mockAWSClient.EXPECT().DescribeRegions(gomock.Any()).Return(&ec2.DescribeRegionsOutput{
+					Regions: []*ec2.Region{{RegionName: aws.String("us-east-1")}},
+				}, nil)
 				// Create empty empy aws responses.
 				lhzo := &route53.ListHostedZonesOutput{
 					HostedZones: []*route53.HostedZone{},
@@ -209,6 +252,7 @@ var _ = Describe("AccountClaim", func() {
 				mockAWSClient.EXPECT().DescribeVpcEndpointServiceConfigurations(gomock.Any()).Return(dvpcesco, nil)
 				mockAWSClient.EXPECT().DescribeSnapshots(gomock.Any()).Return(dso, nil)
 				mockAWSClient.EXPECT().DescribeVolumes(gomock.Any()).Return(dvo, nil)
+				expectNetworkCleanup(mockAWSClient, 1, nil)
 
 				_, err := r.Reconcile(context.TODO(), req)
 
@@ -226,6 +270,9 @@ var _ = Describe("AccountClaim", func() {
 				r.Client = fake.NewClientBuilder().WithScheme(scheme.Scheme).WithRuntimeObjects(objs...).Build()
 
 				mockAWSClient := mock.GetMockClient(r.awsClientBuilder)
+				mockAWSClient.EXPECT().DescribeRegions(gomock.Any()).Return(&ec2.DescribeRegionsOutput{
+					Regions: []*ec2.Region{{RegionName: aws.String("us-east-1")}},
+				}, nil)
 				// Use a bogus error, just so we can fail AWS calls.
 				theErr := awserr.NewBatchError("foo", "bar", []error{})
 				mockAWSClient.EXPECT().ListHostedZones(gomock.Any()).Return(nil, theErr)
@@ -233,6 +280,7 @@ var _ = Describe("AccountClaim", func() {
 				mockAWSClient.EXPECT().DescribeVpcEndpointServiceConfigurations(gomock.Any()).Return(nil, theErr)
 				mockAWSClient.EXPECT().DescribeSnapshots(gomock.Any()).Return(nil, theErr)
 				mockAWSClient.EXPECT().DescribeVolumes(gomock.Any()).Return(nil, theErr)
+				expectNetworkCleanup(mockAWSClient, 1, theErr)
 
 				_, err := r.Reconcile(context.TODO(), req)
 
@@ -243,6 +291,186 @@ var _ = Describe("AccountClaim", func() {
 				err = r.Client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: namespace}, &ac)
 				Expect(err).NotTo(HaveOccurred())
 				Expect(ac.Finalizers).To(Equal(accountClaim.GetFinalizers()))
+
+				// Ensure the failed cleaners were recorded on the account.
+				acc := awsv1alpha1.Account{}
+				err = r.Client.Get(context.TODO(), types.NamespacedName{Name: ac.Spec.AccountLink, Namespace: awsv1alpha1.AccountCrNamespace}, &acc)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(acc.Status.ReuseCleanup).ToNot(BeNil())
+				Expect(acc.Status.ReuseCleanup.Succeeded).To(BeFalse())
+				Expect(acc.Status.ReuseCleanup.Results).To(HaveLen(14))
+				for _, result := range acc.Status.ReuseCleanup.Results {
+					Expect(result.Error).ToNot(BeEmpty())
+				}
+			})
+
+			It("should clean up regional resources in every enabled region", func() {
+				r.Client = fake.NewClientBuilder().WithScheme(scheme.Scheme).WithRuntimeObjects(objs...).Build()
+
+				mockAWSClient := mock.GetMockClient(r.awsClientBuilder)
+				mockAWSClient.EXPECT().DescribeRegions(gomock.Any()).Return(&ec2.DescribeRegionsOutput{
+					Regions: []*ec2.Region{
+						{RegionName: aws.String("us-east-1")},
+						{RegionName: aws.String("eu-west-1")},
+					},
+				}, nil)
+				// Global services are only cleaned up once
+				mockAWSClient.EXPECT().ListHostedZones(gomock.Any()).Return(&route53.ListHostedZonesOutput{IsTruncated: aws.Bool(false)}, nil).Times(1)
+				mockAWSClient.EXPECT().ListBuckets(gomock.Any()).Return(&s3.ListBucketsOutput{}, nil).Times(1)
+				mockAWSClient.EXPECT().DescribeVpcEndpointServiceConfigurations(gomock.Any()).Return(&ec2.DescribeVpcEndpointServiceConfigurationsOutput{}, nil).Times(2)
+				mockAWSClient.EXPECT().DescribeSnapshots(gomock.Any()).Return(&ec2.DescribeSnapshotsOutput{}, nil).Times(2)
+				mockAWSClient.EXPECT().DescribeVolumes(gomock.Any()).Return(&ec2.DescribeVolumesOutput{}, nil).Times(2)
+				expectNetworkCleanup(mockAWSClient, 2, nil)
+
+				_, err := r.Reconcile(context.TODO(), req)
+				Expect(err).ToNot(HaveOccurred())
+
+				acc := awsv1alpha1.Account{}
+				err = r.Client.Get(context.TODO(), types.NamespacedName{Name: accountClaim.Spec.AccountLink, Namespace: awsv1alpha1.AccountCrNamespace}, &acc)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(acc.Status.ReuseCleanup.Results).To(HaveLen(26))
+				regions := map[string]int{}
+				for _, result := range acc.Status.ReuseCleanup.Results {
+					regions[result.Region]++
+				}
+				Expect(regions).To(Equal(map[string]int{"": 2, "us-east-1": 12, "eu-west-1": 12}))
+			})
+
+			It("should requeue without failing the account while resources wait on asynchronous deletions", func() {
+				r.Client = fake.NewClientBuilder().WithScheme(scheme.Scheme).WithRuntimeObjects(objs...).Build()
+
+				mockAWSClient := mock.GetMockClient(r.awsClientBuilder)
+				mockAWSClient.EXPECT().DescribeRegions(gomock.Any()).Return(&ec2.DescribeRegionsOutput{
+					Regions: []*ec2.Region{{RegionName: aws.String("us-east-1")}},
+				}, nil)
+				mockAWSClient.EXPECT().ListHostedZones(gomock.Any()).Return(&route53.ListHostedZonesOutput{IsTruncated: aws.Bool(false)}, nil)
+				mockAWSClient.EXPECT().ListBuckets(gomock.Any()).Return(&s3.ListBucketsOutput{}, nil)
+				mockAWSClient.EXPECT().DescribeVpcEndpointServiceConfigurations(gomock.Any()).Return(&ec2.DescribeVpcEndpointServiceConfigurationsOutput{}, nil)
+				mockAWSClient.EXPECT().DescribeSnapshots(gomock.Any()).Return(&ec2.DescribeSnapshotsOutput{}, nil)
+				mockAWSClient.EXPECT().DescribeVolumes(gomock.Any()).Return(&ec2.DescribeVolumesOutput{}, nil)
+				mockAWSClient.EXPECT().DescribeInstances(gomock.Any()).Return(&ec2.DescribeInstancesOutput{}, nil)
+				mockAWSClient.EXPECT().DescribeLoadBalancers(gomock.Any()).Return(&elb.DescribeLoadBalancersOutput{}, nil)
+				mockAWSClient.EXPECT().DescribeLoadBalancersV2(gomock.Any()).Return(&elbv2.DescribeLoadBalancersOutput{}, nil)
+				mockAWSClient.EXPECT().DescribeVpcEndpoints(gomock.Any()).Return(&ec2.DescribeVpcEndpointsOutput{}, nil)
+				mockAWSClient.EXPECT().DescribeNetworkInterfaces(gomock.Any()).Return(&ec2.DescribeNetworkInterfacesOutput{}, nil)
+				mockAWSClient.EXPECT().DescribeSecurityGroups(gomock.Any()).Return(&ec2.DescribeSecurityGroupsOutput{}, nil)
+				mockAWSClient.EXPECT().DescribeVpcs(gomock.Any()).Return(&ec2.DescribeVpcsOutput{}, nil)
+				// The NAT gateway is still being deleted, so its address can't be released yet
+				mockAWSClient.EXPECT().DescribeNatGateways(gomock.Any()).Return(&ec2.DescribeNatGatewaysOutput{
+					NatGateways: []*ec2.NatGateway{{NatGatewayId: aws.String("nat-1"), State: aws.String(ec2.NatGatewayStateDeleting)}},
+				}, nil)
+				mockAWSClient.EXPECT().DescribeAddresses(gomock.Any()).Return(&ec2.DescribeAddressesOutput{
+					Addresses: []*ec2.Address{{AllocationId: aws.String("eipalloc-1"), AssociationId: aws.String("eipassoc-1")}},
+				}, nil)
+				mockAWSClient.EXPECT().ReleaseAddress(gomock.Any()).Times(0)
+
+				result, err := r.Reconcile(context.TODO(), req)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result.RequeueAfter).To(Equal(cleanupPendingRequeueAfter))
+
+				ac := awsv1alpha1.AccountClaim{}
+				err = r.Client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: namespace}, &ac)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ac.Finalizers).To(Equal(accountClaim.GetFinalizers()))
+
+				// The account is neither failed nor reset, it's waiting for the next run
+				acc := awsv1alpha1.Account{}
+				err = r.Client.Get(context.TODO(), types.NamespacedName{Name: accountClaim.Spec.AccountLink, Namespace: awsv1alpha1.AccountCrNamespace}, &acc)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(acc.Status.State).ToNot(Equal(AccountFailed))
+				Expect(acc.Status.Reused).To(BeFalse())
+				Expect(acc.Status.ReuseCleanup.Succeeded).To(BeFalse())
+				Expect(acc.GetCondition(awsv1alpha1.AccountReuseCleanupFailed)).To(BeNil())
+				Expect(acc.Status.ReuseCleanup.Results).To(ContainElement(awsv1alpha1.CleanupResult{
+					Cleaner: "elastic-ips",
+					Region:  "us-east-1",
+					Found:   1,
+					Pending: 1,
+				}))
+			})
+
+			It("should not clean up when the enabled regions can't be listed", func() {
+				r.Client = fake.NewClientBuilder().WithScheme(scheme.Scheme).WithRuntimeObjects(objs...).Build()
+
+				mockAWSClient := mock.GetMockClient(r.awsClientBuilder)
+				mockAWSClient.EXPECT().DescribeRegions(gomock.Any()).Return(nil, awserr.New("UnauthorizedOperation", "", nil))
+
+				_, err := r.Reconcile(context.TODO(), req)
+				Expect(err).To(HaveOccurred())
+
+				ac := awsv1alpha1.AccountClaim{}
+				err = r.Client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: namespace}, &ac)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ac.Finalizers).To(Equal(accountClaim.GetFinalizers()))
+			})
+
+			It("should only list resources in dry run mode", func() {
+				accountClaim.SetAnnotations(map[string]string{cleanupDryRunAnnotation: "true"})
+				r.Client = fake.NewClientBuilder().WithScheme(scheme.Scheme).WithRuntimeObjects(objs...).Build()
+
+				mockAWSClient := mock.GetMockClient(r.awsClientBuilder)
+				mockAWSClient.EXPECT().DescribeRegions(gomock.Any()).Return(&ec2.DescribeRegionsOutput{
+					Regions: []*ec2.Region{{RegionName: aws.String("us-east-1")}},
+				}, nil)
+				mockAWSClient.EXPECT().ListHostedZones(gomock.Any()).Return(&route53.ListHostedZonesOutput{IsTruncated: aws.Bool(false)}, nil)
+				mockAWSClient.EXPECT().ListBuckets(gomock.Any()).Return(&s3.ListBucketsOutput{
+					Buckets: []*s3.Bucket{{Name: aws.String("leftover")}},
+				}, nil)
+				mockAWSClient.EXPECT().DescribeVpcEndpointServiceConfigurations(gomock.Any()).Return(&ec2.DescribeVpcEndpointServiceConfigurationsOutput{}, nil)
+				mockAWSClient.EXPECT().DescribeSnapshots(gomock.Any()).Return(&ec2.DescribeSnapshotsOutput{}, nil)
+				mockAWSClient.EXPECT().DescribeVolumes(gomock.Any()).Return(&ec2.DescribeVolumesOutput{}, nil)
+				expectNetworkCleanup(mockAWSClient, 1, nil)
+				// Nothing may be deleted
+				mockAWSClient.EXPECT().DeleteBucket(gomock.Any()).Times(0)
+				mockAWSClient.EXPECT().ListObjectsV2(gomock.Any()).Times(0)
+
+				result, err := r.Reconcile(context.TODO(), req)
+				Expect(err).ToNot(HaveOccurred())
+				// The dry run is repeated until it's switched off
+				Expect(result.RequeueAfter).To(Equal(cleanupDryRunRequeueAfter))
+
+				// The claim is kept until cleanup runs for real
+				ac := awsv1alpha1.AccountClaim{}
+				err = r.Client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: namespace}, &ac)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ac.Finalizers).To(Equal(accountClaim.GetFinalizers()))
+
+				// The account is not reset, and holds the inventory
+				acc := awsv1alpha1.Account{}
+				err = r.Client.Get(context.TODO(), types.NamespacedName{Name: accountClaim.Spec.AccountLink, Namespace: awsv1alpha1.AccountCrNamespace}, &acc)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(acc.Status.Reused).To(BeFalse())
+				Expect(acc.Status.ReuseCleanup.DryRun).To(BeTrue())
+				Expect(acc.Status.ReuseCleanup.Results).To(ContainElement(awsv1alpha1.CleanupResult{
+					Cleaner:   "s3-buckets",
+					Found:     1,
+					Resources: []string{"leftover"},
+				}))
+			})
+
+			It("should skip cleaners disabled in the configmap", func() {
+				configMap := objs[2].(*corev1.ConfigMap)
+				configMap.Data["cleanup.s3-buckets.enabled"] = "false"
+				configMap.Data["cleanup.route53-hosted-zones.enabled"] = "false"
+				r.Client = fake.NewClientBuilder().WithScheme(scheme.Scheme).WithRuntimeObjects(objs...).Build()
+
+				mockAWSClient := mock.GetMockClient(r.awsClientBuilder)
+				mockAWSClient.EXPECT().DescribeRegions(gomock.Any()).Return(&ec2.DescribeRegionsOutput{
+					Regions: []*ec2.Region{{RegionName: aws.String("us-east-1")}},
+				}, nil)
+				mockAWSClient.EXPECT().DescribeVpcEndpointServiceConfigurations(gomock.Any()).Return(&ec2.DescribeVpcEndpointServiceConfigurationsOutput{}, nil)
+				mockAWSClient.EXPECT().DescribeSnapshots(gomock.Any()).Return(&ec2.DescribeSnapshotsOutput{}, nil)
+				mockAWSClient.EXPECT().DescribeVolumes(gomock.Any()).Return(&ec2.DescribeVolumesOutput{}, nil)
+				expectNetworkCleanup(mockAWSClient, 1, nil)
+
+				_, err := r.Reconcile(context.TODO(), req)
+				Expect(err).ToNot(HaveOccurred())
+
+				acc := awsv1alpha1.Account{}
+				err = r.Client.Get(context.TODO(), types.NamespacedName{Name: accountClaim.Spec.AccountLink, Namespace: awsv1alpha1.AccountCrNamespace}, &acc)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(acc.Status.ReuseCleanup.Succeeded).To(BeTrue())
+				Expect(acc.Status.ReuseCleanup.Results).To(HaveLen(12))
 			})
 		})
 
@@ -299,6 +527,32 @@ var _ = Describe("AccountClaim", func() {
 	})
 })
 
+// expectNetworkCleanup expects the describe calls of the EC2 and load balancer cleaners in every
+// region. They find nothing to delete, or fail with err if it is set.
+func expectNetworkCleanup(mockAWSClient *mock.MockClient, regions int, err error) {
+	if err != nil {
+		mockAWSClient.EXPECT().DescribeInstances(gomock.Any()).Return(nil, err).Times(regions)
+		mockAWSClient.EXPECT().DescribeLoadBalancers(gomock.Any()).Return(nil, err).Times(regions)
+		mockAWSClient.EXPECT().DescribeLoadBalancersV2(gomock.Any()).Return(nil, err).Times(regions)
+		mockAWSClient.EXPECT().DescribeNatGateways(gomock.Any()).Return(nil, err).Times(regions)
+		mockAWSClient.EXPECT().DescribeAddresses(gomock.Any()).Return(nil, err).Times(regions)
+		mockAWSClient.EXPECT().DescribeSecurityGroups(gomock.Any()).Return(nil, err).Times(regions)
+		mockAWSClient.EXPECT().DescribeVpcs(gomock.Any()).Return(nil, err).Times(regions)
+		mockAWSClient.EXPECT().DescribeVpcEndpoints(gomock.Any()).Return(nil, err).Times(regions)
+		mockAWSClient.EXPECT().DescribeNetworkInterfaces(gomock.Any()).Return(nil, err).Times(regions)
+		return
+	}
+	mockAWSClient.EXPECT().DescribeInstances(gomock.Any()).Return(&ec2.DescribeInstancesOutput{}, nil).Times(regions)
+	mockAWSClient.EXPECT().DescribeLoadBalancers(gomock.Any()).Return(&elb.DescribeLoadBalancersOutput{}, nil).Times(regions)
+	mockAWSClient.EXPECT().DescribeLoadBalancersV2(gomock.Any()).Return(&elbv2.DescribeLoadBalancersOutput{}, nil).Times(regions)
+	mockAWSClient.EXPECT().DescribeNatGateways(gomock.Any()).Return(&ec2.DescribeNatGatewaysOutput{}, nil).Times(regions)
+	mockAWSClient.EXPECT().DescribeAddresses(gomock.Any()).Return(&ec2.DescribeAddressesOutput{}, nil).Times(regions)
+	mockAWSClient.EXPECT().DescribeSecurityGroups(gomock.Any()).Return(&ec2.DescribeSecurityGroupsOutput{}, nil).Times(regions)
+	mockAWSClient.EXPECT().DescribeVpcs(gomock.Any()).Return(&ec2.DescribeVpcsOutput{}, nil).Times(regions)
+	mockAWSClient.EXPECT().DescribeVpcEndpoints(gomock.Any()).Return(&ec2.DescribeVpcEndpointsOutput{}, nil).Times(regions)
+	mockAWSClient.EXPECT().DescribeNetworkInterfaces(gomock.Any()).Return(&ec2.DescribeNetworkInterfacesOutput{}, nil).Times(regions)
+}
+
 type possiblyErroringFakeCtrlRuntimeClient struct {
 	client.Client
 	shouldError bool
@@ -17,6 +17,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
@@ -104,6 +105,7 @@ var _ = Describe("AccountClaim", func() {
 			awsClientBuilder: &mock.Builder{
 				MockController: ctrl,
 			},
+			recorder: &record.FakeRecorder{},
 		}
 	})
 
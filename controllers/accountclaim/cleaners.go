@@ -0,0 +1,699 @@
+package accountclaim
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+
+	awsv1alpha1 "github.com/ravitri/aws-account-operator/api/v1alpha1"
+	"github.com/ravitri/aws-account-operator/pkg/awsclient"
+)
+
+const (
+	// cleanerEnabledConfigMapKey is the configmap key used to switch a cleaner off, e.g.
+	// "cleanup.s3-buckets.enabled: false". Cleaners are enabled unless explicitly disabled.
+	cleanerEnabledConfigMapKey = "cleanup.%s.enabled"
+
+	// maxConcurrentRegionsConfigMapKey is the configmap key limiting how many regions are cleaned
+	// up at the same time
+	maxConcurrentRegionsConfigMapKey = "cleanup.max-concurrent-regions"
+	defaultMaxConcurrentRegions      = 5
+
+	// cleanupDryRunConfigMapKey turns every reuse cleanup into an inventory of what would be
+	// deleted. cleanupDryRunAnnotation does the same for a single AccountClaim and takes
+	// precedence over the configmap.
+	cleanupDryRunConfigMapKey = "cleanup.dry-run"
+	cleanupDryRunAnnotation   = "managed.openshift.com/cleanup-dry-run"
+
+	// cleanupPendingRequeueAfter is the wait before the cleanup runs again when resources wait on
+	// AWS to finish deleting what they depend on
+	cleanupPendingRequeueAfter = time.Minute
+	// cleanupDryRunRequeueAfter is the wait before a dry run is repeated, so switching dry runs off
+	// runs the real cleanup without waiting for the resync period
+	cleanupDryRunRequeueAfter = 10 * time.Minute
+
+	// maxReportedResources caps the resource names a dry run writes to the Account status. The
+	// counts of the results still cover every resource found.
+	maxReportedResources = 100
+)
+
+// dependencyErrorCodes are the AWS errors returned when deleting a resource something else still
+// uses. The cleaners only get them while AWS deletes the dependent resources asynchronously, e.g.
+// an Elastic IP of a NAT gateway being deleted, or a subnet with the interfaces of terminating
+// instances, so the resource is left for the next cleanup run.
+var dependencyErrorCodes = map[string]bool{
+	"DependencyViolation":           true,
+	"InvalidIPAddress.InUse":        true,
+	"InvalidNetworkInterface.InUse": true,
+	"VolumeInUse":                   true,
+	"IncorrectState":                true,
+}
+
+// Cleaner deletes one kind of AWS resource from an account before it is reused
+type Cleaner interface {
+	// Name identifies the cleaner in cleanup results and in its configmap enable flag
+	Name() string
+	// Global returns true for cleaners of global services, which run once per account rather
+	// than once per enabled region
+	Global() bool
+	// Clean deletes every resource the cleaner is responsible for. The result is always
+	// filled in, even when an error is returned. Resources that can only be deleted once AWS
+	// finished deleting what they depend on are counted as pending, and the cleaner returns
+	// ErrAccountReuseCleanupPending if nothing else failed. With dryRun set the resources are only
+	// listed in the result, nothing is deleted.
+	Clean(reqLogger logr.Logger, awsClient awsclient.Client, dryRun bool) (awsv1alpha1.CleanupResult, error)
+}
+
+var (
+	cleanerRegistry      []Cleaner
+	cleanerRegistryMutex sync.Mutex
+)
+
+// RegisterCleaner adds a Cleaner to the set run by reuse cleanup. Registering two cleaners with
+// the same name is a programming error and panics.
+func RegisterCleaner(cleaner Cleaner) {
+	cleanerRegistryMutex.Lock()
+	defer cleanerRegistryMutex.Unlock()
+
+	for _, registered := range cleanerRegistry {
+		if registered.Name() == cleaner.Name() {
+			panic(fmt.Sprintf("cleaner %s registered twice", cleaner.Name()))
+		}
+	}
+	cleanerRegistry = append(cleanerRegistry, cleaner)
+}
+
+// RegisteredCleaners returns the registered cleaners in registration order
+func RegisteredCleaners() []Cleaner {
+	cleanerRegistryMutex.Lock()
+	defer cleanerRegistryMutex.Unlock()
+
+	cleaners := make([]Cleaner, len(cleanerRegistry))
+	copy(cleaners, cleanerRegistry)
+	return cleaners
+}
+
+// Regional cleaners run one after the other in registration order, so they are registered in
+// dependency order: endpoints and endpoint services before the load balancers backing them,
+// instances and NAT gateways before their addresses, network interfaces and security groups, and
+// VPCs last.
+func init() {
+	RegisterCleaner(Ec2InstanceCleaner{})
+	RegisterCleaner(VpcEndpointCleaner{})
+	RegisterCleaner(VpcEndpointServiceCleaner{})
+	RegisterCleaner(ClassicLoadBalancerCleaner{})
+	RegisterCleaner(LoadBalancerCleaner{})
+	RegisterCleaner(NatGatewayCleaner{})
+	RegisterCleaner(ElasticIPCleaner{})
+	RegisterCleaner(NetworkInterfaceCleaner{})
+	RegisterCleaner(EbsVolumeCleaner{})
+	RegisterCleaner(SnapshotCleaner{})
+	RegisterCleaner(SecurityGroupCleaner{})
+	RegisterCleaner(VpcCleaner{})
+	RegisterCleaner(S3Cleaner{})
+	RegisterCleaner(Route53Cleaner{})
+}
+
+// cleanerEnabled returns false only if the configmap explicitly disables the cleaner
+func cleanerEnabled(configMap *corev1.ConfigMap, name string) bool {
+	if configMap == nil {
+		return true
+	}
+	value, ok := configMap.Data[fmt.Sprintf(cleanerEnabledConfigMapKey, name)]
+	if !ok {
+		return true
+	}
+	enabled, err := strconv.ParseBool(value)
+	if err != nil {
+		log.Info(fmt.Sprintf("Could not parse %s, cleaner stays enabled", fmt.Sprintf(cleanerEnabledConfigMapKey, name)))
+		return true
+	}
+	return enabled
+}
+
+// enabledCleaners returns the registered cleaners that are not disabled in the configmap
+func enabledCleaners(configMap *corev1.ConfigMap) []Cleaner {
+	cleaners := []Cleaner{}
+	for _, cleaner := range RegisteredCleaners() {
+		if cleanerEnabled(configMap, cleaner.Name()) {
+			cleaners = append(cleaners, cleaner)
+		}
+	}
+	return cleaners
+}
+
+// cleanupDryRun returns true if the reuse cleanup of the AccountClaim must only list resources,
+// either because the claim is annotated so or because dry runs are enabled in the configmap
+func cleanupDryRun(accountClaim *awsv1alpha1.AccountClaim, configMap *corev1.ConfigMap) bool {
+	if value, ok := accountClaim.Annotations[cleanupDryRunAnnotation]; ok {
+		dryRun, err := strconv.ParseBool(value)
+		if err == nil {
+			return dryRun
+		}
+		log.Info(fmt.Sprintf("Could not parse annotation %s on %s/%s", cleanupDryRunAnnotation, accountClaim.Namespace, accountClaim.Name))
+	}
+
+	if configMap == nil {
+		return false
+	}
+	dryRun, err := strconv.ParseBool(configMap.Data[cleanupDryRunConfigMapKey])
+	if err != nil {
+		return false
+	}
+	return dryRun
+}
+
+// getMaxConcurrentRegions returns how many regions may be cleaned up at the same time
+func getMaxConcurrentRegions(configMap *corev1.ConfigMap) int {
+	if configMap == nil {
+		return defaultMaxConcurrentRegions
+	}
+	value, ok := configMap.Data[maxConcurrentRegionsConfigMapKey]
+	if !ok {
+		return defaultMaxConcurrentRegions
+	}
+	maxConcurrentRegions, err := strconv.Atoi(value)
+	if err != nil || maxConcurrentRegions < 1 {
+		log.Info(fmt.Sprintf("Invalid value for %s, using %d", maxConcurrentRegionsConfigMapKey, defaultMaxConcurrentRegions))
+		return defaultMaxConcurrentRegions
+	}
+	return maxConcurrentRegions
+}
+
+// listEnabledRegions returns the names of the regions enabled in the account
+func listEnabledRegions(awsClient awsclient.Client) ([]string, error) {
+	regionsEnabledInAccount, err := awsClient.DescribeRegions(&ec2.DescribeRegionsInput{
+		AllRegions: aws.Bool(false),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	regions := []string{}
+	for _, region := range regionsEnabledInAccount.Regions {
+		regions = append(regions, *region.RegionName)
+	}
+	return regions, nil
+}
+
+// runCleaners runs the global cleaners once with globalClient, in parallel, and the regional
+// cleaners in every region, with at most maxConcurrentRegions regions in flight. Within a region
+// the cleaners run one after the other in the order they were given, so that resources depending
+// on each other are torn down in order. Global results come first, then each region's results.
+func runCleaners(reqLogger logr.Logger, globalClient awsclient.Client, clientForRegion func(region string) (awsclient.Client, error), regions []string, cleaners []Cleaner, maxConcurrentRegions int, dryRun bool) []awsv1alpha1.CleanupResult {
+	globalCleaners, regionalCleaners := []Cleaner{}, []Cleaner{}
+	for _, cleaner := range cleaners {
+		if cleaner.Global() {
+			globalCleaners = append(globalCleaners, cleaner)
+		} else {
+			regionalCleaners = append(regionalCleaners, cleaner)
+		}
+	}
+
+	results := make([]awsv1alpha1.CleanupResult, len(globalCleaners)+len(regions)*len(regionalCleaners))
+
+	var wg sync.WaitGroup
+	for i, cleaner := range globalCleaners {
+		wg.Add(1)
+		go func(i int, cleaner Cleaner) {
+			defer wg.Done()
+			results[i] = runCleaner(reqLogger, globalClient, cleaner, "", dryRun)
+		}(i, cleaner)
+	}
+
+	if maxConcurrentRegions < 1 {
+		maxConcurrentRegions = 1
+	}
+	regionSlots := make(chan struct{}, maxConcurrentRegions)
+	for i, region := range regions {
+		wg.Add(1)
+		go func(offset int, region string) {
+			defer wg.Done()
+			regionSlots <- struct{}{}
+			defer func() { <-regionSlots }()
+
+			regionLogger := reqLogger.WithValues("Region", region)
+			awsClient, err := clientForRegion(region)
+			for j, cleaner := range regionalCleaners {
+				if err != nil {
+					results[offset+j] = awsv1alpha1.CleanupResult{
+						Cleaner: cleaner.Name(),
+						Region:  region,
+						Error:   fmt.Sprintf("failed creating AWS client: %s", err),
+					}
+					continue
+				}
+				results[offset+j] = runCleaner(regionLogger, awsClient, cleaner, region, dryRun)
+			}
+		}(len(globalCleaners)+i*len(regionalCleaners), region)
+	}
+	wg.Wait()
+
+	return results
+}
+
+// runCleaner runs a single cleaner and labels its result. Pending resources are not an error,
+// they are counted in the result.
+func runCleaner(reqLogger logr.Logger, awsClient awsclient.Client, cleaner Cleaner, region string, dryRun bool) awsv1alpha1.CleanupResult {
+	result, err := cleaner.Clean(reqLogger.WithValues("Cleaner", cleaner.Name()), awsClient, dryRun)
+	result.Cleaner = cleaner.Name()
+	result.Region = region
+	if err != nil && !errors.Is(err, awsv1alpha1.ErrAccountReuseCleanupPending) {
+		result.Error = err.Error()
+	}
+	return result
+}
+
+// capReportedResources trims the resource names listed in the results so that at most
+// maxReportedResources are kept in total, in the order of the results
+func capReportedResources(results []awsv1alpha1.CleanupResult) []awsv1alpha1.CleanupResult {
+	capped := make([]awsv1alpha1.CleanupResult, len(results))
+	left := maxReportedResources
+	for i, result := range results {
+		if len(result.Resources) > left {
+			result.Resources = result.Resources[:left]
+		}
+		if len(result.Resources) == 0 {
+			result.Resources = nil
+		}
+		left -= len(result.Resources)
+		capped[i] = result
+	}
+	return capped
+}
+
+// isDependencyError returns true if AWS refused to delete a resource because something it
+// depends on is still being deleted
+func isDependencyError(err error) bool {
+	var aerr awserr.Error
+	if errors.As(err, &aerr) {
+		return dependencyErrorCodes[aerr.Code()]
+	}
+	return false
+}
+
+// cleanerError returns the error of a cleaner from the last deletion error, or
+// ErrAccountReuseCleanupPending when the only resources left are pending
+func cleanerError(result awsv1alpha1.CleanupResult, lastErr error) error {
+	if lastErr == nil && result.Pending > 0 {
+		return awsv1alpha1.ErrAccountReuseCleanupPending
+	}
+	return lastErr
+}
+
+// pendingCleanupResults returns the number of resources left for the next cleanup run
+func pendingCleanupResults(results []awsv1alpha1.CleanupResult) int {
+	pending := 0
+	for _, result := range results {
+		pending += result.Pending
+	}
+	return pending
+}
+
+// failedCleanupResults returns the results that carry an error
+func failedCleanupResults(results []awsv1alpha1.CleanupResult) []awsv1alpha1.CleanupResult {
+	failed := []awsv1alpha1.CleanupResult{}
+	for _, result := range results {
+		if result.Error != "" {
+			failed = append(failed, result)
+		}
+	}
+	return failed
+}
+
+// summarizeCleanupResults builds a one line, human readable summary of a cleanup run
+func summarizeCleanupResults(results []awsv1alpha1.CleanupResult, dryRun bool) string {
+	failed := failedCleanupResults(results)
+	if len(failed) == 0 {
+		found, deleted := 0, 0
+		for _, result := range results {
+			found += result.Found
+			deleted += result.Deleted
+		}
+		if dryRun {
+			return fmt.Sprintf("Reuse cleanup dry run found %d resources with %d cleaners, nothing was deleted", found, len(results))
+		}
+		if pending := pendingCleanupResults(results); pending > 0 {
+			return fmt.Sprintf("Reuse cleanup deleted %d of %d resources found by %d cleaners, %d wait on AWS to delete what they depend on", deleted, found, len(results), pending)
+		}
+		return fmt.Sprintf("Reuse cleanup deleted %d of %d resources found by %d cleaners", deleted, found, len(results))
+	}
+
+	failures := []string{}
+	for _, result := range failed {
+		name := result.Cleaner
+		if result.Region != "" {
+			name = fmt.Sprintf("%s (%s)", result.Cleaner, result.Region)
+		}
+		failures = append(failures, fmt.Sprintf("%s: %s", name, result.Error))
+	}
+	return fmt.Sprintf("%d of %d cleaners failed: %s", len(failed), len(results), strings.Join(failures, "; "))
+}
+
+// SnapshotCleaner deletes the EBS snapshots owned by the account
+type SnapshotCleaner struct{}
+
+// Name returns the name of the cleaner
+func (SnapshotCleaner) Name() string {
+	return "ebs-snapshots"
+}
+
+// Global returns false, EBS snapshots are regional
+func (SnapshotCleaner) Global() bool {
+	return false
+}
+
+// Clean deletes the EBS snapshots owned by the account
+func (SnapshotCleaner) Clean(reqLogger logr.Logger, awsClient awsclient.Client, dryRun bool) (awsv1alpha1.CleanupResult, error) {
+	result := awsv1alpha1.CleanupResult{}
+
+	// Filter only for snapshots owned by the account
+	selfOwnerFilter := ec2.Filter{
+		Name: aws.String("owner-alias"),
+		Values: []*string{
+			aws.String("self"),
+		},
+	}
+	describeSnapshotsInput := ec2.DescribeSnapshotsInput{
+		Filters: []*ec2.Filter{
+			&selfOwnerFilter,
+		},
+	}
+	ebsSnapshots, err := awsClient.DescribeSnapshots(&describeSnapshotsInput)
+	if err != nil {
+		return result, fmt.Errorf("failed describing EBS snapshots: %w", err)
+	}
+	result.Found = len(ebsSnapshots.Snapshots)
+
+	var lastErr error
+	for _, snapshot := range ebsSnapshots.Snapshots {
+		if dryRun {
+			result.Resources = append(result.Resources, *snapshot.SnapshotId)
+			continue
+		}
+
+		deleteSnapshotInput := ec2.DeleteSnapshotInput{
+			SnapshotId: aws.String(*snapshot.SnapshotId),
+		}
+
+		_, err = awsClient.DeleteSnapshot(&deleteSnapshotInput)
+		if err != nil {
+			lastErr = fmt.Errorf("failed deleting EBS snapshot: %s: %w", *snapshot.SnapshotId, err)
+			reqLogger.Error(err, lastErr.Error())
+			result.Failed++
+			continue
+		}
+		result.Deleted++
+	}
+
+	return result, lastErr
+}
+
+// EbsVolumeCleaner deletes the EBS volumes of the account
+type EbsVolumeCleaner struct{}
+
+// Name returns the name of the cleaner
+func (EbsVolumeCleaner) Name() string {
+	return "ebs-volumes"
+}
+
+// Global returns false, EBS volumes are regional
+func (EbsVolumeCleaner) Global() bool {
+	return false
+}
+
+// Clean deletes the EBS volumes of the account
+func (EbsVolumeCleaner) Clean(reqLogger logr.Logger, awsClient awsclient.Client, dryRun bool) (awsv1alpha1.CleanupResult, error) {
+	result := awsv1alpha1.CleanupResult{}
+
+	describeVolumesInput := ec2.DescribeVolumesInput{}
+	ebsVolumes, err := awsClient.DescribeVolumes(&describeVolumesInput)
+	if err != nil {
+		return result, fmt.Errorf("failed describing EBS volumes: %w", err)
+	}
+	result.Found = len(ebsVolumes.Volumes)
+
+	var lastErr error
+	for _, volume := range ebsVolumes.Volumes {
+		if dryRun {
+			result.Resources = append(result.Resources, *volume.VolumeId)
+			continue
+		}
+
+		deleteVolumeInput := ec2.DeleteVolumeInput{
+			VolumeId: aws.String(*volume.VolumeId),
+		}
+
+		_, err = awsClient.DeleteVolume(&deleteVolumeInput)
+		if isDependencyError(err) {
+			// Volumes are detached once their instances are terminated
+			result.Pending++
+			continue
+		}
+		if err != nil {
+			lastErr = fmt.Errorf("failed deleting EBS volume: %s: %w", *volume.VolumeId, err)
+			reqLogger.Error(err, lastErr.Error())
+			result.Failed++
+			continue
+		}
+		result.Deleted++
+	}
+
+	return result, cleanerError(result, lastErr)
+}
+
+// S3Cleaner empties and deletes the S3 buckets of the account
+type S3Cleaner struct{}
+
+// Name returns the name of the cleaner
+func (S3Cleaner) Name() string {
+	return "s3-buckets"
+}
+
+// Global returns true, buckets of every region are listed by a single ListBuckets call
+func (S3Cleaner) Global() bool {
+	return true
+}
+
+// Clean empties and deletes the S3 buckets of the account
+func (S3Cleaner) Clean(reqLogger logr.Logger, awsClient awsclient.Client, dryRun bool) (awsv1alpha1.CleanupResult, error) {
+	result := awsv1alpha1.CleanupResult{}
+
+	listBucketsInput := s3.ListBucketsInput{}
+	s3Buckets, err := awsClient.ListBuckets(&listBucketsInput)
+	if err != nil {
+		return result, fmt.Errorf("failed listing S3 buckets: %w", err)
+	}
+	result.Found = len(s3Buckets.Buckets)
+
+	var lastErr error
+	for _, bucket := range s3Buckets.Buckets {
+		if dryRun {
+			result.Resources = append(result.Resources, *bucket.Name)
+			continue
+		}
+
+		deleteBucketInput := s3.DeleteBucketInput{
+			Bucket: aws.String(*bucket.Name),
+		}
+
+		// delete any content if any
+		err := DeleteBucketContent(awsClient, *bucket.Name)
+		if err != nil && !isNoSuchBucketError(err) {
+			lastErr = fmt.Errorf("failed to delete bucket content: %s: %w", *bucket.Name, err)
+			reqLogger.Error(err, lastErr.Error())
+			result.Failed++
+			continue
+		}
+		_, err = awsClient.DeleteBucket(&deleteBucketInput)
+		if err != nil && !isNoSuchBucketError(err) {
+			lastErr = fmt.Errorf("failed deleting S3 bucket: %s: %w", *bucket.Name, err)
+			reqLogger.Error(err, lastErr.Error())
+			result.Failed++
+			continue
+		}
+		result.Deleted++
+	}
+
+	return result, lastErr
+}
+
+// isNoSuchBucketError returns true if the bucket is already gone, which S3 cleanup ignores
+func isNoSuchBucketError(err error) bool {
+	if aerr, ok := err.(awserr.Error); ok {
+		return aerr.Code() == s3.ErrCodeNoSuchBucket
+	}
+	return false
+}
+
+// VpcEndpointServiceCleaner deletes the VPC endpoint service configurations of the account
+type VpcEndpointServiceCleaner struct{}
+
+// Name returns the name of the cleaner
+func (VpcEndpointServiceCleaner) Name() string {
+	return "vpc-endpoint-services"
+}
+
+// Global returns false, VPC endpoint services are regional
+func (VpcEndpointServiceCleaner) Global() bool {
+	return false
+}
+
+// Clean deletes the VPC endpoint service configurations of the account
+func (VpcEndpointServiceCleaner) Clean(reqLogger logr.Logger, awsClient awsclient.Client, dryRun bool) (awsv1alpha1.CleanupResult, error) {
+	result := awsv1alpha1.CleanupResult{}
+
+	describeVpcEndpointServiceConfigurationsInput := ec2.DescribeVpcEndpointServiceConfigurationsInput{}
+	vpcEndpointServiceConfigurations, err := awsClient.DescribeVpcEndpointServiceConfigurations(&describeVpcEndpointServiceConfigurationsInput)
+	if vpcEndpointServiceConfigurations == nil || err != nil {
+		if err == nil {
+			err = awsv1alpha1.ErrUnexpectedValue
+		}
+		return result, fmt.Errorf("failed describing VPC endpoint service configurations: %w", err)
+	}
+
+	serviceIds := []*string{}
+
+	for _, config := range vpcEndpointServiceConfigurations.ServiceConfigurations {
+		serviceIds = append(serviceIds, config.ServiceId)
+	}
+	result.Found = len(serviceIds)
+
+	if dryRun {
+		result.Resources = aws.StringValueSlice(serviceIds)
+		return result, nil
+	}
+	if len(serviceIds) == 0 {
+		return result, nil
+	}
+
+	deleteVpcEndpointServiceConfigurationsInput := ec2.DeleteVpcEndpointServiceConfigurationsInput{
+		ServiceIds: serviceIds,
+	}
+
+	output, err := awsClient.DeleteVpcEndpointServiceConfigurations(&deleteVpcEndpointServiceConfigurationsInput)
+	if err != nil {
+		unsuccessful := []string{}
+		if output != nil {
+			for _, unsuccessfulEndpoint := range output.Unsuccessful {
+				unsuccessful = append(unsuccessful, *unsuccessfulEndpoint.ResourceId)
+			}
+		}
+		result.Failed = len(unsuccessful)
+		result.Deleted = result.Found - result.Failed
+		return result, fmt.Errorf("failed deleting VPC endpoint service configurations: %s", strings.Join(unsuccessful, ", "))
+	}
+	result.Deleted = result.Found
+
+	return result, nil
+}
+
+// Route53Cleaner deletes the Route53 hosted zones of the account, along with their record sets
+type Route53Cleaner struct{}
+
+// Name returns the name of the cleaner
+func (Route53Cleaner) Name() string {
+	return "route53-hosted-zones"
+}
+
+// Global returns true, Route53 is a global service
+func (Route53Cleaner) Global() bool {
+	return true
+}
+
+// Clean deletes the Route53 hosted zones of the account, along with their record sets
+func (Route53Cleaner) Clean(reqLogger logr.Logger, awsClient awsclient.Client, dryRun bool) (awsv1alpha1.CleanupResult, error) {
+	result := awsv1alpha1.CleanupResult{}
+
+	var nextZoneMarker *string
+	var lastErr error
+
+	// Paginate through hosted zones
+	for {
+		// Get list of hosted zones by page
+		hostedZonesOutput, err := awsClient.ListHostedZones(&route53.ListHostedZonesInput{Marker: nextZoneMarker})
+		if err != nil {
+			return result, fmt.Errorf("failed to list Hosted Zones: %w", err)
+		}
+		result.Found += len(hostedZonesOutput.HostedZones)
+
+		for _, zone := range hostedZonesOutput.HostedZones {
+			if dryRun {
+				result.Resources = append(result.Resources, *zone.Name)
+				continue
+			}
+			err := deleteHostedZone(awsClient, zone)
+			if err != nil {
+				lastErr = err
+				reqLogger.Error(err, err.Error())
+				result.Failed++
+				continue
+			}
+			result.Deleted++
+		}
+
+		if *hostedZonesOutput.IsTruncated {
+			nextZoneMarker = hostedZonesOutput.NextMarker
+		} else {
+			break
+		}
+	}
+
+	return result, lastErr
+}
+
+// deleteHostedZone deletes all non NS/SOA record sets of a hosted zone and then the zone itself
+func deleteHostedZone(awsClient awsclient.Client, zone *route53.HostedZone) error {
+	// List and delete all Record Sets for the current zone
+	var nextRecordName *string
+	// Pagination again!!!!!
+	for {
+		recordSet, listRecordsError := awsClient.ListResourceRecordSets(&route53.ListResourceRecordSetsInput{HostedZoneId: zone.Id, StartRecordName: nextRecordName})
+		if listRecordsError != nil {
+			return fmt.Errorf("failed to list Record sets for hosted zone %s: %w", *zone.Name, listRecordsError)
+		}
+
+		changeBatch := &route53.ChangeBatch{}
+		for _, record := range recordSet.ResourceRecordSets {
+			// Build ChangeBatch
+			// https://docs.aws.amazon.com/sdk-for-go/api/service/route53/#ChangeBatch
+			//https://docs.aws.amazon.com/sdk-for-go/api/service/route53/#Change
+			if *record.Type != "NS" && *record.Type != "SOA" {
+				changeBatch.Changes = append(changeBatch.Changes, &route53.Change{
+					Action:            aws.String("DELETE"),
+					ResourceRecordSet: record,
+				})
+			}
+		}
+
+		if changeBatch.Changes != nil {
+			_, changeErr := awsClient.ChangeResourceRecordSets(&route53.ChangeResourceRecordSetsInput{HostedZoneId: zone.Id, ChangeBatch: changeBatch})
+			if changeErr != nil {
+				return fmt.Errorf("failed to delete record sets for hosted zone %s: %w", *zone.Name, changeErr)
+			}
+		}
+
+		if *recordSet.IsTruncated {
+			nextRecordName = recordSet.NextRecordName
+		} else {
+			break
+		}
+	}
+
+	_, deleteError := awsClient.DeleteHostedZone(&route53.DeleteHostedZoneInput{Id: zone.Id})
+	if deleteError != nil {
+		return fmt.Errorf("failed to delete hosted zone: %s: %w", *zone.Name, deleteError)
+	}
+	return nil
+}
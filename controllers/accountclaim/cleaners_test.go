@@ -0,0 +1,130 @@
+package accountclaim
+
+import (
+	"fmt"
+
+	awsv1alpha1 "github.com/ravitri/aws-account-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Cleaners", func() {
+	var (
+		configMap    *corev1.ConfigMap
+		accountClaim *awsv1alpha1.AccountClaim
+	)
+
+	BeforeEach(func() {
+		configMap = &corev1.ConfigMap{Data: map[string]string{}}
+		accountClaim = &awsv1alpha1.AccountClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "claim",
+				Namespace:   "claim-ns",
+				Annotations: map[string]string{},
+			},
+		}
+	})
+
+	Context("Testing cleanerEnabled", func() {
+		It("enables cleaners by default", func() {
+			Expect(cleanerEnabled(configMap, "s3-buckets")).To(BeTrue())
+		})
+
+		It("disables a cleaner switched off in the configmap", func() {
+			configMap.Data["cleanup.s3-buckets.enabled"] = "false"
+			Expect(cleanerEnabled(configMap, "s3-buckets")).To(BeFalse())
+			Expect(cleanerEnabled(configMap, "ebs-volumes")).To(BeTrue())
+		})
+
+		It("keeps a cleaner enabled when the flag can't be parsed", func() {
+			configMap.Data["cleanup.s3-buckets.enabled"] = "nope"
+			Expect(cleanerEnabled(configMap, "s3-buckets")).To(BeTrue())
+		})
+	})
+
+	Context("Testing getMaxConcurrentRegions", func() {
+		It("falls back to the default", func() {
+			Expect(getMaxConcurrentRegions(configMap)).To(Equal(defaultMaxConcurrentRegions))
+			configMap.Data[maxConcurrentRegionsConfigMapKey] = "0"
+			Expect(getMaxConcurrentRegions(configMap)).To(Equal(defaultMaxConcurrentRegions))
+		})
+
+		It("reads the configmap", func() {
+			configMap.Data[maxConcurrentRegionsConfigMapKey] = "2"
+			Expect(getMaxConcurrentRegions(configMap)).To(Equal(2))
+		})
+	})
+
+	Context("Testing cleanupDryRun", func() {
+		It("is off by default", func() {
+			Expect(cleanupDryRun(accountClaim, configMap)).To(BeFalse())
+		})
+
+		It("follows the configmap", func() {
+			configMap.Data[cleanupDryRunConfigMapKey] = "true"
+			Expect(cleanupDryRun(accountClaim, configMap)).To(BeTrue())
+		})
+
+		It("lets the annotation override the configmap", func() {
+			configMap.Data[cleanupDryRunConfigMapKey] = "true"
+			accountClaim.Annotations[cleanupDryRunAnnotation] = "false"
+			Expect(cleanupDryRun(accountClaim, configMap)).To(BeFalse())
+
+			configMap.Data[cleanupDryRunConfigMapKey] = "false"
+			accountClaim.Annotations[cleanupDryRunAnnotation] = "true"
+			Expect(cleanupDryRun(accountClaim, configMap)).To(BeTrue())
+		})
+	})
+
+	Context("Testing capReportedResources", func() {
+		It("keeps at most maxReportedResources names across the results", func() {
+			names := make([]string, maxReportedResources-1)
+			for i := range names {
+				names[i] = fmt.Sprintf("vol-%d", i)
+			}
+			results := []awsv1alpha1.CleanupResult{
+				{Cleaner: "ebs-volumes", Found: len(names), Resources: names},
+				{Cleaner: "ebs-snapshots", Found: 3, Resources: []string{"snap-1", "snap-2", "snap-3"}},
+				{Cleaner: "s3-buckets", Found: 1, Resources: []string{"bucket"}},
+			}
+
+			capped := capReportedResources(results)
+			Expect(capped[0].Resources).To(Equal(names))
+			Expect(capped[1].Resources).To(Equal([]string{"snap-1"}))
+			Expect(capped[1].Found).To(Equal(3))
+			Expect(capped[2].Resources).To(BeNil())
+			// The results given are left untouched
+			Expect(results[1].Resources).To(HaveLen(3))
+		})
+	})
+
+	Context("Testing summarizeCleanupResults", func() {
+		It("names the failed cleaners and their region", func() {
+			results := []awsv1alpha1.CleanupResult{
+				{Cleaner: "s3-buckets", Found: 1, Deleted: 1},
+				{Cleaner: "ebs-volumes", Region: "us-east-1", Error: "boom"},
+			}
+			Expect(summarizeCleanupResults(results, false)).To(Equal("1 of 2 cleaners failed: ebs-volumes (us-east-1): boom"))
+		})
+
+		It("counts what was deleted", func() {
+			results := []awsv1alpha1.CleanupResult{
+				{Cleaner: "s3-buckets", Found: 2, Deleted: 2},
+				{Cleaner: "ebs-volumes", Region: "us-east-1", Found: 1, Deleted: 1},
+			}
+			Expect(summarizeCleanupResults(results, false)).To(Equal("Reuse cleanup deleted 3 of 3 resources found by 2 cleaners"))
+			Expect(summarizeCleanupResults(results, true)).To(Equal("Reuse cleanup dry run found 3 resources with 2 cleaners, nothing was deleted"))
+		})
+
+		It("counts what waits on asynchronous deletions", func() {
+			results := []awsv1alpha1.CleanupResult{
+				{Cleaner: "nat-gateways", Region: "us-east-1", Found: 1, Pending: 1},
+				{Cleaner: "elastic-ips", Region: "us-east-1", Found: 2, Deleted: 1, Pending: 1},
+			}
+			Expect(summarizeCleanupResults(results, false)).To(Equal("Reuse cleanup deleted 1 of 3 resources found by 2 cleaners, 2 wait on AWS to delete what they depend on"))
+		})
+	})
+})
@@ -0,0 +1,694 @@
+package accountclaim
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/elb"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/go-logr/logr"
+
+	awsv1alpha1 "github.com/ravitri/aws-account-operator/api/v1alpha1"
+	"github.com/ravitri/aws-account-operator/pkg/awsclient"
+)
+
+// The cleaners in this file remove what a deleted cluster leaves behind in a region. They are
+// registered in dependency order: a resource is only deleted once whatever uses it is gone.
+// Instances, NAT gateways and interface endpoints are deleted asynchronously by AWS, so on the
+// first pass the addresses, network interfaces, security groups, subnets and VPCs they use can't
+// be deleted yet. Those are counted as pending rather than failed: the AccountClaim finalizer
+// stays in place, the account isn't failed, and the cleanup runs again after
+// cleanupPendingRequeueAfter until the region is empty.
+
+// Ec2InstanceCleaner terminates the EC2 instances of the account
+type Ec2InstanceCleaner struct{}
+
+// Name returns the name of the cleaner
+func (Ec2InstanceCleaner) Name() string {
+	return "ec2-instances"
+}
+
+// Global returns false, EC2 instances are regional
+func (Ec2InstanceCleaner) Global() bool {
+	return false
+}
+
+// Clean terminates the EC2 instances of the account. Instances already shutting down are pending
+// until they're terminated.
+func (Ec2InstanceCleaner) Clean(reqLogger logr.Logger, awsClient awsclient.Client, dryRun bool) (awsv1alpha1.CleanupResult, error) {
+	result := awsv1alpha1.CleanupResult{}
+
+	instanceIds := []*string{}
+	var nextToken *string
+	for {
+		instances, err := awsClient.DescribeInstances(&ec2.DescribeInstancesInput{
+			Filters: []*ec2.Filter{
+				{
+					Name:   aws.String("instance-state-name"),
+					Values: aws.StringSlice([]string{"pending", "running", "shutting-down", "stopping", "stopped"}),
+				},
+			},
+			NextToken: nextToken,
+		})
+		if err != nil {
+			return result, fmt.Errorf("failed describing EC2 instances: %w", err)
+		}
+
+		for _, reservation := range instances.Reservations {
+			for _, instance := range reservation.Instances {
+				// Instances terminated by a previous run hold on to their network until they're gone
+				if !dryRun && instance.State != nil && aws.StringValue(instance.State.Name) == ec2.InstanceStateNameShuttingDown {
+					result.Found++
+					result.Pending++
+					continue
+				}
+				instanceIds = append(instanceIds, instance.InstanceId)
+			}
+		}
+
+		if instances.NextToken == nil {
+			break
+		}
+		nextToken = instances.NextToken
+	}
+	result.Found += len(instanceIds)
+
+	if dryRun {
+		result.Resources = aws.StringValueSlice(instanceIds)
+		return result, nil
+	}
+	if len(instanceIds) == 0 {
+		return result, cleanerError(result, nil)
+	}
+
+	_, err := awsClient.TerminateInstances(&ec2.TerminateInstancesInput{
+		InstanceIds: instanceIds,
+	})
+	if err != nil {
+		result.Failed = len(instanceIds)
+		return result, fmt.Errorf("failed terminating EC2 instances: %w", err)
+	}
+	result.Deleted = len(instanceIds)
+
+	return result, cleanerError(result, nil)
+}
+
+// ClassicLoadBalancerCleaner deletes the classic load balancers of the account
+type ClassicLoadBalancerCleaner struct{}
+
+// Name returns the name of the cleaner
+func (ClassicLoadBalancerCleaner) Name() string {
+	return "classic-load-balancers"
+}
+
+// Global returns false, load balancers are regional
+func (ClassicLoadBalancerCleaner) Global() bool {
+	return false
+}
+
+// Clean deletes the classic load balancers of the account
+func (ClassicLoadBalancerCleaner) Clean(reqLogger logr.Logger, awsClient awsclient.Client, dryRun bool) (awsv1alpha1.CleanupResult, error) {
+	result := awsv1alpha1.CleanupResult{}
+
+	var marker *string
+	var lastErr error
+	for {
+		loadBalancers, err := awsClient.DescribeLoadBalancers(&elb.DescribeLoadBalancersInput{Marker: marker})
+		if err != nil {
+			return result, fmt.Errorf("failed describing classic load balancers: %w", err)
+		}
+		result.Found += len(loadBalancers.LoadBalancerDescriptions)
+
+		for _, loadBalancer := range loadBalancers.LoadBalancerDescriptions {
+			if dryRun {
+				result.Resources = append(result.Resources, *loadBalancer.LoadBalancerName)
+				continue
+			}
+			_, err := awsClient.DeleteLoadBalancer(&elb.DeleteLoadBalancerInput{
+				LoadBalancerName: loadBalancer.LoadBalancerName,
+			})
+			if err != nil {
+				lastErr = fmt.Errorf("failed deleting classic load balancer: %s: %w", *loadBalancer.LoadBalancerName, err)
+				reqLogger.Error(err, lastErr.Error())
+				result.Failed++
+				continue
+			}
+			result.Deleted++
+		}
+
+		if loadBalancers.NextMarker == nil {
+			break
+		}
+		marker = loadBalancers.NextMarker
+	}
+
+	return result, lastErr
+}
+
+// LoadBalancerCleaner deletes the application and network load balancers of the account
+type LoadBalancerCleaner struct{}
+
+// Name returns the name of the cleaner
+func (LoadBalancerCleaner) Name() string {
+	return "load-balancers"
+}
+
+// Global returns false, load balancers are regional
+func (LoadBalancerCleaner) Global() bool {
+	return false
+}
+
+// Clean deletes the application and network load balancers of the account
+func (LoadBalancerCleaner) Clean(reqLogger logr.Logger, awsClient awsclient.Client, dryRun bool) (awsv1alpha1.CleanupResult, error) {
+	result := awsv1alpha1.CleanupResult{}
+
+	var marker *string
+	var lastErr error
+	for {
+		loadBalancers, err := awsClient.DescribeLoadBalancersV2(&elbv2.DescribeLoadBalancersInput{Marker: marker})
+		if err != nil {
+			return result, fmt.Errorf("failed describing load balancers: %w", err)
+		}
+		result.Found += len(loadBalancers.LoadBalancers)
+
+		for _, loadBalancer := range loadBalancers.LoadBalancers {
+			if dryRun {
+				result.Resources = append(result.Resources, *loadBalancer.LoadBalancerName)
+				continue
+			}
+			_, err := awsClient.DeleteLoadBalancerV2(&elbv2.DeleteLoadBalancerInput{
+				LoadBalancerArn: loadBalancer.LoadBalancerArn,
+			})
+			if err != nil {
+				lastErr = fmt.Errorf("failed deleting load balancer: %s: %w", *loadBalancer.LoadBalancerName, err)
+				reqLogger.Error(err, lastErr.Error())
+				result.Failed++
+				continue
+			}
+			result.Deleted++
+		}
+
+		if loadBalancers.NextMarker == nil {
+			break
+		}
+		marker = loadBalancers.NextMarker
+	}
+
+	return result, lastErr
+}
+
+// NatGatewayCleaner deletes the NAT gateways of the account
+type NatGatewayCleaner struct{}
+
+// Name returns the name of the cleaner
+func (NatGatewayCleaner) Name() string {
+	return "nat-gateways"
+}
+
+// Global returns false, NAT gateways are regional
+func (NatGatewayCleaner) Global() bool {
+	return false
+}
+
+// Clean deletes the NAT gateways of the account. Gateways already being deleted are pending,
+// as their Elastic IPs can only be released once they're gone.
+func (NatGatewayCleaner) Clean(reqLogger logr.Logger, awsClient awsclient.Client, dryRun bool) (awsv1alpha1.CleanupResult, error) {
+	result := awsv1alpha1.CleanupResult{}
+
+	var nextToken *string
+	var lastErr error
+	for {
+		natGateways, err := awsClient.DescribeNatGateways(&ec2.DescribeNatGatewaysInput{
+			Filter: []*ec2.Filter{
+				{
+					Name:   aws.String("state"),
+					Values: aws.StringSlice([]string{"pending", "available", "failed", "deleting"}),
+				},
+			},
+			NextToken: nextToken,
+		})
+		if err != nil {
+			return result, fmt.Errorf("failed describing NAT gateways: %w", err)
+		}
+		result.Found += len(natGateways.NatGateways)
+
+		for _, natGateway := range natGateways.NatGateways {
+			if dryRun {
+				result.Resources = append(result.Resources, *natGateway.NatGatewayId)
+				continue
+			}
+			if aws.StringValue(natGateway.State) == ec2.NatGatewayStateDeleting {
+				result.Pending++
+				continue
+			}
+			_, err := awsClient.DeleteNatGateway(&ec2.DeleteNatGatewayInput{
+				NatGatewayId: natGateway.NatGatewayId,
+			})
+			if err != nil {
+				lastErr = fmt.Errorf("failed deleting NAT gateway: %s: %w", *natGateway.NatGatewayId, err)
+				reqLogger.Error(err, lastErr.Error())
+				result.Failed++
+				continue
+			}
+			result.Deleted++
+		}
+
+		if natGateways.NextToken == nil {
+			break
+		}
+		nextToken = natGateways.NextToken
+	}
+
+	return result, cleanerError(result, lastErr)
+}
+
+// ElasticIPCleaner releases the Elastic IP addresses of the account
+type ElasticIPCleaner struct{}
+
+// Name returns the name of the cleaner
+func (ElasticIPCleaner) Name() string {
+	return "elastic-ips"
+}
+
+// Global returns false, Elastic IPs are regional
+func (ElasticIPCleaner) Global() bool {
+	return false
+}
+
+// Clean releases the Elastic IP addresses of the account. Addresses still associated with a NAT
+// gateway or an instance being deleted are pending until the association goes away.
+func (ElasticIPCleaner) Clean(reqLogger logr.Logger, awsClient awsclient.Client, dryRun bool) (awsv1alpha1.CleanupResult, error) {
+	result := awsv1alpha1.CleanupResult{}
+
+	addresses, err := awsClient.DescribeAddresses(&ec2.DescribeAddressesInput{})
+	if err != nil {
+		return result, fmt.Errorf("failed describing Elastic IPs: %w", err)
+	}
+	result.Found = len(addresses.Addresses)
+
+	var lastErr error
+	for _, address := range addresses.Addresses {
+		if dryRun {
+			result.Resources = append(result.Resources, aws.StringValue(address.PublicIp))
+			continue
+		}
+		if address.AssociationId != nil {
+			result.Pending++
+			continue
+		}
+		releaseAddressInput := &ec2.ReleaseAddressInput{}
+		if address.AllocationId != nil {
+			releaseAddressInput.AllocationId = address.AllocationId
+		} else {
+			// EC2-Classic addresses have no allocation ID
+			releaseAddressInput.PublicIp = address.PublicIp
+		}
+
+		_, err := awsClient.ReleaseAddress(releaseAddressInput)
+		if isDependencyError(err) {
+			result.Pending++
+			continue
+		}
+		if err != nil {
+			lastErr = fmt.Errorf("failed releasing Elastic IP: %s: %w", aws.StringValue(address.PublicIp), err)
+			reqLogger.Error(err, lastErr.Error())
+			result.Failed++
+			continue
+		}
+		result.Deleted++
+	}
+
+	return result, cleanerError(result, lastErr)
+}
+
+// SecurityGroupCleaner deletes the security groups of the account, except the default ones
+type SecurityGroupCleaner struct{}
+
+// Name returns the name of the cleaner
+func (SecurityGroupCleaner) Name() string {
+	return "security-groups"
+}
+
+// Global returns false, security groups are regional
+func (SecurityGroupCleaner) Global() bool {
+	return false
+}
+
+// Clean deletes the security groups of the account. Default security groups can't be deleted and
+// go away with their VPC. All rules are revoked first, as groups referencing each other can't be
+// deleted otherwise.
+func (SecurityGroupCleaner) Clean(reqLogger logr.Logger, awsClient awsclient.Client, dryRun bool) (awsv1alpha1.CleanupResult, error) {
+	result := awsv1alpha1.CleanupResult{}
+
+	securityGroups := []*ec2.SecurityGroup{}
+	var nextToken *string
+	for {
+		output, err := awsClient.DescribeSecurityGroups(&ec2.DescribeSecurityGroupsInput{NextToken: nextToken})
+		if err != nil {
+			return result, fmt.Errorf("failed describing security groups: %w", err)
+		}
+
+		for _, securityGroup := range output.SecurityGroups {
+			if aws.StringValue(securityGroup.GroupName) != "default" {
+				securityGroups = append(securityGroups, securityGroup)
+			}
+		}
+
+		if output.NextToken == nil {
+			break
+		}
+		nextToken = output.NextToken
+	}
+	result.Found = len(securityGroups)
+
+	if dryRun {
+		for _, securityGroup := range securityGroups {
+			result.Resources = append(result.Resources, *securityGroup.GroupId)
+		}
+		return result, nil
+	}
+
+	var lastErr error
+	for _, securityGroup := range securityGroups {
+		if len(securityGroup.IpPermissions) > 0 {
+			_, err := awsClient.RevokeSecurityGroupIngress(&ec2.RevokeSecurityGroupIngressInput{
+				GroupId:       securityGroup.GroupId,
+				IpPermissions: securityGroup.IpPermissions,
+			})
+			if err != nil {
+				lastErr = fmt.Errorf("failed revoking ingress rules of security group: %s: %w", *securityGroup.GroupId, err)
+				reqLogger.Error(err, lastErr.Error())
+			}
+		}
+		if len(securityGroup.IpPermissionsEgress) > 0 {
+			_, err := awsClient.RevokeSecurityGroupEgress(&ec2.RevokeSecurityGroupEgressInput{
+				GroupId:       securityGroup.GroupId,
+				IpPermissions: securityGroup.IpPermissionsEgress,
+			})
+			if err != nil {
+				lastErr = fmt.Errorf("failed revoking egress rules of security group: %s: %w", *securityGroup.GroupId, err)
+				reqLogger.Error(err, lastErr.Error())
+			}
+		}
+	}
+
+	for _, securityGroup := range securityGroups {
+		_, err := awsClient.DeleteSecurityGroup(&ec2.DeleteSecurityGroupInput{
+			GroupId: securityGroup.GroupId,
+		})
+		if isDependencyError(err) {
+			// Network interfaces being deleted still use the group
+			result.Pending++
+			continue
+		}
+		if err != nil {
+			lastErr = fmt.Errorf("failed deleting security group: %s: %w", *securityGroup.GroupId, err)
+			reqLogger.Error(err, lastErr.Error())
+			result.Failed++
+			continue
+		}
+		result.Deleted++
+	}
+
+	return result, cleanerError(result, lastErr)
+}
+
+// VpcCleaner deletes the non-default VPCs of the account, along with their internet gateways,
+// subnets and route tables
+type VpcCleaner struct{}
+
+// Name returns the name of the cleaner
+func (VpcCleaner) Name() string {
+	return "vpcs"
+}
+
+// Global returns false, VPCs are regional
+func (VpcCleaner) Global() bool {
+	return false
+}
+
+// Clean deletes the non-default VPCs of the account
+func (VpcCleaner) Clean(reqLogger logr.Logger, awsClient awsclient.Client, dryRun bool) (awsv1alpha1.CleanupResult, error) {
+	result := awsv1alpha1.CleanupResult{}
+
+	vpcs, err := awsClient.DescribeVpcs(&ec2.DescribeVpcsInput{
+		Filters: []*ec2.Filter{
+			{
+				Name:   aws.String("isDefault"),
+				Values: aws.StringSlice([]string{"false"}),
+			},
+		},
+	})
+	if err != nil {
+		return result, fmt.Errorf("failed describing VPCs: %w", err)
+	}
+	result.Found = len(vpcs.Vpcs)
+
+	var lastErr error
+	for _, vpc := range vpcs.Vpcs {
+		if dryRun {
+			result.Resources = append(result.Resources, *vpc.VpcId)
+			continue
+		}
+		err := deleteVpc(awsClient, *vpc.VpcId)
+		if isDependencyError(err) {
+			reqLogger.Info("VPC still in use, leaving it for the next cleanup run", "VpcId", *vpc.VpcId, "Error", err.Error())
+			result.Pending++
+			continue
+		}
+		if err != nil {
+			lastErr = err
+			reqLogger.Error(err, err.Error())
+			result.Failed++
+			continue
+		}
+		result.Deleted++
+	}
+
+	return result, cleanerError(result, lastErr)
+}
+
+// deleteVpc deletes the internet gateways, subnets and non-main route tables of a VPC and then the
+// VPC itself
+func deleteVpc(awsClient awsclient.Client, vpcID string) error {
+	vpcFilter := func(name string) []*ec2.Filter {
+		return []*ec2.Filter{
+			{
+				Name:   aws.String(name),
+				Values: aws.StringSlice([]string{vpcID}),
+			},
+		}
+	}
+
+	internetGateways, err := awsClient.DescribeInternetGateways(&ec2.DescribeInternetGatewaysInput{
+		Filters: vpcFilter("attachment.vpc-id"),
+	})
+	if err != nil {
+		return fmt.Errorf("failed describing internet gateways of VPC %s: %w", vpcID, err)
+	}
+	for _, internetGateway := range internetGateways.InternetGateways {
+		_, err := awsClient.DetachInternetGateway(&ec2.DetachInternetGatewayInput{
+			InternetGatewayId: internetGateway.InternetGatewayId,
+			VpcId:             aws.String(vpcID),
+		})
+		if err != nil {
+			return fmt.Errorf("failed detaching internet gateway %s from VPC %s: %w", *internetGateway.InternetGatewayId, vpcID, err)
+		}
+		_, err = awsClient.DeleteInternetGateway(&ec2.DeleteInternetGatewayInput{
+			InternetGatewayId: internetGateway.InternetGatewayId,
+		})
+		if err != nil {
+			return fmt.Errorf("failed deleting internet gateway %s of VPC %s: %w", *internetGateway.InternetGatewayId, vpcID, err)
+		}
+	}
+
+	subnets, err := awsClient.DescribeSubnets(&ec2.DescribeSubnetsInput{
+		Filters: vpcFilter("vpc-id"),
+	})
+	if err != nil {
+		return fmt.Errorf("failed describing subnets of VPC %s: %w", vpcID, err)
+	}
+	for _, subnet := range subnets.Subnets {
+		_, err := awsClient.DeleteSubnet(&ec2.DeleteSubnetInput{
+			SubnetId: subnet.SubnetId,
+		})
+		if err != nil {
+			return fmt.Errorf("failed deleting subnet %s of VPC %s: %w", *subnet.SubnetId, vpcID, err)
+		}
+	}
+
+	routeTables, err := awsClient.DescribeRouteTables(&ec2.DescribeRouteTablesInput{
+		Filters: vpcFilter("vpc-id"),
+	})
+	if err != nil {
+		return fmt.Errorf("failed describing route tables of VPC %s: %w", vpcID, err)
+	}
+	for _, routeTable := range routeTables.RouteTables {
+		// The main route table is deleted along with the VPC
+		if isMainRouteTable(routeTable) {
+			continue
+		}
+		_, err := awsClient.DeleteRouteTable(&ec2.DeleteRouteTableInput{
+			RouteTableId: routeTable.RouteTableId,
+		})
+		if err != nil {
+			return fmt.Errorf("failed deleting route table %s of VPC %s: %w", *routeTable.RouteTableId, vpcID, err)
+		}
+	}
+
+	_, err = awsClient.DeleteVpc(&ec2.DeleteVpcInput{
+		VpcId: aws.String(vpcID),
+	})
+	if err != nil {
+		return fmt.Errorf("failed deleting VPC %s: %w", vpcID, err)
+	}
+	return nil
+}
+
+// isMainRouteTable returns true if the route table is the main route table of its VPC
+func isMainRouteTable(routeTable *ec2.RouteTable) bool {
+	for _, association := range routeTable.Associations {
+		if aws.BoolValue(association.Main) {
+			return true
+		}
+	}
+	return false
+}
+
+// VpcEndpointCleaner deletes the VPC endpoints of the account
+type VpcEndpointCleaner struct{}
+
+// Name returns the name of the cleaner
+func (VpcEndpointCleaner) Name() string {
+	return "vpc-endpoints"
+}
+
+// Global returns false, VPC endpoints are regional
+func (VpcEndpointCleaner) Global() bool {
+	return false
+}
+
+// Clean deletes the VPC endpoints of the account. Interface endpoints are deleted asynchronously,
+// the ones already being deleted are pending as their network interfaces still use the subnets.
+func (VpcEndpointCleaner) Clean(reqLogger logr.Logger, awsClient awsclient.Client, dryRun bool) (awsv1alpha1.CleanupResult, error) {
+	result := awsv1alpha1.CleanupResult{}
+
+	endpointIds := []*string{}
+	var nextToken *string
+	for {
+		output, err := awsClient.DescribeVpcEndpoints(&ec2.DescribeVpcEndpointsInput{NextToken: nextToken})
+		if err != nil {
+			return result, fmt.Errorf("failed describing VPC endpoints: %w", err)
+		}
+
+		for _, endpoint := range output.VpcEndpoints {
+			switch strings.ToLower(aws.StringValue(endpoint.State)) {
+			case "deleted":
+				continue
+			case "deleting":
+				result.Found++
+				if !dryRun {
+					result.Pending++
+					continue
+				}
+			default:
+				result.Found++
+			}
+			endpointIds = append(endpointIds, endpoint.VpcEndpointId)
+		}
+
+		if output.NextToken == nil {
+			break
+		}
+		nextToken = output.NextToken
+	}
+
+	if dryRun {
+		result.Resources = aws.StringValueSlice(endpointIds)
+		return result, nil
+	}
+	if len(endpointIds) == 0 {
+		return result, cleanerError(result, nil)
+	}
+
+	output, err := awsClient.DeleteVpcEndpoints(&ec2.DeleteVpcEndpointsInput{VpcEndpointIds: endpointIds})
+	if err != nil {
+		result.Failed = len(endpointIds)
+		return result, fmt.Errorf("failed deleting VPC endpoints: %w", err)
+	}
+	unsuccessful := []string{}
+	for _, item := range output.Unsuccessful {
+		unsuccessful = append(unsuccessful, aws.StringValue(item.ResourceId))
+	}
+	result.Failed = len(unsuccessful)
+	result.Deleted = len(endpointIds) - result.Failed
+	if len(unsuccessful) > 0 {
+		return result, fmt.Errorf("failed deleting VPC endpoints: %s", strings.Join(unsuccessful, ", "))
+	}
+
+	return result, cleanerError(result, nil)
+}
+
+// NetworkInterfaceCleaner deletes the network interfaces left in the account
+type NetworkInterfaceCleaner struct{}
+
+// Name returns the name of the cleaner
+func (NetworkInterfaceCleaner) Name() string {
+	return "network-interfaces"
+}
+
+// Global returns false, network interfaces are regional
+func (NetworkInterfaceCleaner) Global() bool {
+	return false
+}
+
+// Clean deletes the detached network interfaces of the account, which would keep their subnet
+// and security groups from being deleted. Interfaces still attached belong to instances, load
+// balancers, NAT gateways or endpoints being deleted, and are pending until AWS detaches them.
+func (NetworkInterfaceCleaner) Clean(reqLogger logr.Logger, awsClient awsclient.Client, dryRun bool) (awsv1alpha1.CleanupResult, error) {
+	result := awsv1alpha1.CleanupResult{}
+
+	var nextToken *string
+	var lastErr error
+	for {
+		output, err := awsClient.DescribeNetworkInterfaces(&ec2.DescribeNetworkInterfacesInput{NextToken: nextToken})
+		if err != nil {
+			return result, fmt.Errorf("failed describing network interfaces: %w", err)
+		}
+		result.Found += len(output.NetworkInterfaces)
+
+		for _, networkInterface := range output.NetworkInterfaces {
+			if dryRun {
+				result.Resources = append(result.Resources, *networkInterface.NetworkInterfaceId)
+				continue
+			}
+			if aws.StringValue(networkInterface.Status) != ec2.NetworkInterfaceStatusAvailable {
+				result.Pending++
+				continue
+			}
+			_, err := awsClient.DeleteNetworkInterface(&ec2.DeleteNetworkInterfaceInput{
+				NetworkInterfaceId: networkInterface.NetworkInterfaceId,
+			})
+			if isDependencyError(err) {
+				result.Pending++
+				continue
+			}
+			if err != nil {
+				lastErr = fmt.Errorf("failed deleting network interface: %s: %w", *networkInterface.NetworkInterfaceId, err)
+				reqLogger.Error(err, lastErr.Error())
+				result.Failed++
+				continue
+			}
+			result.Deleted++
+		}
+
+		if output.NextToken == nil {
+			break
+		}
+		nextToken = output.NextToken
+	}
+
+	return result, cleanerError(result, lastErr)
+}
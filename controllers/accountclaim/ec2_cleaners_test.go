@@ -0,0 +1,319 @@
+package accountclaim
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/go-logr/logr"
+	"github.com/golang/mock/gomock"
+	awsv1alpha1 "github.com/ravitri/aws-account-operator/api/v1alpha1"
+	"github.com/ravitri/aws-account-operator/pkg/awsclient/mock"
+	"github.com/ravitri/aws-account-operator/pkg/testutils"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("EC2 cleaners", func() {
+	var (
+		nullLogger    logr.Logger
+		ctrl          *gomock.Controller
+		mockAWSClient *mock.MockClient
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		nullLogger = testutils.NewTestLogger().Logger()
+		mockAWSClient = mock.NewMockClient(ctrl)
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	Context("Ec2InstanceCleaner", func() {
+		It("terminates every instance of every reservation", func() {
+			mockAWSClient.EXPECT().DescribeInstances(gomock.Any()).Return(&ec2.DescribeInstancesOutput{
+				Reservations: []*ec2.Reservation{
+					{Instances: []*ec2.Instance{{InstanceId: aws.String("i-1")}, {InstanceId: aws.String("i-2")}}},
+					{Instances: []*ec2.Instance{{InstanceId: aws.String("i-3")}}},
+				},
+			}, nil)
+			mockAWSClient.EXPECT().TerminateInstances(&ec2.TerminateInstancesInput{
+				InstanceIds: aws.StringSlice([]string{"i-1", "i-2", "i-3"}),
+			}).Return(&ec2.TerminateInstancesOutput{}, nil)
+
+			result, err := Ec2InstanceCleaner{}.Clean(nullLogger, mockAWSClient, false)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.Found).To(Equal(3))
+			Expect(result.Deleted).To(Equal(3))
+		})
+
+		It("counts every instance as failed when termination fails", func() {
+			mockAWSClient.EXPECT().DescribeInstances(gomock.Any()).Return(&ec2.DescribeInstancesOutput{
+				Reservations: []*ec2.Reservation{
+					{Instances: []*ec2.Instance{{InstanceId: aws.String("i-1")}}},
+				},
+			}, nil)
+			mockAWSClient.EXPECT().TerminateInstances(gomock.Any()).Return(nil, fmt.Errorf("OperationNotPermitted"))
+
+			result, err := Ec2InstanceCleaner{}.Clean(nullLogger, mockAWSClient, false)
+			Expect(err).To(HaveOccurred())
+			Expect(result.Failed).To(Equal(1))
+		})
+	})
+
+	Context("Pending resources", func() {
+		It("waits for instances that are shutting down", func() {
+			mockAWSClient.EXPECT().DescribeInstances(gomock.Any()).Return(&ec2.DescribeInstancesOutput{
+				Reservations: []*ec2.Reservation{
+					{Instances: []*ec2.Instance{{InstanceId: aws.String("i-1"), State: &ec2.InstanceState{Name: aws.String(ec2.InstanceStateNameShuttingDown)}}}},
+				},
+			}, nil)
+			mockAWSClient.EXPECT().TerminateInstances(gomock.Any()).Times(0)
+
+			result, err := Ec2InstanceCleaner{}.Clean(nullLogger, mockAWSClient, false)
+			Expect(err).To(MatchError(awsv1alpha1.ErrAccountReuseCleanupPending))
+			Expect(result.Found).To(Equal(1))
+			Expect(result.Pending).To(Equal(1))
+		})
+
+		It("leaves the addresses of NAT gateways being deleted for the next run", func() {
+			mockAWSClient.EXPECT().DescribeAddresses(gomock.Any()).Return(&ec2.DescribeAddressesOutput{
+				Addresses: []*ec2.Address{
+					{AllocationId: aws.String("eipalloc-1"), AssociationId: aws.String("eipassoc-1"), PublicIp: aws.String("192.0.2.1")},
+					{AllocationId: aws.String("eipalloc-2"), PublicIp: aws.String("192.0.2.2")},
+				},
+			}, nil)
+			mockAWSClient.EXPECT().ReleaseAddress(&ec2.ReleaseAddressInput{AllocationId: aws.String("eipalloc-2")}).Return(nil, awserr.New("InvalidIPAddress.InUse", "in use", nil))
+
+			result, err := ElasticIPCleaner{}.Clean(nullLogger, mockAWSClient, false)
+			Expect(err).To(MatchError(awsv1alpha1.ErrAccountReuseCleanupPending))
+			Expect(result.Pending).To(Equal(2))
+			Expect(result.Failed).To(Equal(0))
+		})
+
+		It("reports a VPC still in use as pending", func() {
+			mockAWSClient.EXPECT().DescribeVpcs(gomock.Any()).Return(&ec2.DescribeVpcsOutput{
+				Vpcs: []*ec2.Vpc{{VpcId: aws.String("vpc-1")}},
+			}, nil)
+			mockAWSClient.EXPECT().DescribeInternetGateways(gomock.Any()).Return(&ec2.DescribeInternetGatewaysOutput{}, nil)
+			mockAWSClient.EXPECT().DescribeSubnets(gomock.Any()).Return(&ec2.DescribeSubnetsOutput{
+				Subnets: []*ec2.Subnet{{SubnetId: aws.String("subnet-1")}},
+			}, nil)
+			mockAWSClient.EXPECT().DeleteSubnet(gomock.Any()).Return(nil, awserr.New("DependencyViolation", "in use", nil))
+
+			result, err := VpcCleaner{}.Clean(nullLogger, mockAWSClient, false)
+			Expect(err).To(MatchError(awsv1alpha1.ErrAccountReuseCleanupPending))
+			Expect(result.Pending).To(Equal(1))
+			Expect(result.Failed).To(Equal(0))
+		})
+
+		It("reports the failures along with the pending resources", func() {
+			mockAWSClient.EXPECT().DescribeSecurityGroups(gomock.Any()).Return(&ec2.DescribeSecurityGroupsOutput{
+				SecurityGroups: []*ec2.SecurityGroup{
+					{GroupId: aws.String("sg-1"), GroupName: aws.String("a")},
+					{GroupId: aws.String("sg-2"), GroupName: aws.String("b")},
+				},
+			}, nil)
+			mockAWSClient.EXPECT().DeleteSecurityGroup(&ec2.DeleteSecurityGroupInput{GroupId: aws.String("sg-1")}).Return(nil, awserr.New("DependencyViolation", "in use", nil))
+			mockAWSClient.EXPECT().DeleteSecurityGroup(&ec2.DeleteSecurityGroupInput{GroupId: aws.String("sg-2")}).Return(nil, awserr.New("UnauthorizedOperation", "denied", nil))
+
+			result, err := SecurityGroupCleaner{}.Clean(nullLogger, mockAWSClient, false)
+			Expect(err).To(MatchError(ContainSubstring("sg-2")))
+			Expect(result.Pending).To(Equal(1))
+			Expect(result.Failed).To(Equal(1))
+		})
+	})
+
+	Context("VpcEndpointCleaner", func() {
+		It("deletes the endpoints and waits for the ones being deleted", func() {
+			mockAWSClient.EXPECT().DescribeVpcEndpoints(gomock.Any()).Return(&ec2.DescribeVpcEndpointsOutput{
+				VpcEndpoints: []*ec2.VpcEndpoint{
+					{VpcEndpointId: aws.String("vpce-1"), State: aws.String("available")},
+					{VpcEndpointId: aws.String("vpce-2"), State: aws.String("deleting")},
+					{VpcEndpointId: aws.String("vpce-3"), State: aws.String("deleted")},
+				},
+			}, nil)
+			mockAWSClient.EXPECT().DeleteVpcEndpoints(&ec2.DeleteVpcEndpointsInput{
+				VpcEndpointIds: aws.StringSlice([]string{"vpce-1"}),
+			}).Return(&ec2.DeleteVpcEndpointsOutput{}, nil)
+
+			result, err := VpcEndpointCleaner{}.Clean(nullLogger, mockAWSClient, false)
+			Expect(err).To(MatchError(awsv1alpha1.ErrAccountReuseCleanupPending))
+			Expect(result.Found).To(Equal(2))
+			Expect(result.Deleted).To(Equal(1))
+			Expect(result.Pending).To(Equal(1))
+		})
+
+		It("reports the endpoints that couldn't be deleted", func() {
+			mockAWSClient.EXPECT().DescribeVpcEndpoints(gomock.Any()).Return(&ec2.DescribeVpcEndpointsOutput{
+				VpcEndpoints: []*ec2.VpcEndpoint{{VpcEndpointId: aws.String("vpce-1"), State: aws.String("available")}},
+			}, nil)
+			mockAWSClient.EXPECT().DeleteVpcEndpoints(gomock.Any()).Return(&ec2.DeleteVpcEndpointsOutput{
+				Unsuccessful: []*ec2.UnsuccessfulItem{{ResourceId: aws.String("vpce-1")}},
+			}, nil)
+
+			result, err := VpcEndpointCleaner{}.Clean(nullLogger, mockAWSClient, false)
+			Expect(err).To(MatchError(ContainSubstring("vpce-1")))
+			Expect(result.Failed).To(Equal(1))
+		})
+	})
+
+	Context("NetworkInterfaceCleaner", func() {
+		It("deletes detached interfaces and waits for attached ones", func() {
+			mockAWSClient.EXPECT().DescribeNetworkInterfaces(gomock.Any()).Return(&ec2.DescribeNetworkInterfacesOutput{
+				NetworkInterfaces: []*ec2.NetworkInterface{
+					{NetworkInterfaceId: aws.String("eni-1"), Status: aws.String(ec2.NetworkInterfaceStatusAvailable)},
+					{NetworkInterfaceId: aws.String("eni-2"), Status: aws.String(ec2.NetworkInterfaceStatusInUse)},
+				},
+			}, nil)
+			mockAWSClient.EXPECT().DeleteNetworkInterface(&ec2.DeleteNetworkInterfaceInput{
+				NetworkInterfaceId: aws.String("eni-1"),
+			}).Return(&ec2.DeleteNetworkInterfaceOutput{}, nil)
+
+			result, err := NetworkInterfaceCleaner{}.Clean(nullLogger, mockAWSClient, false)
+			Expect(err).To(MatchError(awsv1alpha1.ErrAccountReuseCleanupPending))
+			Expect(result.Found).To(Equal(2))
+			Expect(result.Deleted).To(Equal(1))
+			Expect(result.Pending).To(Equal(1))
+		})
+	})
+
+	Context("Dry runs", func() {
+		It("lists instances without terminating them", func() {
+			mockAWSClient.EXPECT().DescribeInstances(gomock.Any()).Return(&ec2.DescribeInstancesOutput{
+				Reservations: []*ec2.Reservation{
+					{Instances: []*ec2.Instance{{InstanceId: aws.String("i-1")}, {InstanceId: aws.String("i-2")}}},
+				},
+			}, nil)
+
+			result, err := Ec2InstanceCleaner{}.Clean(nullLogger, mockAWSClient, true)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.Found).To(Equal(2))
+			Expect(result.Deleted).To(Equal(0))
+			Expect(result.Resources).To(Equal([]string{"i-1", "i-2"}))
+		})
+
+		It("lists VPCs without touching their dependencies", func() {
+			mockAWSClient.EXPECT().DescribeVpcs(gomock.Any()).Return(&ec2.DescribeVpcsOutput{
+				Vpcs: []*ec2.Vpc{{VpcId: aws.String("vpc-1")}},
+			}, nil)
+
+			result, err := VpcCleaner{}.Clean(nullLogger, mockAWSClient, true)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.Found).To(Equal(1))
+			Expect(result.Resources).To(Equal([]string{"vpc-1"}))
+		})
+	})
+
+	Context("LoadBalancerCleaner", func() {
+		It("follows the pagination marker", func() {
+			mockAWSClient.EXPECT().DescribeLoadBalancersV2(&elbv2.DescribeLoadBalancersInput{}).Return(&elbv2.DescribeLoadBalancersOutput{
+				LoadBalancers: []*elbv2.LoadBalancer{{LoadBalancerArn: aws.String("arn-1"), LoadBalancerName: aws.String("lb-1")}},
+				NextMarker:    aws.String("next"),
+			}, nil)
+			mockAWSClient.EXPECT().DescribeLoadBalancersV2(&elbv2.DescribeLoadBalancersInput{Marker: aws.String("next")}).Return(&elbv2.DescribeLoadBalancersOutput{
+				LoadBalancers: []*elbv2.LoadBalancer{{LoadBalancerArn: aws.String("arn-2"), LoadBalancerName: aws.String("lb-2")}},
+			}, nil)
+			mockAWSClient.EXPECT().DeleteLoadBalancerV2(gomock.Any()).Return(&elbv2.DeleteLoadBalancerOutput{}, nil).Times(2)
+
+			result, err := LoadBalancerCleaner{}.Clean(nullLogger, mockAWSClient, false)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.Found).To(Equal(2))
+			Expect(result.Deleted).To(Equal(2))
+		})
+	})
+
+	Context("ElasticIPCleaner", func() {
+		It("keeps going when an address can't be released", func() {
+			mockAWSClient.EXPECT().DescribeAddresses(gomock.Any()).Return(&ec2.DescribeAddressesOutput{
+				Addresses: []*ec2.Address{
+					{AllocationId: aws.String("eipalloc-1"), PublicIp: aws.String("192.0.2.1")},
+					{AllocationId: aws.String("eipalloc-2"), PublicIp: aws.String("192.0.2.2")},
+				},
+			}, nil)
+			mockAWSClient.EXPECT().ReleaseAddress(&ec2.ReleaseAddressInput{AllocationId: aws.String("eipalloc-1")}).Return(nil, fmt.Errorf("AuthFailure"))
+			mockAWSClient.EXPECT().ReleaseAddress(&ec2.ReleaseAddressInput{AllocationId: aws.String("eipalloc-2")}).Return(&ec2.ReleaseAddressOutput{}, nil)
+
+			result, err := ElasticIPCleaner{}.Clean(nullLogger, mockAWSClient, false)
+			Expect(err).To(MatchError(ContainSubstring("192.0.2.1")))
+			Expect(result.Found).To(Equal(2))
+			Expect(result.Deleted).To(Equal(1))
+			Expect(result.Failed).To(Equal(1))
+		})
+	})
+
+	Context("SecurityGroupCleaner", func() {
+		It("skips default groups and revokes rules before deleting", func() {
+			permissions := []*ec2.IpPermission{{IpProtocol: aws.String("-1")}}
+			mockAWSClient.EXPECT().DescribeSecurityGroups(gomock.Any()).Return(&ec2.DescribeSecurityGroupsOutput{
+				SecurityGroups: []*ec2.SecurityGroup{
+					{GroupId: aws.String("sg-default"), GroupName: aws.String("default")},
+					{GroupId: aws.String("sg-1"), GroupName: aws.String("cluster-master"), IpPermissions: permissions, IpPermissionsEgress: permissions},
+				},
+			}, nil)
+			gomock.InOrder(
+				mockAWSClient.EXPECT().RevokeSecurityGroupIngress(&ec2.RevokeSecurityGroupIngressInput{GroupId: aws.String("sg-1"), IpPermissions: permissions}).Return(&ec2.RevokeSecurityGroupIngressOutput{}, nil),
+				mockAWSClient.EXPECT().RevokeSecurityGroupEgress(&ec2.RevokeSecurityGroupEgressInput{GroupId: aws.String("sg-1"), IpPermissions: permissions}).Return(&ec2.RevokeSecurityGroupEgressOutput{}, nil),
+				mockAWSClient.EXPECT().DeleteSecurityGroup(&ec2.DeleteSecurityGroupInput{GroupId: aws.String("sg-1")}).Return(&ec2.DeleteSecurityGroupOutput{}, nil),
+			)
+
+			result, err := SecurityGroupCleaner{}.Clean(nullLogger, mockAWSClient, false)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.Found).To(Equal(1))
+			Expect(result.Deleted).To(Equal(1))
+		})
+	})
+
+	Context("VpcCleaner", func() {
+		It("deletes the VPC after its gateways, subnets and route tables", func() {
+			mockAWSClient.EXPECT().DescribeVpcs(gomock.Any()).Return(&ec2.DescribeVpcsOutput{
+				Vpcs: []*ec2.Vpc{{VpcId: aws.String("vpc-1")}},
+			}, nil)
+			gomock.InOrder(
+				mockAWSClient.EXPECT().DescribeInternetGateways(gomock.Any()).Return(&ec2.DescribeInternetGatewaysOutput{
+					InternetGateways: []*ec2.InternetGateway{{InternetGatewayId: aws.String("igw-1")}},
+				}, nil),
+				mockAWSClient.EXPECT().DetachInternetGateway(gomock.Any()).Return(&ec2.DetachInternetGatewayOutput{}, nil),
+				mockAWSClient.EXPECT().DeleteInternetGateway(gomock.Any()).Return(&ec2.DeleteInternetGatewayOutput{}, nil),
+				mockAWSClient.EXPECT().DescribeSubnets(gomock.Any()).Return(&ec2.DescribeSubnetsOutput{
+					Subnets: []*ec2.Subnet{{SubnetId: aws.String("subnet-1")}},
+				}, nil),
+				mockAWSClient.EXPECT().DeleteSubnet(gomock.Any()).Return(&ec2.DeleteSubnetOutput{}, nil),
+				mockAWSClient.EXPECT().DescribeRouteTables(gomock.Any()).Return(&ec2.DescribeRouteTablesOutput{
+					RouteTables: []*ec2.RouteTable{
+						{RouteTableId: aws.String("rtb-main"), Associations: []*ec2.RouteTableAssociation{{Main: aws.Bool(true)}}},
+						{RouteTableId: aws.String("rtb-1")},
+					},
+				}, nil),
+				mockAWSClient.EXPECT().DeleteRouteTable(&ec2.DeleteRouteTableInput{RouteTableId: aws.String("rtb-1")}).Return(&ec2.DeleteRouteTableOutput{}, nil),
+				mockAWSClient.EXPECT().DeleteVpc(&ec2.DeleteVpcInput{VpcId: aws.String("vpc-1")}).Return(&ec2.DeleteVpcOutput{}, nil),
+			)
+
+			result, err := VpcCleaner{}.Clean(nullLogger, mockAWSClient, false)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.Found).To(Equal(1))
+			Expect(result.Deleted).To(Equal(1))
+		})
+
+		It("reports the VPC as failed when a dependency can't be deleted", func() {
+			mockAWSClient.EXPECT().DescribeVpcs(gomock.Any()).Return(&ec2.DescribeVpcsOutput{
+				Vpcs: []*ec2.Vpc{{VpcId: aws.String("vpc-1")}},
+			}, nil)
+			mockAWSClient.EXPECT().DescribeInternetGateways(gomock.Any()).Return(&ec2.DescribeInternetGatewaysOutput{}, nil)
+			mockAWSClient.EXPECT().DescribeSubnets(gomock.Any()).Return(&ec2.DescribeSubnetsOutput{
+				Subnets: []*ec2.Subnet{{SubnetId: aws.String("subnet-1")}},
+			}, nil)
+			mockAWSClient.EXPECT().DeleteSubnet(gomock.Any()).Return(nil, fmt.Errorf("DependencyViolation"))
+
+			result, err := VpcCleaner{}.Clean(nullLogger, mockAWSClient, false)
+			Expect(err).To(MatchError(ContainSubstring("subnet-1")))
+			Expect(result.Failed).To(Equal(1))
+			Expect(result.Deleted).To(Equal(0))
+		})
+	})
+})
@@ -12,6 +12,7 @@ import (
 
 	awsv1alpha1 "github.com/ravitri/aws-account-operator/api/v1alpha1"
 	awsclient "github.com/ravitri/aws-account-operator/pkg/awsclient"
+	"github.com/ravitri/aws-account-operator/pkg/utils"
 )
 
 // MoveAccountToOU takes care of all the logic surrounding moving an account into an OU
@@ -35,6 +36,10 @@ func MoveAccountToOU(r *AccountClaimReconciler, reqLogger logr.Logger, awsClient
 		reqLogger.Error(err, invalidOUErrorMsg)
 		return err
 	}
+	// Accounts of a pool with its own OU go under that OU instead of the base one
+	if poolOU := r.getPoolOrganizationalUnit(reqLogger, account); poolOU != "" {
+		baseID = poolOU
+	}
 
 	// Create/Find account OU
 	ouName := accountClaim.Spec.LegalEntity.ID
@@ -71,6 +76,7 @@ func MoveAccountToOU(r *AccountClaimReconciler, reqLogger logr.Logger, awsClient
 	// Log account moved successfully
 	accountMovedMsg := fmt.Sprintf("OU: Account %s successfully moved to OU %s", account.Name, ouName)
 	reqLogger.Info(accountMovedMsg)
+	r.recorder.Event(accountClaim, corev1.EventTypeNormal, utils.EventReasonMovedToOU, accountMovedMsg)
 
 	// Update unclaimedAccount.Spec.AwsAccountOU
 	accountClaim.Spec.AccountOU = ouID
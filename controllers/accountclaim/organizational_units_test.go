@@ -13,6 +13,7 @@ import (
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -61,8 +62,9 @@ var _ = Describe("Organizational Unit", func() {
 		It("Should error when no ConfigMap can be found", func() {
 			localObjects := []runtime.Object{}
 			r = AccountClaimReconciler{
-				Scheme: scheme.Scheme,
-				Client: fake.NewClientBuilder().WithRuntimeObjects(localObjects...).Build(),
+				Scheme:   scheme.Scheme,
+				Client:   fake.NewClientBuilder().WithRuntimeObjects(localObjects...).Build(),
+				recorder: &record.FakeRecorder{},
 			}
 			err := MoveAccountToOU(&r, nullLogger, mockAWSClient, &accountClaim, &account)
 			Expect(err).To(HaveOccurred())
@@ -80,8 +82,9 @@ var _ = Describe("Organizational Unit", func() {
 			}
 			localObjects := []runtime.Object{&cm}
 			r = AccountClaimReconciler{
-				Scheme: scheme.Scheme,
-				Client: fake.NewClientBuilder().WithRuntimeObjects(localObjects...).Build(),
+				Scheme:   scheme.Scheme,
+				Client:   fake.NewClientBuilder().WithRuntimeObjects(localObjects...).Build(),
+				recorder: &record.FakeRecorder{},
 			}
 
 			err := MoveAccountToOU(&r, nullLogger, mockAWSClient, &accountClaim, &account)
@@ -102,8 +105,9 @@ var _ = Describe("Organizational Unit", func() {
 			}
 			localObjects := []runtime.Object{&cm}
 			r = AccountClaimReconciler{
-				Scheme: scheme.Scheme,
-				Client: fake.NewClientBuilder().WithRuntimeObjects(localObjects...).Build(),
+				Scheme:   scheme.Scheme,
+				Client:   fake.NewClientBuilder().WithRuntimeObjects(localObjects...).Build(),
+				recorder: &record.FakeRecorder{},
 			}
 
 			accountClaim.Spec = awsv1alpha1.AccountClaimSpec{
@@ -135,8 +139,9 @@ var _ = Describe("Organizational Unit", func() {
 
 			localObjects := []runtime.Object{&accountClaim, &cm}
 			r = AccountClaimReconciler{
-				Scheme: scheme.Scheme,
-				Client: fake.NewClientBuilder().WithRuntimeObjects(localObjects...).Build(),
+				Scheme:   scheme.Scheme,
+				Client:   fake.NewClientBuilder().WithRuntimeObjects(localObjects...).Build(),
+				recorder: &record.FakeRecorder{},
 			}
 
 			mockAWSClient.EXPECT().CreateOrganizationalUnit(gomock.Any()).Return(
@@ -186,8 +191,9 @@ var _ = Describe("Organizational Unit", func() {
 
 			localObjects := []runtime.Object{&accountClaim, &cm}
 			r = AccountClaimReconciler{
-				Scheme: scheme.Scheme,
-				Client: fake.NewClientBuilder().WithRuntimeObjects(localObjects...).Build(),
+				Scheme:   scheme.Scheme,
+				Client:   fake.NewClientBuilder().WithRuntimeObjects(localObjects...).Build(),
+				recorder: &record.FakeRecorder{},
 			}
 
 			mockAWSClient.EXPECT().CreateOrganizationalUnit(gomock.Any()).Return(
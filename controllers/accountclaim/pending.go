@@ -0,0 +1,134 @@
+package accountclaim
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+
+	awsv1alpha1 "github.com/ravitri/aws-account-operator/api/v1alpha1"
+	controllerutils "github.com/ravitri/aws-account-operator/pkg/utils"
+)
+
+const (
+	// pendingTimeoutConfigMapKey is how long a claim waits for an account before it fails,
+	// forever when unset or 0
+	pendingTimeoutConfigMapKey = "claims.pending-timeout"
+
+	// PendingPoolEmpty indicates the pools of the claim have no account to claim, nor accounts being created
+	PendingPoolEmpty = "PoolEmpty"
+	// PendingPoolAccountsCreating indicates the accounts the claim waits for are being created
+	PendingPoolAccountsCreating = "PoolAccountsCreating"
+	// PendingAccountLimitReached indicates no account can be created, the AWS account limit is reached
+	PendingAccountLimitReached = "AccountLimitReached"
+	// PendingLegalEntityQuotaReached indicates the legal entity of the claim holds all the accounts it can
+	PendingLegalEntityQuotaReached = "LegalEntityQuotaReached"
+	// PendingWaitingInQueue indicates the accounts the claim can get go to the claims ahead of it first
+	PendingWaitingInQueue = "WaitingInQueue"
+	// PendingTimeout indicates the claim failed after waiting for an account for too long
+	PendingTimeout = "PendingTimeout"
+)
+
+// getPendingTimeout reads how long a claim waits for an account from the operator configmap.
+// Malformed values are logged and ignored.
+func getPendingTimeout(reqLogger logr.Logger, configMap *corev1.ConfigMap) time.Duration {
+	value, ok := configMap.Data[pendingTimeoutConfigMapKey]
+	if !ok {
+		return 0
+	}
+	timeout, err := time.ParseDuration(strings.TrimSpace(value))
+	if err != nil || timeout < 0 {
+		reqLogger.Info("ignoring malformed claim pending timeout", "value", value)
+		return 0
+	}
+	return timeout
+}
+
+// accountLimitReached returns whether no account can be created because of the AWS account limit
+func (r *AccountClaimReconciler) accountLimitReached() bool {
+	if r.accountWatcher == nil || r.accountWatcher.GetLimit() <= 0 {
+		return false
+	}
+	return r.accountWatcher.GetAccountCount() >= r.accountWatcher.GetLimit()
+}
+
+// pendingReason returns why the claim at the position in the queue can't get one of the
+// available accounts of its pools yet
+func (r *AccountClaimReconciler) pendingReason(poolAccounts []awsv1alpha1.Account, position int, available int) (string, string) {
+	if available > 0 {
+		return PendingWaitingInQueue, fmt.Sprintf("%d claims are ahead of the claim for the %d accounts available", position-1, available)
+	}
+	creating := 0
+	for i := range poolAccounts {
+		account := &poolAccounts[i]
+		if account.HasNeverBeenClaimed() && !account.IsReady() && !account.IsFailed() && account.DeletionTimestamp == nil {
+			creating++
+		}
+	}
+	if creating > 0 {
+		return PendingPoolAccountsCreating, fmt.Sprintf("Waiting for one of the %d accounts being created", creating)
+	}
+	if r.accountLimitReached() {
+		return PendingAccountLimitReached, "No account can be created, the AWS account limit is reached"
+	}
+	return PendingPoolEmpty, "The pools of the claim have no account to claim"
+}
+
+// waitForAccount records why the claim waits for an account and its queue position, and returns
+// when to check it again. A claim still waiting past the pending timeout fails instead.
+func (r *AccountClaimReconciler) waitForAccount(reqLogger logr.Logger, accountClaim *awsv1alpha1.AccountClaim, timeout time.Duration, position int, reason string, message string, requeueAfter time.Duration) (bool, time.Duration, error) {
+	if timeout > 0 {
+		pendingFor := time.Since(accountClaim.CreationTimestamp.Time)
+		if pendingFor >= timeout {
+			return false, 0, r.failPendingClaim(reqLogger, accountClaim, timeout, message)
+		}
+		if remaining := timeout - pendingFor; remaining < requeueAfter {
+			requeueAfter = remaining
+		}
+	}
+
+	reqLogger.Info("waiting for an account", "reason", reason, "queuePosition", position)
+	condition := controllerutils.FindAccountClaimCondition(accountClaim.Status.Conditions, awsv1alpha1.AccountClaimPending)
+	if accountClaim.Status.QueuePosition == position && condition != nil &&
+		condition.Status == corev1.ConditionTrue && condition.Reason == reason && condition.Message == message {
+		return false, requeueAfter, nil
+	}
+	accountClaim.Status.QueuePosition = position
+	accountClaim.Status.Conditions = controllerutils.SetAccountClaimCondition(
+		accountClaim.Status.Conditions,
+		awsv1alpha1.AccountClaimPending,
+		corev1.ConditionTrue,
+		reason,
+		message,
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+		false,
+	)
+	return false, requeueAfter, r.statusUpdate(reqLogger, accountClaim)
+}
+
+// failPendingClaim moves a claim that waited for an account for longer than the timeout to Error
+func (r *AccountClaimReconciler) failPendingClaim(reqLogger logr.Logger, accountClaim *awsv1alpha1.AccountClaim, timeout time.Duration, message string) error {
+	message = fmt.Sprintf("No account was claimed within %s: %s", timeout, message)
+	reqLogger.Info(message)
+	accountClaim.Status.State = awsv1alpha1.ClaimStatusError
+	accountClaim.Status.QueuePosition = 0
+	accountClaim.Status.Conditions = controllerutils.SetAccountClaimCondition(
+		accountClaim.Status.Conditions,
+		awsv1alpha1.AccountClaimFailed,
+		corev1.ConditionTrue,
+		PendingTimeout,
+		message,
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+		false,
+	)
+	r.recorder.Event(accountClaim, corev1.EventTypeWarning, controllerutils.EventReasonClaimTimedOut, message)
+	return r.statusUpdate(reqLogger, accountClaim)
+}
+
+// claimTimedOut returns whether the claim failed waiting for an account
+func claimTimedOut(accountClaim *awsv1alpha1.AccountClaim) bool {
+	condition := controllerutils.FindAccountClaimCondition(accountClaim.Status.Conditions, awsv1alpha1.AccountClaimFailed)
+	return accountClaim.Status.State == awsv1alpha1.ClaimStatusError && condition != nil && condition.Reason == PendingTimeout
+}
@@ -0,0 +1,189 @@
+package accountclaim
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	apis "github.com/ravitri/aws-account-operator/api"
+	awsv1alpha1 "github.com/ravitri/aws-account-operator/api/v1alpha1"
+	"github.com/ravitri/aws-account-operator/pkg/localmetrics"
+	"github.com/ravitri/aws-account-operator/pkg/testutils"
+	controllerutils "github.com/ravitri/aws-account-operator/pkg/utils"
+)
+
+type mockAccountWatcher struct {
+	accounts int
+	limit    int
+}
+
+func (w *mockAccountWatcher) GetAccountCount() int {
+	return w.accounts
+}
+
+func (w *mockAccountWatcher) GetLimit() int {
+	return w.limit
+}
+
+var _ = Describe("Pending claims", func() {
+	var (
+		nullLogger = testutils.NewTestLogger().Logger()
+		now        = time.Now()
+	)
+
+	err := apis.AddToScheme(scheme.Scheme)
+	if err != nil {
+		fmt.Printf("failed adding apis to scheme in pending claim tests")
+	}
+	localmetrics.Collector = localmetrics.NewMetricsCollector(nil)
+
+	newAccount := func(name string, state awsv1alpha1.AccountConditionType) awsv1alpha1.Account {
+		return awsv1alpha1.Account{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: awsv1alpha1.AccountCrNamespace},
+			Status:     awsv1alpha1.AccountStatus{State: string(state)},
+		}
+	}
+
+	Context("Reading the configmap", func() {
+		It("parses the pending timeout, ignoring malformed values", func() {
+			Expect(getPendingTimeout(nullLogger, &corev1.ConfigMap{})).To(Equal(time.Duration(0)))
+			Expect(getPendingTimeout(nullLogger, &corev1.ConfigMap{Data: map[string]string{pendingTimeoutConfigMapKey: "2h"}})).To(Equal(2 * time.Hour))
+			Expect(getPendingTimeout(nullLogger, &corev1.ConfigMap{Data: map[string]string{pendingTimeoutConfigMapKey: "a day"}})).To(Equal(time.Duration(0)))
+		})
+	})
+
+	Context("Telling why a claim waits", func() {
+		r := &AccountClaimReconciler{accountWatcher: &mockAccountWatcher{accounts: 10, limit: 10}}
+
+		It("waits in the queue while accounts are available", func() {
+			reason, _ := r.pendingReason([]awsv1alpha1.Account{newAccount("ready", awsv1alpha1.AccountReady)}, 3, 1)
+			Expect(reason).To(Equal(PendingWaitingInQueue))
+		})
+
+		It("waits for the accounts being created", func() {
+			accounts := []awsv1alpha1.Account{newAccount("creating", awsv1alpha1.AccountCreating), newAccount("failed", awsv1alpha1.AccountFailed)}
+			reason, message := r.pendingReason(accounts, 1, 0)
+			Expect(reason).To(Equal(PendingPoolAccountsCreating))
+			Expect(message).To(ContainSubstring("1 accounts"))
+		})
+
+		It("reports the account limit when no account can be created", func() {
+			reason, _ := r.pendingReason([]awsv1alpha1.Account{newAccount("failed", awsv1alpha1.AccountFailed)}, 1, 0)
+			Expect(reason).To(Equal(PendingAccountLimitReached))
+		})
+
+		It("reports an empty pool otherwise", func() {
+			r := &AccountClaimReconciler{accountWatcher: &mockAccountWatcher{accounts: 1, limit: 10}}
+			reason, _ := r.pendingReason(nil, 1, 0)
+			Expect(reason).To(Equal(PendingPoolEmpty))
+		})
+	})
+
+	Context("Reconciling a claim without accounts", func() {
+		var (
+			r     *AccountClaimReconciler
+			claim *awsv1alpha1.AccountClaim
+			data  map[string]string
+		)
+		request := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "claims", Name: "claim"}}
+
+		BeforeEach(func() {
+			data = map[string]string{}
+			claim = &awsv1alpha1.AccountClaim{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "claim",
+					Namespace:         "claims",
+					CreationTimestamp: metav1.NewTime(now.Add(-time.Hour)),
+					Finalizers:        []string{accountClaimFinalizer},
+				},
+				Spec:   awsv1alpha1.AccountClaimSpec{LegalEntity: awsv1alpha1.LegalEntity{ID: "corp"}},
+				Status: awsv1alpha1.AccountClaimStatus{State: awsv1alpha1.ClaimStatusPending},
+			}
+		})
+
+		JustBeforeEach(func() {
+			configMap := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      awsv1alpha1.DefaultConfigMap,
+					Namespace: awsv1alpha1.AccountCrNamespace,
+				},
+				Data: data,
+			}
+			r = &AccountClaimReconciler{
+				Client:         fake.NewClientBuilder().WithScheme(scheme.Scheme).WithRuntimeObjects([]runtime.Object{claim, configMap}...).Build(),
+				Scheme:         scheme.Scheme,
+				accountWatcher: &mockAccountWatcher{accounts: 1, limit: 10},
+				recorder:       &record.FakeRecorder{},
+			}
+		})
+
+		getClaim := func() *awsv1alpha1.AccountClaim {
+			claim := &awsv1alpha1.AccountClaim{}
+			Expect(r.Client.Get(context.TODO(), request.NamespacedName, claim)).To(Succeed())
+			return claim
+		}
+
+		It("records why it is pending", func() {
+			result, err := r.Reconcile(context.TODO(), request)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(claimQueueRequeueInterval))
+
+			updated := getClaim()
+			Expect(updated.Status.State).To(Equal(awsv1alpha1.ClaimStatusPending))
+			Expect(updated.Status.QueuePosition).To(Equal(1))
+			condition := controllerutils.FindAccountClaimCondition(updated.Status.Conditions, awsv1alpha1.AccountClaimPending)
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Reason).To(Equal(PendingPoolEmpty))
+		})
+
+		Context("With a pending timeout not over yet", func() {
+			BeforeEach(func() {
+				data[pendingTimeoutConfigMapKey] = "1h5s"
+			})
+
+			It("checks it again by the timeout at the latest", func() {
+				result, err := r.Reconcile(context.TODO(), request)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.RequeueAfter).To(BeNumerically("<=", 5*time.Second))
+				Expect(getClaim().Status.State).To(Equal(awsv1alpha1.ClaimStatusPending))
+			})
+		})
+
+		Context("With a pending timeout over", func() {
+			BeforeEach(func() {
+				data[pendingTimeoutConfigMapKey] = "30m"
+			})
+
+			It("fails the claim", func() {
+				_, err := r.Reconcile(context.TODO(), request)
+				Expect(err).NotTo(HaveOccurred())
+
+				updated := getClaim()
+				Expect(updated.Status.State).To(Equal(awsv1alpha1.ClaimStatusError))
+				condition := controllerutils.FindAccountClaimCondition(updated.Status.Conditions, awsv1alpha1.AccountClaimFailed)
+				Expect(condition).NotTo(BeNil())
+				Expect(condition.Reason).To(Equal(PendingTimeout))
+				Expect(condition.Message).To(ContainSubstring("no account to claim"))
+
+				// An account created afterwards doesn't go to the failed claim
+				account := newAccount("ready", awsv1alpha1.AccountReady)
+				Expect(r.Client.Create(context.TODO(), &account)).To(Succeed())
+				result, err := r.Reconcile(context.TODO(), request)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result).To(Equal(reconcile.Result{}))
+				Expect(getClaim().Spec.AccountLink).To(BeEmpty())
+			})
+		})
+	})
+})
@@ -0,0 +1,76 @@
+package accountclaim
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	awsv1alpha1 "github.com/ravitri/aws-account-operator/api/v1alpha1"
+)
+
+// claimablePools returns the names of the AccountPools the claim can get an account from.
+// It returns nil when the claim doesn't select a pool, in which case any account will do.
+func (r *AccountClaimReconciler) claimablePools(accountClaim *awsv1alpha1.AccountClaim) (map[string]bool, error) {
+	if accountClaim.Spec.AccountPool != "" {
+		return map[string]bool{accountClaim.Spec.AccountPool: true}, nil
+	}
+	if accountClaim.Spec.AccountPoolSelector == nil {
+		return nil, nil
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(accountClaim.Spec.AccountPoolSelector)
+	if err != nil {
+		return nil, err
+	}
+	accountPoolList := &awsv1alpha1.AccountPoolList{}
+	listOpts := []client.ListOption{
+		client.InNamespace(awsv1alpha1.AccountCrNamespace),
+		client.MatchingLabelsSelector{Selector: selector},
+	}
+	if err := r.Client.List(context.TODO(), accountPoolList, listOpts...); err != nil {
+		return nil, err
+	}
+
+	pools := map[string]bool{}
+	for _, accountPool := range accountPoolList.Items {
+		pools[accountPool.Name] = true
+	}
+	return pools, nil
+}
+
+// filterAccountsByPool keeps the accounts that belong to one of the pools, or all of them when pools is nil
+func filterAccountsByPool(accounts []awsv1alpha1.Account, pools map[string]bool) []awsv1alpha1.Account {
+	if pools == nil {
+		return accounts
+	}
+	var filtered []awsv1alpha1.Account
+	for _, account := range accounts {
+		if pools[account.GetAccountPoolName()] {
+			filtered = append(filtered, account)
+		}
+	}
+	return filtered
+}
+
+// getPoolOrganizationalUnit returns the OU the pool of the account wants its claimed accounts in,
+// or an empty string when the account has no pool or the pool uses the configmap base OU
+func (r *AccountClaimReconciler) getPoolOrganizationalUnit(reqLogger logr.Logger, account *awsv1alpha1.Account) string {
+	poolName := account.GetAccountPoolName()
+	if poolName == "" {
+		return ""
+	}
+
+	accountPool := &awsv1alpha1.AccountPool{}
+	err := r.Client.Get(context.TODO(), types.NamespacedName{Namespace: account.Namespace, Name: poolName}, accountPool)
+	if err != nil {
+		if !k8serr.IsNotFound(err) {
+			reqLogger.Error(err, "failed getting accountpool, using the base OU", "accountpool", poolName)
+		}
+		return ""
+	}
+	return accountPool.Spec.AccountTemplate.OrganizationalUnit
+}
@@ -0,0 +1,101 @@
+package accountclaim
+
+import (
+	"fmt"
+
+	apis "github.com/ravitri/aws-account-operator/api"
+	awsv1alpha1 "github.com/ravitri/aws-account-operator/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("AccountPool selection", func() {
+	var (
+		r            *AccountClaimReconciler
+		accountClaim *awsv1alpha1.AccountClaim
+		accounts     []awsv1alpha1.Account
+	)
+
+	err := apis.AddToScheme(scheme.Scheme)
+	if err != nil {
+		fmt.Printf("failed adding apis to scheme in accountpool selection tests")
+	}
+
+	newPool := func(name string, labels map[string]string) *awsv1alpha1.AccountPool {
+		return &awsv1alpha1.AccountPool{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: awsv1alpha1.AccountCrNamespace,
+				Labels:    labels,
+			},
+		}
+	}
+	newAccount := func(name string, pool string) awsv1alpha1.Account {
+		return awsv1alpha1.Account{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: awsv1alpha1.AccountCrNamespace,
+				Labels:    map[string]string{awsv1alpha1.AccountPoolLabel: pool},
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		objs := []runtime.Object{
+			newPool("default", nil),
+			newPool("fedramp-east", map[string]string{"partition": "fedramp"}),
+			newPool("fedramp-west", map[string]string{"partition": "fedramp"}),
+		}
+		r = &AccountClaimReconciler{
+			Client: fake.NewClientBuilder().WithScheme(scheme.Scheme).WithRuntimeObjects(objs...).Build(),
+			Scheme: scheme.Scheme,
+		}
+		accountClaim = &awsv1alpha1.AccountClaim{}
+		accounts = []awsv1alpha1.Account{
+			newAccount("osd-creds-mgmt-default", "default"),
+			newAccount("osd-creds-mgmt-east", "fedramp-east"),
+			newAccount("osd-creds-mgmt-west", "fedramp-west"),
+		}
+	})
+
+	It("keeps every account when the claim doesn't select a pool", func() {
+		pools, err := r.claimablePools(accountClaim)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(filterAccountsByPool(accounts, pools)).To(HaveLen(3))
+	})
+
+	It("keeps the accounts of the pool named by the claim", func() {
+		accountClaim.Spec.AccountPool = "fedramp-east"
+		pools, err := r.claimablePools(accountClaim)
+		Expect(err).NotTo(HaveOccurred())
+		filtered := filterAccountsByPool(accounts, pools)
+		Expect(filtered).To(HaveLen(1))
+		Expect(filtered[0].Name).To(Equal("osd-creds-mgmt-east"))
+	})
+
+	It("keeps the accounts of the pools matching the claim selector", func() {
+		accountClaim.Spec.AccountPoolSelector = &metav1.LabelSelector{
+			MatchLabels: map[string]string{"partition": "fedramp"},
+		}
+		pools, err := r.claimablePools(accountClaim)
+		Expect(err).NotTo(HaveOccurred())
+		filtered := filterAccountsByPool(accounts, pools)
+		Expect(filtered).To(HaveLen(2))
+		Expect(filtered[0].Name).To(Equal("osd-creds-mgmt-east"))
+		Expect(filtered[1].Name).To(Equal("osd-creds-mgmt-west"))
+	})
+
+	It("keeps no account when no pool matches the claim selector", func() {
+		accountClaim.Spec.AccountPoolSelector = &metav1.LabelSelector{
+			MatchLabels: map[string]string{"partition": "china"},
+		}
+		pools, err := r.claimablePools(accountClaim)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(filterAccountsByPool(accounts, pools)).To(BeEmpty())
+	})
+})
@@ -0,0 +1,69 @@
+package accountclaim
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+
+	awsv1alpha1 "github.com/ravitri/aws-account-operator/api/v1alpha1"
+)
+
+// reservedAccount returns the account already linked to the claim, reserved by a reconcile that
+// failed to link the claim back to it
+func reservedAccount(accounts []awsv1alpha1.Account, accountClaim *awsv1alpha1.AccountClaim) *awsv1alpha1.Account {
+	for i := range accounts {
+		account := &accounts[i]
+		if account.Spec.BYOC || account.DeletionTimestamp != nil {
+			continue
+		}
+		if account.Spec.ClaimLink == accountClaim.Name && account.Spec.ClaimLinkNamespace == accountClaim.Namespace {
+			return account
+		}
+	}
+	return nil
+}
+
+// reserveAccount links the first account the claim can get to it. Each account is updated with the
+// resourceVersion it was listed with, so an account reserved by another claim since then fails with
+// a conflict and the claim moves on to the next one instead of sharing it. A conflict is returned
+// when every account the claim can get was reserved by another claim.
+func (r *AccountClaimReconciler) reserveAccount(reqLogger logr.Logger, accountList *awsv1alpha1.AccountList, accountClaim *awsv1alpha1.AccountClaim) (*awsv1alpha1.Account, error) {
+	if account := reservedAccount(accountList.Items, accountClaim); account != nil {
+		reqLogger.Info(fmt.Sprintf("Account %s is already reserved for the claim", account.Name))
+		return account, nil
+	}
+
+	// Reused accounts of the legal entity of the claim take priority, then the oldest Ready account
+	candidates := claimableAccounts(accountList.Items, accountClaim)
+	var conflict error
+	for i := range candidates {
+		account := &candidates[i]
+		if account.Status.Reused {
+			reqLogger.Info(fmt.Sprintf("Reusing account: %s", account.Name))
+		} else {
+			reqLogger.Info(fmt.Sprintf("Claiming account: %s", account.Name))
+		}
+
+		// This will trigger the reconcile loop for the account which will mark the account as claimed in its status
+		updateClaimedAccountFields(reqLogger, account, accountClaim)
+		err := r.Client.Update(context.TODO(), account)
+		if err == nil {
+			return account, nil
+		}
+		if k8serr.IsConflict(err) || k8serr.IsNotFound(err) {
+			reqLogger.Info(fmt.Sprintf("Account %s changed since it was listed, trying the next one", account.Name), "error", err.Error())
+			conflict = err
+			continue
+		}
+		reqLogger.Error(err, fmt.Sprintf("Account spec update for %s failed", account.Name))
+		return nil, err
+	}
+
+	if conflict != nil {
+		return nil, conflict
+	}
+	// Neither unclaimed nor reused accounts found
+	return nil, fmt.Errorf("can't find a ready account to claim")
+}
@@ -0,0 +1,290 @@
+package accountclaim
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	apis "github.com/ravitri/aws-account-operator/api"
+	awsv1alpha1 "github.com/ravitri/aws-account-operator/api/v1alpha1"
+	"github.com/ravitri/aws-account-operator/pkg/localmetrics"
+	"github.com/ravitri/aws-account-operator/pkg/testutils"
+)
+
+// serializedClient serializes the updates of the fake client, which checks the resourceVersion and
+// stores the object in separate steps where the API server does both at once
+type serializedClient struct {
+	client.Client
+	mutex *sync.Mutex
+}
+
+func (c serializedClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.Client.Update(ctx, obj, opts...)
+}
+
+func (c serializedClient) Status() client.StatusWriter {
+	return serializedStatusWriter{StatusWriter: c.Client.Status(), mutex: c.mutex}
+}
+
+type serializedStatusWriter struct {
+	client.StatusWriter
+	mutex *sync.Mutex
+}
+
+func (w serializedStatusWriter) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	return w.StatusWriter.Update(ctx, obj, opts...)
+}
+
+// failingClaimLinkClient fails the updates linking an AccountClaim to its account while fail is set
+type failingClaimLinkClient struct {
+	client.Client
+	fail *bool
+}
+
+func (c failingClaimLinkClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	if claim, ok := obj.(*awsv1alpha1.AccountClaim); ok && claim.Spec.AccountLink != "" && *c.fail {
+		return k8serr.NewInternalError(fmt.Errorf("claim update failed"))
+	}
+	return c.Client.Update(ctx, obj, opts...)
+}
+
+var _ = Describe("Account reservation", func() {
+	var (
+		nullLogger = testutils.NewTestLogger().Logger()
+		now        = time.Now()
+		r          *AccountClaimReconciler
+		objs       []runtime.Object
+	)
+
+	err := apis.AddToScheme(scheme.Scheme)
+	if err != nil {
+		fmt.Printf("failed adding apis to scheme in account reservation tests")
+	}
+	localmetrics.Collector = localmetrics.NewMetricsCollector(nil)
+
+	newAccount := func(name string, readyFor time.Duration) *awsv1alpha1.Account {
+		return &awsv1alpha1.Account{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: awsv1alpha1.AccountCrNamespace,
+			},
+			Status: awsv1alpha1.AccountStatus{
+				State: string(awsv1alpha1.AccountReady),
+				Conditions: []awsv1alpha1.AccountCondition{{
+					Type:               awsv1alpha1.AccountReady,
+					Status:             corev1.ConditionTrue,
+					LastTransitionTime: metav1.NewTime(now.Add(-readyFor)),
+				}},
+			},
+		}
+	}
+	newClaim := func(name string, age time.Duration) *awsv1alpha1.AccountClaim {
+		return &awsv1alpha1.AccountClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         "claims",
+				CreationTimestamp: metav1.NewTime(now.Add(-age)),
+			},
+			Spec: awsv1alpha1.AccountClaimSpec{
+				LegalEntity:   awsv1alpha1.LegalEntity{ID: "corp-" + name},
+				ManualSTSMode: true,
+			},
+		}
+	}
+	listAccounts := func() *awsv1alpha1.AccountList {
+		accounts := &awsv1alpha1.AccountList{}
+		Expect(r.Client.List(context.TODO(), accounts, client.InNamespace(awsv1alpha1.AccountCrNamespace))).To(Succeed())
+		return accounts
+	}
+	getAccount := func(name string) *awsv1alpha1.Account {
+		account := &awsv1alpha1.Account{}
+		Expect(r.Client.Get(context.TODO(), types.NamespacedName{Namespace: awsv1alpha1.AccountCrNamespace, Name: name}, account)).To(Succeed())
+		return account
+	}
+
+	JustBeforeEach(func() {
+		configMap := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      awsv1alpha1.DefaultConfigMap,
+				Namespace: awsv1alpha1.AccountCrNamespace,
+			},
+		}
+		r = &AccountClaimReconciler{
+			Client: serializedClient{
+				Client: fake.NewClientBuilder().WithScheme(scheme.Scheme).WithRuntimeObjects(append(objs, configMap)...).Build(),
+				mutex:  &sync.Mutex{},
+			},
+			Scheme:   scheme.Scheme,
+			recorder: &record.FakeRecorder{},
+		}
+	})
+
+	Context("Reserving an account", func() {
+		BeforeEach(func() {
+			objs = []runtime.Object{newAccount("oldest", 2*time.Hour), newAccount("newest", time.Hour)}
+		})
+
+		It("links the oldest Ready account to the claim", func() {
+			account, err := r.reserveAccount(nullLogger, listAccounts(), newClaim("claim", time.Minute))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(account.Name).To(Equal("oldest"))
+			Expect(getAccount("oldest").Spec.ClaimLink).To(Equal("claim"))
+			Expect(getAccount("oldest").Spec.ClaimLinkNamespace).To(Equal("claims"))
+		})
+
+		It("moves on to the next account when one was reserved since it was listed", func() {
+			stale := listAccounts()
+			_, err := r.reserveAccount(nullLogger, listAccounts(), newClaim("first", time.Hour))
+			Expect(err).NotTo(HaveOccurred())
+
+			account, err := r.reserveAccount(nullLogger, stale, newClaim("second", time.Minute))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(account.Name).To(Equal("newest"))
+			Expect(getAccount("oldest").Spec.ClaimLink).To(Equal("first"))
+			Expect(getAccount("newest").Spec.ClaimLink).To(Equal("second"))
+		})
+
+		It("returns a conflict when every account was reserved since it was listed", func() {
+			stale := listAccounts()
+			_, err := r.reserveAccount(nullLogger, listAccounts(), newClaim("first", time.Hour))
+			Expect(err).NotTo(HaveOccurred())
+			_, err = r.reserveAccount(nullLogger, listAccounts(), newClaim("second", time.Hour))
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = r.reserveAccount(nullLogger, stale, newClaim("third", time.Minute))
+			Expect(k8serr.IsConflict(err)).To(BeTrue())
+		})
+
+		It("returns the account already reserved for the claim", func() {
+			claim := newClaim("claim", time.Minute)
+			_, err := r.reserveAccount(nullLogger, listAccounts(), claim)
+			Expect(err).NotTo(HaveOccurred())
+
+			account, err := r.reserveAccount(nullLogger, listAccounts(), claim)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(account.Name).To(Equal("oldest"))
+			Expect(getAccount("newest").Spec.ClaimLink).To(BeEmpty())
+		})
+	})
+
+	Context("Failing to link the claim to its reserved account", func() {
+		BeforeEach(func() {
+			objs = []runtime.Object{newAccount("account", time.Hour), newClaim("claim", time.Minute)}
+		})
+
+		It("links the reserved account without waiting for its turn again", func() {
+			fail := true
+			r.Client = failingClaimLinkClient{Client: r.Client, fail: &fail}
+			request := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "claims", Name: "claim"}}
+			var err error
+			for i := 0; i < 5 && err == nil; i++ {
+				_, err = r.Reconcile(context.TODO(), request)
+			}
+			Expect(err).To(HaveOccurred())
+			Expect(getAccount("account").Spec.ClaimLink).To(Equal("claim"))
+
+			// An older claim now comes first in the queue, and no other account is available
+			older := newClaim("older", time.Hour)
+			older.Status.State = awsv1alpha1.ClaimStatusPending
+			Expect(r.Client.Create(context.TODO(), older)).To(Succeed())
+
+			fail = false
+			_, err = r.Reconcile(context.TODO(), request)
+			Expect(err).NotTo(HaveOccurred())
+			claim := &awsv1alpha1.AccountClaim{}
+			Expect(r.Client.Get(context.TODO(), request.NamespacedName, claim)).To(Succeed())
+			Expect(claim.Spec.AccountLink).To(Equal("account"))
+			Expect(claim.Status.QueuePosition).To(Equal(0))
+		})
+	})
+
+	Context("Reconciling claims concurrently", func() {
+		const (
+			accountCount = 8
+			claimCount   = 12
+		)
+
+		BeforeEach(func() {
+			objs = []runtime.Object{}
+			for i := 0; i < accountCount; i++ {
+				objs = append(objs, newAccount(fmt.Sprintf("account-%d", i), time.Duration(i+1)*time.Hour))
+			}
+			for i := 0; i < claimCount; i++ {
+				objs = append(objs, newClaim(fmt.Sprintf("claim-%d", i), time.Duration(i+1)*time.Minute))
+			}
+		})
+
+		// reconcileClaim reconciles the claim until it is linked to an account, or every account is
+		// reserved for another claim
+		reconcileClaim := func(name string) {
+			defer GinkgoRecover()
+			request := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "claims", Name: name}}
+			for i := 0; i < 1000; i++ {
+				// Conflicting updates fail the reconcile, and are retried like the controller would
+				_, _ = r.Reconcile(context.TODO(), request)
+
+				claim := &awsv1alpha1.AccountClaim{}
+				Expect(r.Client.Get(context.TODO(), request.NamespacedName, claim)).To(Succeed())
+				if claim.Spec.AccountLink != "" {
+					return
+				}
+				available := false
+				for _, account := range listAccounts().Items {
+					if account.Spec.ClaimLink == "" || account.Spec.ClaimLink == name {
+						available = true
+					}
+				}
+				if !available {
+					return
+				}
+			}
+			Fail(fmt.Sprintf("claim %s was neither linked to an account nor left without one", name))
+		}
+
+		It("links each account to a single claim", func() {
+			var wg sync.WaitGroup
+			for i := 0; i < claimCount; i++ {
+				wg.Add(1)
+				go func(name string) {
+					defer wg.Done()
+					reconcileClaim(name)
+				}(fmt.Sprintf("claim-%d", i))
+			}
+			wg.Wait()
+
+			claims := &awsv1alpha1.AccountClaimList{}
+			Expect(r.Client.List(context.TODO(), claims)).To(Succeed())
+			linked := map[string]string{}
+			for _, claim := range claims.Items {
+				if claim.Spec.AccountLink == "" {
+					continue
+				}
+				Expect(linked).NotTo(HaveKey(claim.Spec.AccountLink), "account %s is linked to two claims", claim.Spec.AccountLink)
+				linked[claim.Spec.AccountLink] = claim.Name
+			}
+			Expect(linked).To(HaveLen(accountCount))
+			for _, account := range listAccounts().Items {
+				Expect(account.Spec.ClaimLink).To(Equal(linked[account.Name]))
+				Expect(account.Spec.ClaimLinkNamespace).To(Equal("claims"))
+			}
+		})
+	})
+})
@@ -6,15 +6,14 @@ import (
 	"fmt"
 	"time"
 
-	"github.com/rkt/rkt/tests/testutils/logger"
-
 	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/aws/awserr"
-	"github.com/aws/aws-sdk-go/service/ec2"
-	"github.com/aws/aws-sdk-go/service/route53"
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
 	awsv1alpha1 "github.com/ravitri/aws-account-operator/api/v1alpha1"
+	"github.com/ravitri/aws-account-operator/config"
 	"github.com/ravitri/aws-account-operator/pkg/awsclient"
 	"github.com/ravitri/aws-account-operator/pkg/localmetrics"
 	"github.com/ravitri/aws-account-operator/pkg/utils"
@@ -61,8 +60,11 @@ func (r *AccountClaimReconciler) finalizeAccountClaim(reqLogger logr.Logger, acc
 
 	var awsClientInput awsclient.NewAwsClientInput
 
-	// Region comes from accountClaim
-	clusterAwsRegion := accountClaim.Spec.Aws.Regions[0].Name
+	// Region comes from accountClaim, claims created before regions were validated may have none
+	clusterAwsRegion := config.GetDefaultRegion()
+	if len(accountClaim.Spec.Aws.Regions) > 0 {
+		clusterAwsRegion = accountClaim.Spec.Aws.Regions[0].Name
+	}
 	if reusedAccount.IsBYOC() {
 		// AWS credential comes from accountclaim object osdCcsAdmin user
 		// We must use this user as we would other delete the osdManagedAdmin
@@ -106,13 +108,40 @@ func (r *AccountClaimReconciler) finalizeAccountClaim(reqLogger logr.Logger, acc
 		return nil
 	}
 
+	configMap, err := utils.GetOperatorConfigMap(r.Client)
+	if err != nil {
+		reqLogger.Error(err, "Failed retrieving configmap")
+		return err
+	}
+
+	dryRun := cleanupDryRun(accountClaim, configMap)
+
 	before := time.Now()
 	// Perform account clean up in AWS
-	err = r.cleanUpAwsAccount(reqLogger, awsClient)
-	if err != nil {
+	results, cleanupErr := r.cleanUpAwsAccount(reqLogger, awsClient, awsClientInput, configMap, dryRun)
+
+	// Record what was found and deleted, and what blocked the reuse if anything did
+	if results != nil {
+		err = r.setReuseCleanupReport(reqLogger, reusedAccount, results, dryRun)
+		if err != nil {
+			reqLogger.Error(err, "Failed to record reuse cleanup report")
+		}
+	}
+
+	// A dry run leaves the account as it is, still linked to the claim, until cleanup is enabled
+	if dryRun {
+		reqLogger.Info("Reuse cleanup dry run finished, the account is not reset")
+		return awsv1alpha1.ErrAccountReuseCleanupDryRun
+	}
+
+	if errors.Is(cleanupErr, awsv1alpha1.ErrAccountReuseCleanupPending) {
+		reqLogger.Info("Resources of the account wait on AWS to delete what they depend on, cleaning up again later")
+		return cleanupErr
+	}
+	if cleanupErr != nil {
 		localmetrics.Collector.AddAccountReuseCleanupFailure()
-		reqLogger.Error(err, "Failed to clean up AWS account")
-		return err
+		reqLogger.Error(cleanupErr, "Failed to clean up AWS account")
+		return cleanupErr
 	}
 	localmetrics.Collector.SetAccountReusedCleanupDuration(time.Since(before).Seconds())
 
@@ -156,6 +185,11 @@ func (r *AccountClaimReconciler) resetAccountSpecStatus(reqLogger logr.Logger, r
 	reusedAccount.Status.Reused = true
 	conditionMsg := fmt.Sprintf("Account Reuse - %s", conditionStatus)
 	utils.SetAccountStatus(reusedAccount, conditionMsg, accountState, conditionStatus)
+	eventType := corev1.EventTypeNormal
+	if conditionStatus == AccountFailed {
+		eventType = corev1.EventTypeWarning
+	}
+	r.recorder.Eventf(reusedAccount, eventType, string(accountState), "Released by AccountClaim %s/%s: %s", deletedAccountClaim.Namespace, deletedAccountClaim.Name, conditionMsg)
 	err = r.accountStatusUpdate(reqLogger, reusedAccount)
 	if err != nil {
 		reqLogger.Error(err, "Failed to update account status for reuse")
@@ -165,295 +199,93 @@ func (r *AccountClaimReconciler) resetAccountSpecStatus(reqLogger logr.Logger, r
 	return nil
 }
 
-func (r *AccountClaimReconciler) cleanUpAwsAccount(reqLogger logr.Logger, awsClient awsclient.Client) error {
-	// Clean up status, used to store an error if any of the cleanup functions received one
-	cleanUpStatusFailed := false
-
-	// Channels to track clean up functions
-	awsNotifications, awsErrors := make(chan string), make(chan string)
-
-	defer close(awsNotifications)
-	defer close(awsErrors)
-
-	// Declare un array of cleanup functions
-	cleanUpFunctions := []func(logr.Logger, awsclient.Client, chan string, chan string) error{
-		r.cleanUpAwsAccountSnapshots,
-		r.cleanUpAwsAccountEbsVolumes,
-		r.cleanUpAwsAccountS3,
-		r.CleanUpAwsAccountVpcEndpointServiceConfigurations,
-		r.cleanUpAwsRoute53,
-	}
-
-	// Call the clean up functions in parallel
-	for _, cleanUpFunc := range cleanUpFunctions {
-		//nolint:errcheck // Not checking return value of goroutine
-		go cleanUpFunc(reqLogger, awsClient, awsNotifications, awsErrors)
-	}
-
-	var err error
-	// Wait for clean up functions to end
-	for i := 0; i < len(cleanUpFunctions); i++ {
-		select {
-		case msg := <-awsNotifications:
-			reqLogger.Info(msg)
-		case errMsg := <-awsErrors:
-			err = errors.New(errMsg)
-			reqLogger.Error(err, errMsg)
-			cleanUpStatusFailed = true
-		}
-	}
-
-	// Return an error if we saw any errors on the awsErrors channel so we can make the reused account as failed
-	if cleanUpStatusFailed {
-		cleanUpStatusFailedMsg := "failed to clean up AWS account"
-		reqLogger.Error(err, cleanUpStatusFailedMsg)
-		return err
-	}
-
-	reqLogger.Info("AWS account cleanup completed")
-
-	return nil
-}
-
-func (r *AccountClaimReconciler) cleanUpAwsAccountSnapshots(reqLogger logr.Logger, awsClient awsclient.Client, awsNotifications chan string, awsErrors chan string) error {
-
-	// Filter only for snapshots owned by the account
-	selfOwnerFilter := ec2.Filter{
-		Name: aws.String("owner-alias"),
-		Values: []*string{
-			aws.String("self"),
-		},
-	}
-	describeSnapshotsInput := ec2.DescribeSnapshotsInput{
-		Filters: []*ec2.Filter{
-			&selfOwnerFilter,
-		},
-	}
-	ebsSnapshots, err := awsClient.DescribeSnapshots(&describeSnapshotsInput)
+// cleanUpAwsAccount runs every enabled Cleaner against the account and returns their results.
+// Global cleaners run once with awsClient, regional cleaners run in every region enabled in the
+// account with a client built from awsClientInput. The error is non-nil if any cleaner failed,
+// or ErrAccountReuseCleanupPending if resources are left for the next run. With dryRun set the
+// cleaners only list what they would delete.
+func (r *AccountClaimReconciler) cleanUpAwsAccount(reqLogger logr.Logger, awsClient awsclient.Client, awsClientInput awsclient.NewAwsClientInput, configMap *corev1.ConfigMap, dryRun bool) ([]awsv1alpha1.CleanupResult, error) {
+	// Resources left in any region would leak into the next claim, so all of them are cleaned up
+	regions, err := listEnabledRegions(awsClient)
 	if err != nil {
-		descError := "Failed describing EBS snapshots"
-		awsErrors <- descError
-		return err
+		reqLogger.Error(err, "Failed to retrieve list of regions enabled in this account.")
+		return nil, err
 	}
 
-	for _, snapshot := range ebsSnapshots.Snapshots {
-
-		deleteSnapshotInput := ec2.DeleteSnapshotInput{
-			SnapshotId: aws.String(*snapshot.SnapshotId),
-		}
-
-		_, err = awsClient.DeleteSnapshot(&deleteSnapshotInput)
-		if err != nil {
-			delError := fmt.Errorf("failed deleting EBS snapshot: %s: %w", *snapshot.SnapshotId, err).Error()
-			awsErrors <- delError
-			return err
-		}
+	clientForRegion := func(region string) (awsclient.Client, error) {
+		regionInput := awsClientInput
+		regionInput.AwsRegion = region
+		return r.awsClientBuilder.GetClient(controllerName, r.Client, regionInput)
 	}
 
-	successMsg := "Snapshot cleanup finished successfully"
-	awsNotifications <- successMsg
-	return nil
-}
+	results := runCleaners(reqLogger, awsClient, clientForRegion, regions, enabledCleaners(configMap), getMaxConcurrentRegions(configMap), dryRun)
 
-func (r *AccountClaimReconciler) CleanUpAwsAccountVpcEndpointServiceConfigurations(reqLogger logr.Logger, awsClient awsclient.Client, awsNotifications chan string, awsErrors chan string) error {
-	describeVpcEndpointServiceConfigurationsInput := ec2.DescribeVpcEndpointServiceConfigurationsInput{}
-	vpcEndpointServiceConfigurations, err := awsClient.DescribeVpcEndpointServiceConfigurations(&describeVpcEndpointServiceConfigurationsInput)
-	if vpcEndpointServiceConfigurations == nil || err != nil {
-		descError := "Failed describing VPC endpoint service configurations"
-		awsErrors <- descError
-		return err
+	for _, result := range results {
+		reqLogger.Info("cleaner finished", "Cleaner", result.Cleaner, "Region", result.Region,
+			"Found", result.Found, "Deleted", result.Deleted, "Failed", result.Failed, "Error", result.Error)
 	}
 
-	serviceIds := []*string{}
-
-	for _, config := range vpcEndpointServiceConfigurations.ServiceConfigurations {
-		serviceIds = append(serviceIds, config.ServiceId)
+	// Return an error if any cleaner failed so we can keep the claim and retry
+	if len(failedCleanupResults(results)) > 0 {
+		err := awsv1alpha1.ErrAccountReuseCleanupFailed
+		reqLogger.Error(err, "failed to clean up AWS account", "Summary", summarizeCleanupResults(results, dryRun))
+		return results, err
 	}
-
-	successMsg := "VPC endpoint service configuration cleanup finished successfully"
-	if len(serviceIds) == 0 {
-		awsNotifications <- successMsg + " (nothing to do)"
-		return nil
+	if !dryRun && pendingCleanupResults(results) > 0 {
+		return results, awsv1alpha1.ErrAccountReuseCleanupPending
 	}
 
-	deleteVpcEndpointServiceConfigurationsInput := ec2.DeleteVpcEndpointServiceConfigurationsInput{
-		ServiceIds: serviceIds,
-	}
-
-	output, err := awsClient.DeleteVpcEndpointServiceConfigurations(&deleteVpcEndpointServiceConfigurationsInput)
-	if err != nil {
-		unsuccessfulList := ""
-		for i, unsuccessfulEndpoint := range output.Unsuccessful {
-			if i > 0 {
-				unsuccessfulList += ", "
-			}
-			unsuccessfulList += *unsuccessfulEndpoint.ResourceId
-		}
-		delError := fmt.Sprintf("Failed deleting VPC endpoint service configurations: %s", unsuccessfulList)
-		awsErrors <- delError
-		return err
-	}
-
-	awsNotifications <- successMsg
-	return nil
-}
-
-func (r *AccountClaimReconciler) cleanUpAwsAccountEbsVolumes(reqLogger logr.Logger, awsClient awsclient.Client, awsNotifications chan string, awsErrors chan string) error {
-
-	describeVolumesInput := ec2.DescribeVolumesInput{}
-	ebsVolumes, err := awsClient.DescribeVolumes(&describeVolumesInput)
-	if err != nil {
-		descError := "Failed describing EBS volumes"
-		awsErrors <- descError
-		return err
-	}
-
-	for _, volume := range ebsVolumes.Volumes {
-
-		deleteVolumeInput := ec2.DeleteVolumeInput{
-			VolumeId: aws.String(*volume.VolumeId),
-		}
-
-		_, err = awsClient.DeleteVolume(&deleteVolumeInput)
-		if err != nil {
-			delError := fmt.Errorf("failed deleting EBS volume: %s: %w", *volume.VolumeId, err).Error()
-			logger.Error(delError)
-			awsErrors <- delError
-			return err
-		}
-
-	}
-
-	successMsg := "EBS Volume cleanup finished successfully"
-	awsNotifications <- successMsg
-	return nil
-}
-
-func (r *AccountClaimReconciler) cleanUpAwsAccountS3(reqLogger logr.Logger, awsClient awsclient.Client, awsNotifications chan string, awsErrors chan string) error {
-	listBucketsInput := s3.ListBucketsInput{}
-	s3Buckets, err := awsClient.ListBuckets(&listBucketsInput)
-	if err != nil {
-		listError := fmt.Errorf("failed listing S3 buckets: %w", err).Error()
-		awsErrors <- listError
-		return err
-	}
-
-	for _, bucket := range s3Buckets.Buckets {
-
-		deleteBucketInput := s3.DeleteBucketInput{
-			Bucket: aws.String(*bucket.Name),
-		}
-
-		// delete any content if any
-		err := DeleteBucketContent(awsClient, *bucket.Name)
-		if err != nil {
-			ContentDelErr := fmt.Errorf("failed to delete bucket content: %s: %w", *bucket.Name, err).Error()
-			if aerr, ok := err.(awserr.Error); ok {
-				switch aerr.Code() {
-				case s3.ErrCodeNoSuchBucket:
-					//ignore these errors
-				default:
-					awsErrors <- ContentDelErr
-					return err
-				}
-			}
-		}
-		_, err = awsClient.DeleteBucket(&deleteBucketInput)
-		if err != nil {
-			DelError := fmt.Errorf("failed deleting S3 bucket: %s: %w", *bucket.Name, err).Error()
-			if aerr, ok := err.(awserr.Error); ok {
-				switch aerr.Code() {
-				case s3.ErrCodeNoSuchBucket:
-					//ignore these errors
-				default:
-					awsErrors <- DelError
-					return err
-				}
-			}
-		}
-
-	}
+	reqLogger.Info("AWS account cleanup completed")
 
-	successMsg := "S3 cleanup finished successfully"
-	awsNotifications <- successMsg
-	return nil
+	return results, nil
 }
 
-func (r *AccountClaimReconciler) cleanUpAwsRoute53(reqLogger logr.Logger, awsClient awsclient.Client, awsNotifications chan string, awsErrors chan string) error {
-
-	var nextZoneMarker *string
-
-	// Paginate through hosted zones
-	for {
-		// Get list of hosted zones by page
-		hostedZonesOutput, err := awsClient.ListHostedZones(&route53.ListHostedZonesInput{Marker: nextZoneMarker})
-		if err != nil {
-			listError := fmt.Errorf("failed to list Hosted Zones: %w", err).Error()
-			awsErrors <- listError
-			return err
-		}
-
-		for _, zone := range hostedZonesOutput.HostedZones {
-
-			// List and delete all Record Sets for the current zone
-			var nextRecordName *string
-			// Pagination again!!!!!
-			for {
-				recordSet, listRecordsError := awsClient.ListResourceRecordSets(&route53.ListResourceRecordSetsInput{HostedZoneId: zone.Id, StartRecordName: nextRecordName})
-				if listRecordsError != nil {
-					recordSetListError := fmt.Errorf("failed to list Record sets for hosted zone %s: %w", *zone.Name, err).Error()
-					awsErrors <- recordSetListError
-					return listRecordsError
-				}
-
-				changeBatch := &route53.ChangeBatch{}
-				for _, record := range recordSet.ResourceRecordSets {
-					// Build ChangeBatch
-					// https://docs.aws.amazon.com/sdk-for-go/api/service/route53/#ChangeBatch
-					//https://docs.aws.amazon.com/sdk-for-go/api/service/route53/#Change
-					if *record.Type != "NS" && *record.Type != "SOA" {
-						changeBatch.Changes = append(changeBatch.Changes, &route53.Change{
-							Action:            aws.String("DELETE"),
-							ResourceRecordSet: record,
-						})
-					}
-				}
-
-				if changeBatch.Changes != nil {
-					_, changeErr := awsClient.ChangeResourceRecordSets(&route53.ChangeResourceRecordSetsInput{HostedZoneId: zone.Id, ChangeBatch: changeBatch})
-					if changeErr != nil {
-						recordDeleteError := fmt.Errorf("failed to delete record sets for hosted zone %s: %w", *zone.Name, err).Error()
-						awsErrors <- recordDeleteError
-						return changeErr
-					}
-				}
-
-				if *recordSet.IsTruncated {
-					nextRecordName = recordSet.NextRecordName
-				} else {
-					break
-				}
-
-			}
-
-			_, deleteError := awsClient.DeleteHostedZone(&route53.DeleteHostedZoneInput{Id: zone.Id})
-			if deleteError != nil {
-				zoneDelErr := fmt.Errorf("failed to delete hosted zone: %s: %w", *zone.Name, err).Error()
-				awsErrors <- zoneDelErr
-				return deleteError
-			}
-		}
-
-		if *hostedZonesOutput.IsTruncated {
-			nextZoneMarker = hostedZonesOutput.Marker
-		} else {
-			break
-		}
-	}
-
-	successMsg := "Route53 cleanup finished successfully"
-	awsNotifications <- successMsg
-	return nil
+// setReuseCleanupReport records the cleanup results in the Account status, along with a condition
+// that explains what blocked the reuse when a cleaner failed
+func (r *AccountClaimReconciler) setReuseCleanupReport(reqLogger logr.Logger, account *awsv1alpha1.Account, results []awsv1alpha1.CleanupResult, dryRun bool) error {
+	failed := len(failedCleanupResults(results)) > 0
+	pending := !dryRun && pendingCleanupResults(results) > 0
+	succeeded := !failed && !pending
+	account.Status.ReuseCleanup = &awsv1alpha1.ReuseCleanupReport{
+		CompletionTime: metav1.Now(),
+		Succeeded:      succeeded,
+		DryRun:         dryRun,
+		Results:        capReportedResources(results),
+	}
+
+	conditionStatus := corev1.ConditionTrue
+	reason := utils.EventReasonReuseCleanupFailed
+	eventType := corev1.EventTypeWarning
+	if succeeded {
+		conditionStatus = corev1.ConditionFalse
+		reason = utils.EventReasonReuseCleanupSucceeded
+		eventType = corev1.EventTypeNormal
+	}
+	// Pending resources only delay the reuse, the next run deletes them
+	if pending && !failed {
+		conditionStatus = corev1.ConditionFalse
+		reason = utils.EventReasonReuseCleanupPending
+		eventType = corev1.EventTypeNormal
+	}
+	// A dry run never blocks the reuse on its own, listing errors are only reported
+	if dryRun {
+		conditionStatus = corev1.ConditionFalse
+		reason = utils.EventReasonReuseCleanupDryRun
+		eventType = corev1.EventTypeNormal
+	}
+	summary := summarizeCleanupResults(results, dryRun)
+	account.Status.Conditions = utils.SetAccountCondition(
+		account.Status.Conditions,
+		awsv1alpha1.AccountReuseCleanupFailed,
+		conditionStatus,
+		reason,
+		summary,
+		utils.UpdateConditionIfReasonOrMessageChange,
+		account.Spec.BYOC,
+	)
+	r.recorder.Event(account, eventType, reason, summary)
+
+	return r.accountStatusUpdate(reqLogger, account)
 }
 
 // DeleteBucketContent deletes any content in a bucket if it is not empty
@@ -2,69 +2,30 @@ package accountclaim_test
 
 import (
 	"fmt"
-	"sync"
 
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/go-logr/logr"
 	"github.com/golang/mock/gomock"
+	awsv1alpha1 "github.com/ravitri/aws-account-operator/api/v1alpha1"
 	"github.com/ravitri/aws-account-operator/controllers/accountclaim"
-	mock "github.com/ravitri/aws-account-operator/controllers/accountclaim/mock"
-	"github.com/ravitri/aws-account-operator/pkg/awsclient"
 	awsmock "github.com/ravitri/aws-account-operator/pkg/awsclient/mock"
 	"github.com/ravitri/aws-account-operator/pkg/testutils"
-	"k8s.io/client-go/kubernetes/scheme"
-	kclient "sigs.k8s.io/controller-runtime/pkg/client"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 )
 
-type cleanupfunc func(logr.Logger, awsclient.Client, chan string, chan string) error
-
-func runCleanupFunc(functorun cleanupfunc, client awsclient.Client) (string, string, error) {
-
-	wg := sync.WaitGroup{}
-	notifications, errors := make(chan string), make(chan string)
-
-	msg := ""
-	errMsg := ""
-	go func() {
-		defer wg.Done()
-		wg.Add(1)
-		select {
-		case msg = <-notifications:
-
-		case errMsg = <-errors:
-
-		}
-	}()
-	err := functorun(testutils.NewTestLogger().Logger(), client, notifications, errors)
-	wg.Wait()
-
-	return msg, errMsg, err
-}
-
 var _ = Describe("Account Reuse", func() {
 	var (
-		r                *accountclaim.AccountClaimReconciler
-		ctrl             *gomock.Controller
-		awsClientBuilder *awsmock.Builder
-		mockAwsClient    *awsmock.MockClient
-		kubeClient       kclient.Client
+		nullLogger    logr.Logger
+		ctrl          *gomock.Controller
+		mockAwsClient *awsmock.MockClient
+		cleaner       accountclaim.VpcEndpointServiceCleaner
 	)
 
 	BeforeEach(func() {
 		ctrl = gomock.NewController(GinkgoT())
-		awsClientBuilder = &awsmock.Builder{MockController: ctrl}
-		kubeClient = mock.NewMockClient(ctrl)
-
-		// Create the reconciler with a mocking AWS client IBuilder.
-		r = accountclaim.NewAccountClaimReconciler(
-			kubeClient,
-			scheme.Scheme,
-			awsClientBuilder,
-		)
-
+		nullLogger = testutils.NewTestLogger().Logger()
 		mockAwsClient = awsmock.NewMockClient(ctrl)
 	})
 
@@ -72,20 +33,45 @@ var _ = Describe("Account Reuse", func() {
 		ctrl.Finish()
 	})
 
-	Describe("CleanUpAwsAccountVpcEndpointServiceConfigurations", func() {
+	Describe("RegisteredCleaners", func() {
+		It("Contains the VPC endpoint service cleaner", func() {
+			names := []string{}
+			for _, registered := range accountclaim.RegisteredCleaners() {
+				names = append(names, registered.Name())
+			}
+			Expect(names).To(ContainElement(cleaner.Name()))
+		})
+
+		It("Runs only S3 and Route53 cleanup once per account", func() {
+			global := []string{}
+			for _, registered := range accountclaim.RegisteredCleaners() {
+				if registered.Global() {
+					global = append(global, registered.Name())
+				}
+			}
+			Expect(global).To(ConsistOf("s3-buckets", "route53-hosted-zones"))
+		})
+
+		It("Panics when a cleaner is registered twice", func() {
+			Expect(func() { accountclaim.RegisterCleaner(cleaner) }).To(Panic())
+		})
+	})
+
+	Describe("VpcEndpointServiceCleaner", func() {
 		var (
 			describeOutput ec2.DescribeVpcEndpointServiceConfigurationsOutput
 			deleteOutput   ec2.DeleteVpcEndpointServiceConfigurationsOutput
 		)
 		Context("When no VPC Endpoint Service Configuration exists", func() {
 			BeforeEach(func() {
+				describeOutput = ec2.DescribeVpcEndpointServiceConfigurationsOutput{}
 				mockAwsClient.EXPECT().DescribeVpcEndpointServiceConfigurations(gomock.Any()).Return(&describeOutput, nil)
 			})
 
 			It("Does nothing", func() {
-				notifications, errors, _ := runCleanupFunc(r.CleanUpAwsAccountVpcEndpointServiceConfigurations, mockAwsClient)
-				Expect(errors).To(Equal(""))
-				Expect(notifications).To(Equal("VPC endpoint service configuration cleanup finished successfully (nothing to do)"))
+				result, err := cleaner.Clean(nullLogger, mockAwsClient, false)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result).To(Equal(awsv1alpha1.CleanupResult{}))
 			})
 		})
 
@@ -111,12 +97,14 @@ var _ = Describe("Account Reuse", func() {
 			})
 
 			It("Deletes the VPC Endpoint Service Configuration", func() {
-				notifications, errors, _ := runCleanupFunc(r.CleanUpAwsAccountVpcEndpointServiceConfigurations, mockAwsClient)
+				result, err := cleaner.Clean(nullLogger, mockAwsClient, false)
 
 				Expect(len(deleteInput.ServiceIds)).To(Equal(1))
 				Expect(*deleteInput.ServiceIds[0]).To(Equal(serviceConfigId))
-				Expect(errors).To(Equal(""))
-				Expect(notifications).To(Equal("VPC endpoint service configuration cleanup finished successfully"))
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result.Found).To(Equal(1))
+				Expect(result.Deleted).To(Equal(1))
+				Expect(result.Failed).To(Equal(0))
 			})
 		})
 
@@ -153,13 +141,14 @@ var _ = Describe("Account Reuse", func() {
 					deleteOutput = ec2.DeleteVpcEndpointServiceConfigurationsOutput{}
 				})
 				It("Deletes the VPC Endpoint Service Configuration and doesn't return an error", func() {
-					notifications, errors, _ := runCleanupFunc(r.CleanUpAwsAccountVpcEndpointServiceConfigurations, mockAwsClient)
+					result, err := cleaner.Clean(nullLogger, mockAwsClient, false)
 
 					Expect(len(deleteInput.ServiceIds)).To(Equal(2))
 					Expect(*deleteInput.ServiceIds[0]).To(Equal(serviceConfigId1))
 					Expect(*deleteInput.ServiceIds[1]).To(Equal(serviceConfigId2))
-					Expect(errors).To(Equal(""))
-					Expect(notifications).To(Equal("VPC endpoint service configuration cleanup finished successfully"))
+					Expect(err).ToNot(HaveOccurred())
+					Expect(result.Found).To(Equal(2))
+					Expect(result.Deleted).To(Equal(2))
 				})
 			})
 
@@ -175,14 +164,15 @@ var _ = Describe("Account Reuse", func() {
 					}
 				})
 				It("Deletes the VPC Endpoint Service Configuration and returns the failing Service ID", func() {
-					notifications, errors, _ := runCleanupFunc(r.CleanUpAwsAccountVpcEndpointServiceConfigurations, mockAwsClient)
+					result, err := cleaner.Clean(nullLogger, mockAwsClient, false)
 
 					Expect(len(deleteInput.ServiceIds)).To(Equal(2))
 					Expect(*deleteInput.ServiceIds[0]).To(Equal(serviceConfigId1))
 					Expect(*deleteInput.ServiceIds[1]).To(Equal(serviceConfigId2))
-					Expect(errors).To(Equal("Failed deleting VPC endpoint service configurations: " + serviceConfigId1))
-					Expect(notifications).To(Equal(""))
-
+					Expect(err).To(MatchError("failed deleting VPC endpoint service configurations: " + serviceConfigId1))
+					Expect(result.Found).To(Equal(2))
+					Expect(result.Deleted).To(Equal(1))
+					Expect(result.Failed).To(Equal(1))
 				})
 			})
 			Context("When both of the VPC Endpoint Service Configurations can't be deleted", func() {
@@ -200,14 +190,14 @@ var _ = Describe("Account Reuse", func() {
 					}
 				})
 				It("Deletes the VPC Endpoint Service Configuration and returns the failing Service ID", func() {
-					notifications, errors, _ := runCleanupFunc(r.CleanUpAwsAccountVpcEndpointServiceConfigurations, mockAwsClient)
+					result, err := cleaner.Clean(nullLogger, mockAwsClient, false)
 
 					Expect(len(deleteInput.ServiceIds)).To(Equal(2))
 					Expect(*deleteInput.ServiceIds[0]).To(Equal(serviceConfigId1))
 					Expect(*deleteInput.ServiceIds[1]).To(Equal(serviceConfigId2))
-					Expect(errors).To(Equal("Failed deleting VPC endpoint service configurations: " + serviceConfigId1 + ", " + serviceConfigId2))
-					Expect(notifications).To(Equal(""))
-
+					Expect(err).To(MatchError("failed deleting VPC endpoint service configurations: " + serviceConfigId1 + ", " + serviceConfigId2))
+					Expect(result.Deleted).To(Equal(0))
+					Expect(result.Failed).To(Equal(2))
 				})
 			})
 		})
@@ -218,9 +208,9 @@ var _ = Describe("Account Reuse", func() {
 			})
 
 			It("Returns an error", func() {
-				notifications, errors, _ := runCleanupFunc(r.CleanUpAwsAccountVpcEndpointServiceConfigurations, mockAwsClient)
-				Expect(errors).To(Equal("Failed describing VPC endpoint service configurations"))
-				Expect(notifications).To(Equal(""))
+				result, err := cleaner.Clean(nullLogger, mockAwsClient, false)
+				Expect(err).To(MatchError("failed describing VPC endpoint service configurations: UnexpectedValue"))
+				Expect(result.Found).To(Equal(0))
 			})
 		})
 	})
@@ -0,0 +1,318 @@
+package accountclaim
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	awsv1alpha1 "github.com/ravitri/aws-account-operator/api/v1alpha1"
+	"github.com/ravitri/aws-account-operator/pkg/utils"
+)
+
+const (
+	// priorityClassesConfigMapKey lists the claim priority classes, one name:priority per line
+	priorityClassesConfigMapKey = "claims.priority-classes"
+	// legalEntityMaxAccountsConfigMapKey is the most non-CCS accounts a legal entity can hold,
+	// unlimited when unset or 0
+	legalEntityMaxAccountsConfigMapKey = "claims.legal-entity-max-accounts"
+	// legalEntityQuotasConfigMapKey overrides legalEntityMaxAccountsConfigMapKey for some legal
+	// entities, one legalEntityID:maxAccounts per line
+	legalEntityQuotasConfigMapKey = "claims.legal-entity-quotas"
+
+	// claimQueueRequeueInterval is how often a pending claim checks its place in the queue
+	claimQueueRequeueInterval = 10 * time.Second
+	// claimQuotaRequeueInterval is how often a claim of a legal entity at its quota checks it again
+	claimQuotaRequeueInterval = time.Minute
+)
+
+// claimScheduling is how pending claims are ordered and how many accounts each legal entity can
+// hold, from the operator configmap
+type claimScheduling struct {
+	priorityClasses map[string]int
+	// maxAccounts is the quota of the legal entities without one of their own, 0 is unlimited
+	maxAccounts int
+	quotas      map[string]int
+}
+
+// getClaimScheduling reads the claim scheduling of the operator configmap. Malformed lines are
+// logged and skipped.
+func getClaimScheduling(reqLogger logr.Logger, configMap *corev1.ConfigMap) claimScheduling {
+	scheduling := claimScheduling{
+		priorityClasses: parseNamedInts(reqLogger, configMap.Data[priorityClassesConfigMapKey], priorityClassesConfigMapKey),
+		quotas:          parseNamedInts(reqLogger, configMap.Data[legalEntityQuotasConfigMapKey], legalEntityQuotasConfigMapKey),
+	}
+	if value, ok := configMap.Data[legalEntityMaxAccountsConfigMapKey]; ok {
+		maxAccounts, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil || maxAccounts < 0 {
+			reqLogger.Info("ignoring malformed legal entity quota", "value", value)
+		} else {
+			scheduling.maxAccounts = maxAccounts
+		}
+	}
+	return scheduling
+}
+
+// parseNamedInts parses the name:value lines of a configmap key
+func parseNamedInts(reqLogger logr.Logger, data string, key string) map[string]int {
+	values := map[string]int{}
+	for _, line := range strings.Split(data, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		i := strings.LastIndex(line, ":")
+		if i <= 0 {
+			reqLogger.Info("skipping malformed configmap line", "key", key, "line", line)
+			continue
+		}
+		value, err := strconv.Atoi(strings.TrimSpace(line[i+1:]))
+		if err != nil {
+			reqLogger.Info("skipping malformed configmap line", "key", key, "line", line)
+			continue
+		}
+		values[strings.TrimSpace(line[:i])] = value
+	}
+	return values
+}
+
+// priority returns the priority of the claim, 0 for claims without a known priority class
+func (s claimScheduling) priority(accountClaim *awsv1alpha1.AccountClaim) int {
+	return s.priorityClasses[accountClaim.Spec.PriorityClassName]
+}
+
+// quota returns how many non-CCS accounts the legal entity can hold, 0 being unlimited
+func (s claimScheduling) quota(legalEntityID string) int {
+	if legalEntityID == "" {
+		return 0
+	}
+	if quota, ok := s.quotas[legalEntityID]; ok {
+		return quota
+	}
+	return s.maxAccounts
+}
+
+// atQuota returns whether the legal entity of the claim already holds all the accounts it can
+func (s claimScheduling) atQuota(accountClaim *awsv1alpha1.AccountClaim, held map[string]int) bool {
+	quota := s.quota(accountClaim.Spec.LegalEntity.ID)
+	return quota > 0 && held[accountClaim.Spec.LegalEntity.ID] >= quota
+}
+
+// heldAccounts returns how many non-CCS accounts each legal entity holds
+func heldAccounts(accounts []awsv1alpha1.Account) map[string]int {
+	held := map[string]int{}
+	for _, account := range accounts {
+		if account.Spec.BYOC || account.Spec.ClaimLink == "" || account.Spec.LegalEntity.ID == "" {
+			continue
+		}
+		held[account.Spec.LegalEntity.ID]++
+	}
+	return held
+}
+
+// waitsForAccount returns whether the claim is a pending non-CCS claim without account
+func waitsForAccount(accountClaim *awsv1alpha1.AccountClaim) bool {
+	return accountClaim.Status.State == awsv1alpha1.ClaimStatusPending &&
+		accountClaim.Spec.AccountLink == "" &&
+		!accountClaim.Spec.BYOC &&
+		accountClaim.DeletionTimestamp == nil &&
+		accountClaim.Annotations[fakeAnnotation] != "true"
+}
+
+// claimQueue returns the claims waiting for an account in the order they get one: highest priority
+// first, then oldest first. Claims of legal entities that would reach their quota with the claims
+// ahead of them wait out of the queue.
+func claimQueue(claims []awsv1alpha1.AccountClaim, scheduling claimScheduling, held map[string]int) []awsv1alpha1.AccountClaim {
+	waiting := []awsv1alpha1.AccountClaim{}
+	for i := range claims {
+		if waitsForAccount(&claims[i]) {
+			waiting = append(waiting, claims[i])
+		}
+	}
+	sort.SliceStable(waiting, func(i, j int) bool {
+		if pi, pj := scheduling.priority(&waiting[i]), scheduling.priority(&waiting[j]); pi != pj {
+			return pi > pj
+		}
+		if ti, tj := waiting[i].CreationTimestamp.Time, waiting[j].CreationTimestamp.Time; !ti.Equal(tj) {
+			return ti.Before(tj)
+		}
+		if waiting[i].Namespace != waiting[j].Namespace {
+			return waiting[i].Namespace < waiting[j].Namespace
+		}
+		return waiting[i].Name < waiting[j].Name
+	})
+
+	queue := []awsv1alpha1.AccountClaim{}
+	queued := map[string]int{}
+	for key, value := range held {
+		queued[key] = value
+	}
+	for i := range waiting {
+		if scheduling.atQuota(&waiting[i], queued) {
+			continue
+		}
+		queue = append(queue, waiting[i])
+		queued[waiting[i].Spec.LegalEntity.ID]++
+	}
+	return queue
+}
+
+// samePoolSelection returns whether the claims select the same pools, and so compete for the same accounts
+func samePoolSelection(a *awsv1alpha1.AccountClaim, b *awsv1alpha1.AccountClaim) bool {
+	return a.Spec.AccountPool == b.Spec.AccountPool && equality.Semantic.DeepEqual(a.Spec.AccountPoolSelector, b.Spec.AccountPoolSelector)
+}
+
+// competingClaims keeps the claims of the queue that compete with the claim for the same accounts
+func competingClaims(queue []awsv1alpha1.AccountClaim, accountClaim *awsv1alpha1.AccountClaim) []awsv1alpha1.AccountClaim {
+	competing := []awsv1alpha1.AccountClaim{}
+	for i := range queue {
+		if samePoolSelection(&queue[i], accountClaim) {
+			competing = append(competing, queue[i])
+		}
+	}
+	return competing
+}
+
+// queuePosition returns the position of the claim in the queue, 1 being the first, or 0 when it
+// isn't in the queue
+func queuePosition(queue []awsv1alpha1.AccountClaim, accountClaim *awsv1alpha1.AccountClaim) int {
+	for i := range queue {
+		if queue[i].Namespace == accountClaim.Namespace && queue[i].Name == accountClaim.Name {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// readySince returns when the account became Ready
+func readySince(account *awsv1alpha1.Account) time.Time {
+	if condition := account.GetCondition(awsv1alpha1.AccountReady); condition != nil {
+		return condition.LastTransitionTime.Time
+	}
+	return account.CreationTimestamp.Time
+}
+
+// isClaimable returns whether the account is Ready and free to be claimed
+func isClaimable(account *awsv1alpha1.Account) bool {
+	return !account.Status.Claimed && account.Spec.ClaimLink == "" && account.Status.State == string(awsv1alpha1.AccountReady)
+}
+
+// claimableAccounts returns the accounts the claim can get, in the order it gets them: the reused
+// accounts of its legal entity first, then the accounts that were never claimed, oldest Ready first
+func claimableAccounts(accounts []awsv1alpha1.Account, accountClaim *awsv1alpha1.AccountClaim) []awsv1alpha1.Account {
+	reused := []awsv1alpha1.Account{}
+	unclaimed := []awsv1alpha1.Account{}
+	for i, account := range accounts {
+		if !isClaimable(&accounts[i]) {
+			continue
+		}
+		if !account.Status.Reused {
+			unclaimed = append(unclaimed, account)
+		} else if matchAccountForReuse(&accounts[i], accountClaim) {
+			reused = append(reused, account)
+		}
+	}
+	for _, candidates := range [][]awsv1alpha1.Account{reused, unclaimed} {
+		sort.SliceStable(candidates, func(i, j int) bool {
+			if ti, tj := readySince(&candidates[i]), readySince(&candidates[j]); !ti.Equal(tj) {
+				return ti.Before(tj)
+			}
+			return candidates[i].Name < candidates[j].Name
+		})
+	}
+	return append(reused, unclaimed...)
+}
+
+// accountLeft returns whether an account is left for the last claim of the queue once the claims ahead
+// of it got theirs. As in claimableAccounts, each claim gets a reused account of its legal entity
+// first, then an account that was never claimed.
+func accountLeft(queue []awsv1alpha1.AccountClaim, accounts []awsv1alpha1.Account) bool {
+	reused := map[string]int{}
+	unclaimed := 0
+	for i := range accounts {
+		if !isClaimable(&accounts[i]) {
+			continue
+		}
+		if accounts[i].Status.Reused {
+			reused[accounts[i].Spec.LegalEntity.ID]++
+		} else {
+			unclaimed++
+		}
+	}
+
+	// Claims ahead that get no account leave the accounts to the next ones
+	got := false
+	for i := range queue {
+		legalEntityID := queue[i].Spec.LegalEntity.ID
+		switch {
+		case reused[legalEntityID] > 0:
+			reused[legalEntityID]--
+			got = true
+		case unclaimed > 0:
+			unclaimed--
+			got = true
+		default:
+			got = false
+		}
+	}
+	return got
+}
+
+// scheduleClaim returns whether the claim can get an account now. A claim gets one when its legal
+// entity is under its quota and an account is left for it once the claims ahead of it in the queue
+// that select the same pools got theirs. Otherwise why it waits and its queue position are recorded and it is checked again
+// after the returned delay, or it fails once it waited for longer than the pending timeout.
+func (r *AccountClaimReconciler) scheduleClaim(reqLogger logr.Logger, accountClaim *awsv1alpha1.AccountClaim, allAccounts []awsv1alpha1.Account, poolAccounts []awsv1alpha1.Account) (bool, time.Duration, error) {
+	configMap, err := utils.GetOperatorConfigMap(r.Client)
+	if err != nil {
+		reqLogger.Error(err, "Failed retrieving configmap")
+		return false, 0, err
+	}
+	scheduling := getClaimScheduling(reqLogger, configMap)
+	timeout := getPendingTimeout(reqLogger, configMap)
+	held := heldAccounts(allAccounts)
+
+	if scheduling.atQuota(accountClaim, held) {
+		message := fmt.Sprintf("Legal entity %s holds %d accounts, its quota", accountClaim.Spec.LegalEntity.ID, held[accountClaim.Spec.LegalEntity.ID])
+		r.recorder.Event(accountClaim, corev1.EventTypeWarning, utils.EventReasonClaimQuotaExceeded, message)
+		return r.waitForAccount(reqLogger, accountClaim, timeout, 0, PendingLegalEntityQuotaReached, message, claimQuotaRequeueInterval)
+	}
+
+	claims := &awsv1alpha1.AccountClaimList{}
+	if err := r.Client.List(context.TODO(), claims, []client.ListOption{}...); err != nil {
+		reqLogger.Error(err, "Unable to list the accountclaims")
+		return false, 0, err
+	}
+	// The claim being reconciled is more recent than the cached list
+	found := false
+	for i := range claims.Items {
+		if claims.Items[i].Namespace == accountClaim.Namespace && claims.Items[i].Name == accountClaim.Name {
+			claims.Items[i] = *accountClaim
+			found = true
+		}
+	}
+	if !found {
+		claims.Items = append(claims.Items, *accountClaim)
+	}
+
+	// The quotas count the claims of every pool, the position only the claims competing for the same accounts
+	queue := competingClaims(claimQueue(claims.Items, scheduling, held), accountClaim)
+	position := queuePosition(queue, accountClaim)
+	if position == 0 {
+		message := fmt.Sprintf("Legal entity %s will hold all the accounts it can with the claims ahead", accountClaim.Spec.LegalEntity.ID)
+		return r.waitForAccount(reqLogger, accountClaim, timeout, 0, PendingLegalEntityQuotaReached, message, claimQuotaRequeueInterval)
+	}
+	if !accountLeft(queue[:position], poolAccounts) {
+		available := len(claimableAccounts(poolAccounts, accountClaim))
+		reason, message := r.pendingReason(poolAccounts, position, available)
+		return r.waitForAccount(reqLogger, accountClaim, timeout, position, reason, message, claimQueueRequeueInterval)
+	}
+	return true, 0, nil
+}
@@ -0,0 +1,253 @@
+package accountclaim
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	apis "github.com/ravitri/aws-account-operator/api"
+	awsv1alpha1 "github.com/ravitri/aws-account-operator/api/v1alpha1"
+	"github.com/ravitri/aws-account-operator/pkg/testutils"
+)
+
+var _ = Describe("Claim scheduling", func() {
+	var (
+		nullLogger = testutils.NewTestLogger().Logger()
+		now        = time.Now()
+	)
+
+	err := apis.AddToScheme(scheme.Scheme)
+	if err != nil {
+		fmt.Printf("failed adding apis to scheme in claim scheduling tests")
+	}
+
+	newClaim := func(name string, legalEntityID string, age time.Duration) *awsv1alpha1.AccountClaim {
+		return &awsv1alpha1.AccountClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         "claims",
+				CreationTimestamp: metav1.NewTime(now.Add(-age)),
+			},
+			Spec: awsv1alpha1.AccountClaimSpec{
+				LegalEntity: awsv1alpha1.LegalEntity{ID: legalEntityID},
+			},
+			Status: awsv1alpha1.AccountClaimStatus{State: awsv1alpha1.ClaimStatusPending},
+		}
+	}
+	newReadyAccount := func(name string, readyFor time.Duration) *awsv1alpha1.Account {
+		return &awsv1alpha1.Account{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: awsv1alpha1.AccountCrNamespace,
+			},
+			Status: awsv1alpha1.AccountStatus{
+				State: string(awsv1alpha1.AccountReady),
+				Conditions: []awsv1alpha1.AccountCondition{{
+					Type:               awsv1alpha1.AccountReady,
+					Status:             corev1.ConditionTrue,
+					LastTransitionTime: metav1.NewTime(now.Add(-readyFor)),
+				}},
+			},
+		}
+	}
+	newClaimedAccount := func(name string, legalEntityID string) *awsv1alpha1.Account {
+		account := newReadyAccount(name, time.Hour)
+		account.Spec.ClaimLink = "claim"
+		account.Spec.LegalEntity.ID = legalEntityID
+		account.Status.Claimed = true
+		return account
+	}
+
+	Context("Reading the configmap", func() {
+		It("parses priority classes and quotas, skipping malformed lines", func() {
+			scheduling := getClaimScheduling(nullLogger, &corev1.ConfigMap{Data: map[string]string{
+				priorityClassesConfigMapKey:        "critical: 1000\nhigh:100\nlow:-10\nbroken\nmedium:ten",
+				legalEntityMaxAccountsConfigMapKey: "5",
+				legalEntityQuotasConfigMapKey:      "big-corp:20",
+			}})
+			Expect(scheduling.priorityClasses).To(Equal(map[string]int{"critical": 1000, "high": 100, "low": -10}))
+			Expect(scheduling.quota("big-corp")).To(Equal(20))
+			Expect(scheduling.quota("small-corp")).To(Equal(5))
+			Expect(scheduling.quota("")).To(Equal(0))
+		})
+	})
+
+	Context("Ordering the pending claims", func() {
+		It("puts the highest priority first, then the oldest", func() {
+			scheduling := claimScheduling{priorityClasses: map[string]int{"high": 100}}
+			old := newClaim("old", "a", time.Hour)
+			recent := newClaim("recent", "b", time.Minute)
+			urgent := newClaim("urgent", "c", time.Second)
+			urgent.Spec.PriorityClassName = "high"
+			ready := newClaim("ready", "d", 2*time.Hour)
+			ready.Status.State = awsv1alpha1.ClaimStatusReady
+
+			queue := claimQueue([]awsv1alpha1.AccountClaim{*recent, *ready, *old, *urgent}, scheduling, nil)
+			Expect(queue).To(HaveLen(3))
+			Expect(queuePosition(queue, urgent)).To(Equal(1))
+			Expect(queuePosition(queue, old)).To(Equal(2))
+			Expect(queuePosition(queue, recent)).To(Equal(3))
+			Expect(queuePosition(queue, ready)).To(Equal(0))
+		})
+
+		It("leaves out the claims that would exceed the quota of their legal entity", func() {
+			scheduling := claimScheduling{maxAccounts: 2}
+			first := newClaim("first", "corp", time.Hour)
+			second := newClaim("second", "corp", time.Minute)
+			other := newClaim("other", "other-corp", time.Second)
+
+			queue := claimQueue([]awsv1alpha1.AccountClaim{*first, *second, *other}, scheduling, map[string]int{"corp": 1})
+			Expect(queuePosition(queue, first)).To(Equal(1))
+			Expect(queuePosition(queue, second)).To(Equal(0))
+			Expect(queuePosition(queue, other)).To(Equal(2))
+		})
+	})
+
+	Context("Ordering the claimable accounts", func() {
+		It("prefers reused accounts of the legal entity, then the oldest Ready", func() {
+			claim := newClaim("claim", "corp", time.Minute)
+			recent := newReadyAccount("recent", time.Minute)
+			oldest := newReadyAccount("oldest", 3*time.Hour)
+			reused := newReadyAccount("reused", time.Second)
+			reused.Status.Reused = true
+			reused.Spec.LegalEntity.ID = "corp"
+			reusedOther := newReadyAccount("reused-other", 5*time.Hour)
+			reusedOther.Status.Reused = true
+			reusedOther.Spec.LegalEntity.ID = "other-corp"
+			claimed := newClaimedAccount("claimed", "corp")
+
+			accounts := claimableAccounts([]awsv1alpha1.Account{*recent, *claimed, *reusedOther, *oldest, *reused}, claim)
+			Expect(accounts).To(HaveLen(3))
+			Expect(accounts[0].Name).To(Equal("reused"))
+			Expect(accounts[1].Name).To(Equal("oldest"))
+			Expect(accounts[2].Name).To(Equal("recent"))
+		})
+	})
+
+	Context("Scheduling a claim", func() {
+		var (
+			r        *AccountClaimReconciler
+			objs     []runtime.Object
+			accounts []awsv1alpha1.Account
+			data     map[string]string
+		)
+
+		BeforeEach(func() {
+			data = map[string]string{}
+			accounts = []awsv1alpha1.Account{*newReadyAccount("ready", time.Hour), *newClaimedAccount("claimed", "corp")}
+			objs = []runtime.Object{
+				newClaim("older", "other-corp", time.Hour),
+				newClaim("claim", "corp", time.Minute),
+			}
+		})
+
+		JustBeforeEach(func() {
+			configMap := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      awsv1alpha1.DefaultConfigMap,
+					Namespace: awsv1alpha1.AccountCrNamespace,
+				},
+				Data: data,
+			}
+			r = &AccountClaimReconciler{
+				Client:   fake.NewClientBuilder().WithScheme(scheme.Scheme).WithRuntimeObjects(append(objs, configMap)...).Build(),
+				Scheme:   scheme.Scheme,
+				recorder: &record.FakeRecorder{},
+			}
+		})
+
+		getClaim := func(name string) *awsv1alpha1.AccountClaim {
+			claim := &awsv1alpha1.AccountClaim{}
+			Expect(r.Client.Get(context.TODO(), types.NamespacedName{Namespace: "claims", Name: name}, claim)).To(Succeed())
+			return claim
+		}
+
+		It("waits behind older claims and records its queue position", func() {
+			claim := getClaim("claim")
+			scheduled, requeueAfter, err := r.scheduleClaim(nullLogger, claim, accounts, accounts)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(scheduled).To(BeFalse())
+			Expect(requeueAfter).To(Equal(claimQueueRequeueInterval))
+			Expect(getClaim("claim").Status.QueuePosition).To(Equal(2))
+
+			scheduled, _, err = r.scheduleClaim(nullLogger, getClaim("older"), accounts, accounts)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(scheduled).To(BeTrue())
+		})
+
+		Context("With claims of other pools", func() {
+			BeforeEach(func() {
+				older := newClaim("older", "other-corp", time.Hour)
+				older.Spec.AccountPool = "pool-a"
+				claim := newClaim("claim", "corp", time.Minute)
+				claim.Spec.AccountPool = "pool-b"
+				objs = []runtime.Object{older, claim}
+			})
+
+			It("only waits behind the claims of its pools", func() {
+				scheduled, _, err := r.scheduleClaim(nullLogger, getClaim("claim"), accounts, accounts)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(scheduled).To(BeTrue())
+				Expect(getClaim("claim").Status.QueuePosition).To(Equal(0))
+			})
+		})
+
+		Context("With a reused account of its legal entity", func() {
+			BeforeEach(func() {
+				reused := newReadyAccount("reused", time.Hour)
+				reused.Status.Reused = true
+				reused.Spec.LegalEntity.ID = "corp"
+				accounts = []awsv1alpha1.Account{*reused}
+			})
+
+			It("gets it ahead of older claims of other legal entities", func() {
+				scheduled, _, err := r.scheduleClaim(nullLogger, getClaim("claim"), accounts, accounts)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(scheduled).To(BeTrue())
+
+				scheduled, _, err = r.scheduleClaim(nullLogger, getClaim("older"), accounts, accounts)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(scheduled).To(BeFalse())
+			})
+		})
+
+		Context("With a priority class", func() {
+			BeforeEach(func() {
+				data[priorityClassesConfigMapKey] = "high:100"
+				claim := newClaim("claim", "corp", time.Minute)
+				claim.Spec.PriorityClassName = "high"
+				objs[1] = claim
+			})
+
+			It("gets an account ahead of older claims", func() {
+				scheduled, _, err := r.scheduleClaim(nullLogger, getClaim("claim"), accounts, accounts)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(scheduled).To(BeTrue())
+			})
+		})
+
+		Context("With the legal entity at its quota", func() {
+			BeforeEach(func() {
+				data[legalEntityQuotasConfigMapKey] = "corp:1"
+			})
+
+			It("waits out of the queue", func() {
+				scheduled, requeueAfter, err := r.scheduleClaim(nullLogger, getClaim("claim"), accounts, accounts)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(scheduled).To(BeFalse())
+				Expect(requeueAfter).To(Equal(claimQuotaRequeueInterval))
+				Expect(getClaim("claim").Status.QueuePosition).To(Equal(0))
+			})
+		})
+	})
+})
@@ -3,16 +3,22 @@ package accountpool
 import (
 	"context"
 	"fmt"
+	"time"
 
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	awsv1alpha1 "github.com/ravitri/aws-account-operator/api/v1alpha1"
 	"github.com/ravitri/aws-account-operator/controllers/account"
@@ -31,6 +37,7 @@ type AccountPoolReconciler struct {
 	client.Client
 	Scheme         *runtime.Scheme
 	accountWatcher totalaccountwatcher.AccountWatcherIface
+	recorder       record.EventRecorder
 }
 
 //+kubebuilder:rbac:groups=aws.managed.openshift.io,resources=accountpools,verbs=get;list;watch;create;update;patch;delete
@@ -59,12 +66,28 @@ func (r *AccountPoolReconciler) Reconcile(ctx context.Context, request ctrl.Requ
 	}
 
 	// Calculate unclaimed accounts vs claimed accounts
-	calculatedStatus, err := r.calculateAccountPoolStatus()
+	calculatedStatus, err := r.calculateAccountPoolStatus(currentAccountPool.Name)
 	if err != nil {
 		return reconcile.Result{}, err
 	}
 	// Update the pool size after we calculate all other values
-	calculatedStatus.PoolSize = currentAccountPool.Spec.PoolSize
+	sizing := calculatePoolSize(reqLogger, currentAccountPool, time.Now())
+	calculatedStatus.PoolSize = sizing.poolSize
+	calculatedStatus.ActiveWindow = sizing.activeWindow
+	calculatedStatus.DemandPoolSize = sizing.demandPoolSize
+	// On-demand pools create an account for each claim waiting for one
+	if currentAccountPool.Spec.OnDemand {
+		calculatedStatus.PendingClaims, err = r.pendingClaims(currentAccountPool)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		if calculatedStatus.PendingClaims > calculatedStatus.PoolSize {
+			calculatedStatus.PoolSize = calculatedStatus.PendingClaims
+		}
+	}
+	if calculatedStatus.UnclaimedAccounts > calculatedStatus.PoolSize {
+		calculatedStatus.SurplusAccounts = calculatedStatus.UnclaimedAccounts - calculatedStatus.PoolSize
+	}
 
 	if shouldUpdateAccountPoolStatus(currentAccountPool, calculatedStatus) {
 		currentAccountPool.Status = calculatedStatus
@@ -75,36 +98,75 @@ func (r *AccountPoolReconciler) Reconcile(ctx context.Context, request ctrl.Requ
 	}
 
 	// Get the number of desired unclaimed AWS accounts in the pool
-	poolSizeCount := currentAccountPool.Spec.PoolSize
+	poolSizeCount := calculatedStatus.PoolSize
+	// Scheduled and demand sizing change the pool size over time, resize the pool when it may have changed
+	result := reconcile.Result{RequeueAfter: sizing.requeueAfter}
 	unclaimedAccountCount := calculatedStatus.UnclaimedAccounts
 
 	reqLogger.Info(fmt.Sprintf("AccountPool Calculations Completed: %+v", calculatedStatus))
 
+	if calculatedStatus.SurplusAccounts > 0 {
+		err = r.scaleDown(reqLogger, currentAccountPool, calculatedStatus.SurplusAccounts)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
 	if unclaimedAccountCount >= poolSizeCount {
 		reqLogger.Info(fmt.Sprintf("unclaimed account pool satisfied, unclaimedAccounts %d >= poolSize %d", unclaimedAccountCount, poolSizeCount))
-		return reconcile.Result{}, nil
+		return result, nil
+	}
+
+	// Without a max in flight the pool creates one account per reconcile
+	accountsToCreate := 1
+	if maxInFlight := currentAccountPool.Spec.MaxInFlight; maxInFlight > 0 {
+		inFlight := calculatedStatus.UnclaimedAccounts - calculatedStatus.AvailableAccounts
+		accountsToCreate = maxInFlight - inFlight
+		if missing := poolSizeCount - unclaimedAccountCount; missing < accountsToCreate {
+			accountsToCreate = missing
+		}
+		if accountsToCreate <= 0 {
+			reqLogger.Info(fmt.Sprintf("waiting for accounts in flight, inFlight %d >= maxInFlight %d", inFlight, maxInFlight))
+			return result, nil
+		}
 	}
 
+	for i := 0; i < accountsToCreate; i++ {
+		err = r.createAccount(reqLogger, currentAccountPool, unclaimedAccountCount+i)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	return result, nil
+}
+
+// createAccount creates a new Account CR owned by the pool
+func (r *AccountPoolReconciler) createAccount(reqLogger logr.Logger, currentAccountPool *awsv1alpha1.AccountPool, unclaimedAccountCount int) error {
+	poolSizeCount := currentAccountPool.Status.PoolSize
+
 	// Create Account CR
-	newAccount := account.GenerateAccountCR(awsv1alpha1.AccountCrNamespace)
+	newAccount := account.GenerateAccountCR(awsv1alpha1.AccountCrNamespace, account.GetAccountNamePrefix(r.Client))
 	utils.AddFinalizer(newAccount, awsv1alpha1.AccountFinalizer)
+	newAccount.Labels[awsv1alpha1.AccountPoolLabel] = currentAccountPool.Name
 
 	// Set AccountPool instance as the owner and controller
 	if err := controllerutil.SetControllerReference(currentAccountPool, newAccount, r.Scheme); err != nil {
-		return reconcile.Result{}, err
+		return err
 	}
 
 	reqLogger.Info(fmt.Sprintf("Creating account %s for accountpool. Unclaimed accounts: %d, poolsize%d", newAccount.Name, unclaimedAccountCount, poolSizeCount))
-	err = r.Client.Create(context.TODO(), newAccount)
+	err := r.Client.Create(context.TODO(), newAccount)
 	if err != nil {
-		return reconcile.Result{}, err
+		return err
 	}
-
-	return reconcile.Result{}, nil
+	r.recorder.Eventf(currentAccountPool, corev1.EventTypeNormal, utils.EventReasonPoolAccountCreated,
+		"Created account %s, %d of %d unclaimed accounts in the pool", newAccount.Name, unclaimedAccountCount+1, poolSizeCount)
+	return nil
 }
 
-// Calculates the unclaimedAccountCount and Claimed Account Counts
-func (r *AccountPoolReconciler) calculateAccountPoolStatus() (awsv1alpha1.AccountPoolStatus, error) {
+// Calculates the unclaimedAccountCount and Claimed Account Counts of the accounts of the pool named poolName
+func (r *AccountPoolReconciler) calculateAccountPoolStatus(poolName string) (awsv1alpha1.AccountPoolStatus, error) {
 	unclaimedAccountCount := 0
 	claimedAccountCount := 0
 	availableAccounts := 0
@@ -120,9 +182,16 @@ func (r *AccountPoolReconciler) calculateAccountPoolStatus() (awsv1alpha1.Accoun
 		return awsv1alpha1.AccountPoolStatus{}, err
 	}
 
+	retiringAccounts := 0
 	for _, account := range accountList.Items {
-		// if the account is not owned by the accountpool, skip it
-		if !account.IsOwnedByAccountPool() {
+		// if the account is not owned by this accountpool, skip it
+		if account.GetAccountPoolName() != poolName {
+			continue
+		}
+
+		// accounts retired by the scale down policy are on their way out of the pool
+		if account.IsPendingDeletion() && account.GetAnnotations()[awsv1alpha1.RetireAnnotation] != "" {
+			retiringAccounts++
 			continue
 		}
 
@@ -157,6 +226,7 @@ func (r *AccountPoolReconciler) calculateAccountPoolStatus() (awsv1alpha1.Accoun
 		AvailableAccounts:   availableAccounts,
 		AccountsProgressing: accountsProgressing,
 		AWSLimitDelta:       accountDelta,
+		RetiringAccounts:    retiringAccounts,
 	}, nil
 }
 
@@ -175,6 +245,7 @@ func shouldUpdateAccountPoolStatus(currentAccountPool *awsv1alpha1.AccountPool,
 // SetupWithManager sets up the controller with the Manager.
 func (r *AccountPoolReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	r.accountWatcher = totalaccountwatcher.TotalAccountWatcher
+	r.recorder = mgr.GetEventRecorderFor(controllerName)
 	maxReconciles, err := utils.GetControllerMaxReconciles(controllerName)
 	if err != nil {
 		log.Error(err, "missing max reconciles for controller", "controller", controllerName)
@@ -184,6 +255,7 @@ func (r *AccountPoolReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&awsv1alpha1.AccountPool{}).
 		Owns(&awsv1alpha1.Account{}).
+		Watches(&source.Kind{Type: &awsv1alpha1.AccountClaim{}}, handler.EnqueueRequestsFromMapFunc(r.onDemandPoolsForClaim)).
 		WithOptions(controller.Options{
 			MaxConcurrentReconciles: maxReconciles,
 		}).Complete(rwm)
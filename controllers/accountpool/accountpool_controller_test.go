@@ -9,10 +9,12 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -64,7 +66,7 @@ func createAccountMock(name string, state string, claimed bool) *awsv1alpha1.Acc
 			Name:      name,
 			Namespace: "aws-account-operator",
 			OwnerReferences: []metav1.OwnerReference{
-				metav1.OwnerReference{Kind: "AccountPool"},
+				metav1.OwnerReference{Kind: "AccountPool", Name: "test"},
 			},
 		},
 		Spec: awsv1alpha1.AccountSpec{
@@ -87,6 +89,19 @@ func createAccountMock(name string, state string, claimed bool) *awsv1alpha1.Acc
 
 }
 
+// inAccountPool moves an account mock to another pool through the pool label
+func inAccountPool(account *awsv1alpha1.Account, poolName string) *awsv1alpha1.Account {
+	account.OwnerReferences = nil
+	account.Labels = map[string]string{awsv1alpha1.AccountPoolLabel: poolName}
+	return account
+}
+
+// withoutClaimLink makes an account mock free to be claimed
+func withoutClaimLink(account *awsv1alpha1.Account) *awsv1alpha1.Account {
+	account.Spec.ClaimLink = ""
+	return account
+}
+
 func TestReconcileAccountPool(t *testing.T) {
 	err := awsaccountapis.AddToScheme(scheme.Scheme)
 	if err != nil {
@@ -133,6 +148,7 @@ func TestReconcileAccountPool(t *testing.T) {
 					PoolSize:          1,
 					UnclaimedAccounts: 2,
 					AvailableAccounts: 2,
+					SurplusAccounts:   1,
 				},
 			},
 			expectedAWSCount:      2,
@@ -206,12 +222,48 @@ func TestReconcileAccountPool(t *testing.T) {
 					AvailableAccounts:   1,
 					AccountsProgressing: 2,
 					AWSLimitDelta:       1,
+					SurplusAccounts:     2,
 				},
 			},
 			expectedAWSCount:      5,
 			expectedLimit:         6,
 			verifyAccountFunction: verifyAccountPool,
 		},
+		{
+			name: "Accounts of other pools are not counted",
+			localObjects: []runtime.Object{
+				&awsv1alpha1.AccountPool{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "test",
+						Namespace: "aws-account-operator",
+					},
+					Spec: awsv1alpha1.AccountPoolSpec{
+						PoolSize: 1,
+					},
+				},
+				inAccountPool(createAccountMock("account1", "Ready", unclaimed), "test"),
+				inAccountPool(createAccountMock("account2", "Ready", unclaimed), "fedramp"),
+				inAccountPool(createAccountMock("account3", "Ready", claimed), "fedramp"),
+			},
+			expectedAccountPool: awsv1alpha1.AccountPool{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test",
+					Namespace: "aws-account-operator",
+				},
+				Spec: awsv1alpha1.AccountPoolSpec{
+					PoolSize: 1,
+				},
+				Status: awsv1alpha1.AccountPoolStatus{
+					PoolSize:          1,
+					UnclaimedAccounts: 1,
+					AvailableAccounts: 1,
+					AWSLimitDelta:     1,
+				},
+			},
+			expectedAWSCount:      3,
+			expectedLimit:         4,
+			verifyAccountFunction: verifyAccountPool,
+		},
 	}
 
 	for _, test := range tests {
@@ -229,6 +281,7 @@ func TestReconcileAccountPool(t *testing.T) {
 					accounts: test.expectedAWSCount,
 					limit:    test.expectedLimit,
 				},
+				recorder: &record.FakeRecorder{},
 			}
 
 			ap := awsv1alpha1.AccountPool{}
@@ -257,6 +310,98 @@ func TestReconcileAccountPool(t *testing.T) {
 	}
 }
 
+func TestAccountPoolScaling(t *testing.T) {
+	err := awsaccountapis.AddToScheme(scheme.Scheme)
+	if err != nil {
+		fmt.Printf("failed adding to scheme in accountpoot_controller_test.go")
+	}
+
+	localmetrics.Collector = localmetrics.NewMetricsCollector(nil)
+	tests := []struct {
+		name             string
+		spec             awsv1alpha1.AccountPoolSpec
+		accounts         []runtime.Object
+		expectedAccounts int
+		expectedRetired  []string
+	}{
+		{
+			name:             "Creates accounts up to maxInFlight",
+			spec:             awsv1alpha1.AccountPoolSpec{PoolSize: 5, MaxInFlight: 2},
+			expectedAccounts: 2,
+		},
+		{
+			name: "Doesn't create accounts while maxInFlight are progressing",
+			spec: awsv1alpha1.AccountPoolSpec{PoolSize: 5, MaxInFlight: 2},
+			accounts: []runtime.Object{
+				createAccountMock("account1", "Creating", unclaimed),
+				createAccountMock("account2", "InitializingRegions", unclaimed),
+				createAccountMock("account3", "Ready", unclaimed),
+			},
+			expectedAccounts: 3,
+		},
+		{
+			name: "Keeps surplus accounts without a scale down policy",
+			spec: awsv1alpha1.AccountPoolSpec{PoolSize: 1},
+			accounts: []runtime.Object{
+				createAccountMock("account1", "Ready", unclaimed),
+				createAccountMock("account2", "Ready", unclaimed),
+			},
+			expectedAccounts: 2,
+		},
+		{
+			name: "Retires the surplus ready accounts",
+			spec: awsv1alpha1.AccountPoolSpec{
+				PoolSize:  1,
+				ScaleDown: &awsv1alpha1.ScaleDownPolicy{Action: awsv1alpha1.ScaleDownClose},
+			},
+			accounts: []runtime.Object{
+				withoutClaimLink(createAccountMock("account1", "Ready", unclaimed)),
+				withoutClaimLink(createAccountMock("account2", "Ready", unclaimed)),
+				withoutClaimLink(createAccountMock("account3", "Creating", unclaimed)),
+				createAccountMock("account4", "Ready", claimed),
+			},
+			expectedAccounts: 2,
+			expectedRetired:  []string{"account1", "account2"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pool := &awsv1alpha1.AccountPool{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test",
+					Namespace: "aws-account-operator",
+				},
+				Spec: test.spec,
+			}
+			mocks := setupDefaultMocks(t, append(test.accounts, pool))
+			defer mocks.mockCtrl.Finish()
+
+			rap := &AccountPoolReconciler{
+				Client:         mocks.fakeKubeClient,
+				Scheme:         scheme.Scheme,
+				accountWatcher: &mockTAW{accounts: 1, limit: 10},
+				recorder:       &record.FakeRecorder{},
+			}
+
+			_, err := rap.Reconcile(context.TODO(), reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: "test", Namespace: "aws-account-operator"},
+			})
+			assert.NoError(t, err, "Unexpected Error")
+
+			al := awsv1alpha1.AccountList{}
+			err = mocks.fakeKubeClient.List(context.TODO(), &al, client.InNamespace("aws-account-operator"))
+			assert.NoError(t, err, "Unexpected Error")
+			assert.Len(t, al.Items, test.expectedAccounts)
+			for _, name := range test.expectedRetired {
+				acc := awsv1alpha1.Account{}
+				err = mocks.fakeKubeClient.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: "aws-account-operator"}, &acc)
+				assert.True(t, k8serr.IsNotFound(err), "expected account %s to be retired", name)
+			}
+		})
+	}
+}
+
 func verifyAccountPool(c client.Client, expected *awsv1alpha1.AccountPool) bool {
 
 	ap := awsv1alpha1.AccountPool{}
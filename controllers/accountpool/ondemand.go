@@ -0,0 +1,69 @@
+package accountpool
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	awsv1alpha1 "github.com/ravitri/aws-account-operator/api/v1alpha1"
+)
+
+// claimWaitsForPool returns whether the claim waits for an account it can take from the pool
+func claimWaitsForPool(accountClaim *awsv1alpha1.AccountClaim, accountPool *awsv1alpha1.AccountPool) bool {
+	if accountClaim.Status.State != awsv1alpha1.ClaimStatusPending || accountClaim.Spec.AccountLink != "" ||
+		accountClaim.Spec.BYOC || accountClaim.DeletionTimestamp != nil {
+		return false
+	}
+	if accountClaim.Spec.AccountPool != "" {
+		return accountClaim.Spec.AccountPool == accountPool.Name
+	}
+	if accountClaim.Spec.AccountPoolSelector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(accountClaim.Spec.AccountPoolSelector)
+		return err == nil && selector.Matches(labels.Set(accountPool.Labels))
+	}
+	// Claims without pools take their account from any pool
+	return true
+}
+
+// pendingClaims returns the number of claims waiting for an account of the pool
+func (r *AccountPoolReconciler) pendingClaims(accountPool *awsv1alpha1.AccountPool) (int, error) {
+	accountClaims := &awsv1alpha1.AccountClaimList{}
+	if err := r.Client.List(context.TODO(), accountClaims); err != nil {
+		return 0, err
+	}
+	pending := 0
+	for i := range accountClaims.Items {
+		if claimWaitsForPool(&accountClaims.Items[i], accountPool) {
+			pending++
+		}
+	}
+	return pending, nil
+}
+
+// onDemandPoolsForClaim maps a claim waiting for an account to the on-demand pools it can take one
+// from, so they create an account for it
+func (r *AccountPoolReconciler) onDemandPoolsForClaim(obj client.Object) []reconcile.Request {
+	accountClaim, ok := obj.(*awsv1alpha1.AccountClaim)
+	if !ok || accountClaim.Status.State != awsv1alpha1.ClaimStatusPending {
+		return nil
+	}
+	accountPools := &awsv1alpha1.AccountPoolList{}
+	if err := r.Client.List(context.TODO(), accountPools, client.InNamespace(awsv1alpha1.AccountCrNamespace)); err != nil {
+		log.Error(err, "failed listing the accountpools of a pending claim", "accountclaim", accountClaim.Name)
+		return nil
+	}
+	requests := []reconcile.Request{}
+	for i := range accountPools.Items {
+		accountPool := &accountPools.Items[i]
+		if accountPool.Spec.OnDemand && claimWaitsForPool(accountClaim, accountPool) {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: accountPool.Name, Namespace: accountPool.Namespace},
+			})
+		}
+	}
+	return requests
+}
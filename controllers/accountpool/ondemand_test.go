@@ -0,0 +1,116 @@
+package accountpool
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	awsaccountapis "github.com/ravitri/aws-account-operator/api"
+	awsv1alpha1 "github.com/ravitri/aws-account-operator/api/v1alpha1"
+	"github.com/ravitri/aws-account-operator/pkg/localmetrics"
+)
+
+func newPendingClaim(name string, spec awsv1alpha1.AccountClaimSpec) *awsv1alpha1.AccountClaim {
+	return &awsv1alpha1.AccountClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "claims"},
+		Spec:       spec,
+		Status:     awsv1alpha1.AccountClaimStatus{State: awsv1alpha1.ClaimStatusPending},
+	}
+}
+
+func TestClaimWaitsForPool(t *testing.T) {
+	pool := &awsv1alpha1.AccountPool{
+		ObjectMeta: metav1.ObjectMeta{Name: "fedramp", Labels: map[string]string{"partition": "fedramp"}},
+	}
+	ready := newPendingClaim("ready", awsv1alpha1.AccountClaimSpec{})
+	ready.Status.State = awsv1alpha1.ClaimStatusReady
+
+	tests := []struct {
+		name     string
+		claim    *awsv1alpha1.AccountClaim
+		expected bool
+	}{
+		{name: "Claim from any pool", claim: newPendingClaim("any", awsv1alpha1.AccountClaimSpec{}), expected: true},
+		{name: "Claim naming the pool", claim: newPendingClaim("named", awsv1alpha1.AccountClaimSpec{AccountPool: "fedramp"}), expected: true},
+		{name: "Claim naming another pool", claim: newPendingClaim("other", awsv1alpha1.AccountClaimSpec{AccountPool: "default"})},
+		{
+			name: "Claim selecting the pool",
+			claim: newPendingClaim("selected", awsv1alpha1.AccountClaimSpec{
+				AccountPoolSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"partition": "fedramp"}},
+			}),
+			expected: true,
+		},
+		{
+			name: "Claim selecting other pools",
+			claim: newPendingClaim("unselected", awsv1alpha1.AccountClaimSpec{
+				AccountPoolSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"partition": "commercial"}},
+			}),
+		},
+		{name: "Claim with an account", claim: newPendingClaim("linked", awsv1alpha1.AccountClaimSpec{AccountLink: "account"})},
+		{name: "CCS claim", claim: newPendingClaim("ccs", awsv1alpha1.AccountClaimSpec{BYOC: true})},
+		{name: "Ready claim", claim: ready},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, claimWaitsForPool(test.claim, pool))
+		})
+	}
+}
+
+func TestOnDemandPool(t *testing.T) {
+	err := awsaccountapis.AddToScheme(scheme.Scheme)
+	if err != nil {
+		fmt.Printf("failed adding to scheme in ondemand_test.go")
+	}
+	localmetrics.Collector = localmetrics.NewMetricsCollector(nil)
+
+	pool := &awsv1alpha1.AccountPool{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "aws-account-operator"},
+		Spec:       awsv1alpha1.AccountPoolSpec{PoolSize: 0, MaxInFlight: 5, OnDemand: true},
+	}
+	objs := []runtime.Object{
+		pool,
+		newPendingClaim("claim1", awsv1alpha1.AccountClaimSpec{}),
+		newPendingClaim("claim2", awsv1alpha1.AccountClaimSpec{AccountPool: "test"}),
+		newPendingClaim("claim3", awsv1alpha1.AccountClaimSpec{AccountPool: "other"}),
+	}
+	mocks := setupDefaultMocks(t, objs)
+	defer mocks.mockCtrl.Finish()
+
+	rap := &AccountPoolReconciler{
+		Client:         mocks.fakeKubeClient,
+		Scheme:         scheme.Scheme,
+		accountWatcher: &mockTAW{accounts: 1, limit: 10},
+		recorder:       &record.FakeRecorder{},
+	}
+
+	// A claim waiting for the pool triggers its reconcile
+	requests := rap.onDemandPoolsForClaim(objs[1].(client.Object))
+	assert.Equal(t, []reconcile.Request{{NamespacedName: types.NamespacedName{Name: "test", Namespace: "aws-account-operator"}}}, requests)
+	assert.Empty(t, rap.onDemandPoolsForClaim(objs[3].(client.Object)))
+
+	// The pool creates an account for each of the claims waiting for it
+	_, err = rap.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "test", Namespace: "aws-account-operator"}})
+	assert.NoError(t, err)
+
+	updated := &awsv1alpha1.AccountPool{}
+	err = mocks.fakeKubeClient.Get(context.TODO(), types.NamespacedName{Name: "test", Namespace: "aws-account-operator"}, updated)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, updated.Status.PendingClaims)
+	assert.Equal(t, 2, updated.Status.PoolSize)
+
+	accounts := &awsv1alpha1.AccountList{}
+	err = mocks.fakeKubeClient.List(context.TODO(), accounts, client.InNamespace("aws-account-operator"))
+	assert.NoError(t, err)
+	assert.Len(t, accounts.Items, 2)
+}
@@ -0,0 +1,96 @@
+package accountpool
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	awsv1alpha1 "github.com/ravitri/aws-account-operator/api/v1alpha1"
+	"github.com/ravitri/aws-account-operator/pkg/utils"
+)
+
+// scaleDown retires up to surplus never-claimed accounts of the pool with its scale down policy.
+// The Accounts are annotated with the action and deleted, the Account finalizer parks or closes
+// the AWS accounts. Only Ready accounts are retired, accounts still being created finish first.
+func (r *AccountPoolReconciler) scaleDown(reqLogger logr.Logger, currentAccountPool *awsv1alpha1.AccountPool, surplus int) error {
+	policy := currentAccountPool.Spec.ScaleDown
+	if policy == nil || policy.Action == awsv1alpha1.ScaleDownRetain {
+		reqLogger.Info(fmt.Sprintf("keeping %d surplus accounts, the pool has no scale down policy", surplus))
+		return nil
+	}
+
+	accounts, err := r.getRetirableAccounts(currentAccountPool.Name)
+	if err != nil {
+		return err
+	}
+
+	for i := 0; i < surplus && i < len(accounts); i++ {
+		err := r.retireAccount(reqLogger, currentAccountPool, &accounts[i])
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getRetirableAccounts returns the Ready never-claimed accounts of the pool, newest first
+func (r *AccountPoolReconciler) getRetirableAccounts(poolName string) ([]awsv1alpha1.Account, error) {
+	accountList := &awsv1alpha1.AccountList{}
+	listOpts := []client.ListOption{
+		client.InNamespace(awsv1alpha1.AccountCrNamespace),
+	}
+	if err := r.Client.List(context.TODO(), accountList, listOpts...); err != nil {
+		return nil, err
+	}
+
+	var accounts []awsv1alpha1.Account
+	for _, account := range accountList.Items {
+		if account.GetAccountPoolName() != poolName || account.IsPendingDeletion() || account.IsBYOC() {
+			continue
+		}
+		if account.HasNeverBeenClaimed() && account.Spec.ClaimLink == "" && account.IsReady() {
+			accounts = append(accounts, account)
+		}
+	}
+
+	sort.SliceStable(accounts, func(i, j int) bool {
+		return accounts[j].CreationTimestamp.Before(&accounts[i].CreationTimestamp)
+	})
+	return accounts, nil
+}
+
+// retireAccount annotates the account with the scale down action of the pool and deletes it.
+// The delete is conditioned on the annotated version, so an account claimed in between is kept.
+func (r *AccountPoolReconciler) retireAccount(reqLogger logr.Logger, currentAccountPool *awsv1alpha1.AccountPool, account *awsv1alpha1.Account) error {
+	policy := currentAccountPool.Spec.ScaleDown
+
+	annotations := account.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[awsv1alpha1.RetireAnnotation] = string(policy.Action)
+	if policy.Action == awsv1alpha1.ScaleDownPark {
+		annotations[awsv1alpha1.HoldingOUAnnotation] = policy.HoldingOU
+	}
+	account.SetAnnotations(annotations)
+	if err := r.Client.Update(context.TODO(), account); err != nil {
+		reqLogger.Error(err, "failed annotating surplus account", "account", account.Name)
+		return err
+	}
+
+	resourceVersion := account.ResourceVersion
+	err := r.Client.Delete(context.TODO(), account, client.Preconditions{ResourceVersion: &resourceVersion})
+	if err != nil {
+		reqLogger.Error(err, "failed deleting surplus account", "account", account.Name)
+		return err
+	}
+
+	reqLogger.Info("retiring surplus account", "account", account.Name, "action", policy.Action)
+	r.recorder.Eventf(currentAccountPool, corev1.EventTypeNormal, utils.EventReasonAccountRetired,
+		"Retiring surplus account %s, action %s", account.Name, policy.Action)
+	return nil
+}
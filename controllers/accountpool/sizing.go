@@ -0,0 +1,85 @@
+package accountpool
+
+import (
+	"math"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	awsv1alpha1 "github.com/ravitri/aws-account-operator/api/v1alpha1"
+	"github.com/ravitri/aws-account-operator/pkg/localmetrics"
+)
+
+const (
+	// defaultDemandPeriod is the lookback period and lead time of demand sizing when they're not set
+	defaultDemandPeriod = time.Hour
+	// demandRequeueInterval is how often a pool with demand sizing is resized
+	demandRequeueInterval = 5 * time.Minute
+)
+
+// poolSizing is the size the pool should have at a point in time, and why
+type poolSizing struct {
+	poolSize       int
+	activeWindow   string
+	demandPoolSize int
+	// requeueAfter is when the size may change next, zero when it only changes with the spec
+	requeueAfter time.Duration
+}
+
+// calculatePoolSize returns the desired pool size at now. An active schedule window replaces
+// spec.poolSize, and demand sizing grows the pool past that size when claims come in faster.
+func calculatePoolSize(reqLogger logr.Logger, currentAccountPool *awsv1alpha1.AccountPool, now time.Time) poolSizing {
+	sizing := poolSizing{poolSize: currentAccountPool.Spec.PoolSize}
+
+	if schedule := currentAccountPool.Spec.Schedule; schedule != nil {
+		window, err := schedule.ActiveWindow(now)
+		if err != nil {
+			// The webhook rejects invalid schedules, fall back to poolSize if one got through
+			reqLogger.Error(err, "invalid accountpool schedule, using poolSize")
+		} else {
+			if window != nil {
+				sizing.poolSize = window.PoolSize
+				sizing.activeWindow = window.Name
+			}
+			next, err := schedule.NextTransition(now)
+			if err == nil && !next.IsZero() {
+				sizing.requeueAfter = next.Sub(now)
+			}
+		}
+	}
+
+	if demand := currentAccountPool.Spec.Demand; demand != nil {
+		sizing.demandPoolSize = demandPoolSize(demand, now)
+		if sizing.demandPoolSize > sizing.poolSize {
+			sizing.poolSize = sizing.demandPoolSize
+		}
+		if sizing.requeueAfter == 0 || sizing.requeueAfter > demandRequeueInterval {
+			sizing.requeueAfter = demandRequeueInterval
+		}
+	}
+
+	return sizing
+}
+
+// demandPoolSize returns the number of accounts needed to serve claims for the lead time,
+// at the rate accountClaims became Ready over the lookback period
+func demandPoolSize(demand *awsv1alpha1.DemandSizing, now time.Time) int {
+	if localmetrics.Collector == nil {
+		return 0
+	}
+	lookback := demand.LookbackPeriod.Duration
+	if lookback <= 0 {
+		lookback = defaultDemandPeriod
+	}
+	leadTime := demand.LeadTime.Duration
+	if leadTime <= 0 {
+		leadTime = defaultDemandPeriod
+	}
+
+	claims := localmetrics.Collector.AccountClaimsReadySince(now.Add(-lookback))
+	size := int(math.Ceil(float64(claims) * leadTime.Seconds() / lookback.Seconds()))
+	if demand.MaxPoolSize > 0 && size > demand.MaxPoolSize {
+		size = demand.MaxPoolSize
+	}
+	return size
+}
@@ -0,0 +1,80 @@
+package accountpool
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	awsv1alpha1 "github.com/ravitri/aws-account-operator/api/v1alpha1"
+	"github.com/ravitri/aws-account-operator/pkg/localmetrics"
+	"github.com/ravitri/aws-account-operator/pkg/testutils"
+)
+
+func TestCalculatePoolSize(t *testing.T) {
+	// 2022-06-03 is a Friday
+	now := time.Date(2022, 6, 3, 12, 0, 0, 0, time.UTC)
+	businessHours := &awsv1alpha1.PoolSchedule{
+		Windows: []awsv1alpha1.PoolSizeWindow{{Name: "business-hours", Days: "Mon-Fri", Start: "08:00", End: "18:00", PoolSize: 20}},
+	}
+
+	tests := []struct {
+		name     string
+		spec     awsv1alpha1.AccountPoolSpec
+		claims   int
+		expected poolSizing
+	}{
+		{
+			name:     "Uses poolSize without a schedule or demand sizing",
+			spec:     awsv1alpha1.AccountPoolSpec{PoolSize: 5},
+			expected: poolSizing{poolSize: 5},
+		},
+		{
+			name:     "Uses the pool size of the active window",
+			spec:     awsv1alpha1.AccountPoolSpec{PoolSize: 5, Schedule: businessHours},
+			expected: poolSizing{poolSize: 20, activeWindow: "business-hours", requeueAfter: 6 * time.Hour},
+		},
+		{
+			name: "Uses poolSize outside of the windows",
+			spec: awsv1alpha1.AccountPoolSpec{PoolSize: 5, Schedule: &awsv1alpha1.PoolSchedule{
+				Windows: []awsv1alpha1.PoolSizeWindow{{Name: "weekend", Days: "Sat,Sun", Start: "00:00", End: "00:00", PoolSize: 2}},
+			}},
+			expected: poolSizing{poolSize: 5, requeueAfter: 12 * time.Hour},
+		},
+		{
+			name: "Grows the pool with the claim rate",
+			spec: awsv1alpha1.AccountPoolSpec{PoolSize: 5, Demand: &awsv1alpha1.DemandSizing{
+				LookbackPeriod: metav1.Duration{Duration: time.Hour},
+				LeadTime:       metav1.Duration{Duration: 2 * time.Hour},
+			}},
+			claims:   4,
+			expected: poolSizing{poolSize: 8, demandPoolSize: 8, requeueAfter: demandRequeueInterval},
+		},
+		{
+			name:     "Caps demand sizing at maxPoolSize",
+			spec:     awsv1alpha1.AccountPoolSpec{PoolSize: 5, Demand: &awsv1alpha1.DemandSizing{MaxPoolSize: 6}},
+			claims:   10,
+			expected: poolSizing{poolSize: 6, demandPoolSize: 6, requeueAfter: demandRequeueInterval},
+		},
+		{
+			name:     "Never shrinks the pool below the scheduled size",
+			spec:     awsv1alpha1.AccountPoolSpec{PoolSize: 5, Schedule: businessHours, Demand: &awsv1alpha1.DemandSizing{}},
+			claims:   3,
+			expected: poolSizing{poolSize: 20, activeWindow: "business-hours", demandPoolSize: 3, requeueAfter: demandRequeueInterval},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			localmetrics.Collector = localmetrics.NewMetricsCollector(nil)
+			for i := 0; i < test.claims; i++ {
+				localmetrics.Collector.SetAccountClaimReadyDuration(false, 60)
+			}
+			pool := &awsv1alpha1.AccountPool{Spec: test.spec}
+
+			sizing := calculatePoolSize(testutils.NewTestLogger().Logger(), pool, now)
+			assert.Equal(t, test.expected, sizing)
+		})
+	}
+}
@@ -17,6 +17,7 @@ import (
 	k8serr "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
@@ -49,6 +50,7 @@ type AWSFederatedAccountAccessReconciler struct {
 	client.Client
 	Scheme           *runtime.Scheme
 	awsClientBuilder awsclient.IBuilder
+	recorder         record.EventRecorder
 }
 
 //+kubebuilder:rbac:groups=aws.managed.openshift.io,resources=awsfederatedaccountaccesses,verbs=get;list;watch;create;update;patch;delete
@@ -85,7 +87,7 @@ func (r *AWSFederatedAccountAccessReconciler) Reconcile(ctx context.Context, req
 	err = r.Client.Get(context.TODO(), types.NamespacedName{Name: currentFAA.Spec.AWSFederatedRole.Name, Namespace: currentFAA.Spec.AWSFederatedRole.Namespace}, requestedRole)
 	if err != nil {
 		if k8serr.IsNotFound(err) {
-			SetStatuswithCondition(currentFAA, "Requested role does not exist", awsv1alpha1.AWSFederatedAccountFailed, awsv1alpha1.AWSFederatedAccountStateFailed)
+			r.setStatus(currentFAA, "Requested role does not exist", awsv1alpha1.AWSFederatedAccountFailed, awsv1alpha1.AWSFederatedAccountStateFailed)
 			reqLogger.Error(ErrFederatedAccessRoleNotFound, fmt.Sprintf("Requested role %s not found", currentFAA.Spec.AWSFederatedRole.Name))
 
 			err := r.Client.Status().Update(context.TODO(), currentFAA)
@@ -178,7 +180,7 @@ func (r *AWSFederatedAccountAccessReconciler) Reconcile(ctx context.Context, req
 	// Get account number of cluster account
 	gciOut, err := awsClient.GetCallerIdentity(&sts.GetCallerIdentityInput{})
 	if err != nil {
-		SetStatuswithCondition(currentFAA, "Failed to get account ID information", awsv1alpha1.AWSFederatedAccountFailed, awsv1alpha1.AWSFederatedAccountStateFailed)
+		r.setStatus(currentFAA, "Failed to get account ID information", awsv1alpha1.AWSFederatedAccountFailed, awsv1alpha1.AWSFederatedAccountStateFailed)
 		controllerutils.LogAwsError(log, fmt.Sprintf("Failed to get account ID information for '%s'", currentFAA.Name), err, err)
 		err := r.Client.Status().Update(context.TODO(), currentFAA)
 		if err != nil {
@@ -215,7 +217,7 @@ func (r *AWSFederatedAccountAccessReconciler) Reconcile(ctx context.Context, req
 	err = r.createOrUpdateIAMPolicy(awsClient, *requestedRole, *currentFAA)
 	if err != nil {
 		// if we were unable to create the policy fail this CR.
-		SetStatuswithCondition(currentFAA, "Failed to create custom policy", awsv1alpha1.AWSFederatedAccountFailed, awsv1alpha1.AWSFederatedAccountStateFailed)
+		r.setStatus(currentFAA, "Failed to create custom policy", awsv1alpha1.AWSFederatedAccountFailed, awsv1alpha1.AWSFederatedAccountStateFailed)
 		reqLogger.Error(err, fmt.Sprintf("Unable to create policy requested by '%s'", currentFAA.Name))
 
 		err := r.Client.Status().Update(context.TODO(), currentFAA)
@@ -231,7 +233,7 @@ func (r *AWSFederatedAccountAccessReconciler) Reconcile(ctx context.Context, req
 	role, err := r.createOrUpdateIAMRole(awsClient, *requestedRole, *currentFAA, reqLogger)
 
 	if err != nil {
-		SetStatuswithCondition(currentFAA, "Failed to create role", awsv1alpha1.AWSFederatedAccountFailed, awsv1alpha1.AWSFederatedAccountStateFailed)
+		r.setStatus(currentFAA, "Failed to create role", awsv1alpha1.AWSFederatedAccountFailed, awsv1alpha1.AWSFederatedAccountStateFailed)
 		reqLogger.Error(ErrFederatedAccessRoleFailedCreate, fmt.Sprintf("Unable to create role requested by '%s'", currentFAA.Name), "AWS ERROR: ", err)
 
 		err := r.Client.Status().Update(context.TODO(), currentFAA)
@@ -260,7 +262,7 @@ func (r *AWSFederatedAccountAccessReconciler) Reconcile(ctx context.Context, req
 	if err != nil {
 		//TODO() role should be deleted here so that we leave nothing behind.
 
-		SetStatuswithCondition(currentFAA, "Failed to attach policies to role", awsv1alpha1.AWSFederatedAccountFailed, awsv1alpha1.AWSFederatedAccountStateFailed)
+		r.setStatus(currentFAA, "Failed to attach policies to role", awsv1alpha1.AWSFederatedAccountFailed, awsv1alpha1.AWSFederatedAccountStateFailed)
 		reqLogger.Error(err, fmt.Sprintf("Failed to attach policies to role requested by '%s'", currentFAA.Name))
 		err := r.Client.Status().Update(context.TODO(), currentFAA)
 		if err != nil {
@@ -271,7 +273,7 @@ func (r *AWSFederatedAccountAccessReconciler) Reconcile(ctx context.Context, req
 		return reconcile.Result{}, nil
 	}
 	// Mark AWSFederatedAccountAccess CR as Ready.
-	SetStatuswithCondition(currentFAA, "Account Access Ready", awsv1alpha1.AWSFederatedAccountReady, awsv1alpha1.AWSFederatedAccountStateReady)
+	r.setStatus(currentFAA, "Account Access Ready", awsv1alpha1.AWSFederatedAccountReady, awsv1alpha1.AWSFederatedAccountStateReady)
 	reqLogger.Info(fmt.Sprintf("Successfully applied %s", currentFAA.Name))
 	err = r.Client.Status().Update(context.TODO(), currentFAA)
 	if err != nil {
@@ -501,6 +503,16 @@ func SetStatuswithCondition(afaa *awsv1alpha1.AWSFederatedAccountAccess, message
 	afaa.Status.State = state
 }
 
+// setStatus sets the status of the AWSFederatedAccountAccess and records an Event for the new state
+func (r *AWSFederatedAccountAccessReconciler) setStatus(afaa *awsv1alpha1.AWSFederatedAccountAccess, message string, ctype awsv1alpha1.AWSFederatedAccountAccessConditionType, state awsv1alpha1.AWSFederatedAccountAccessState) {
+	eventType := corev1.EventTypeNormal
+	if state == awsv1alpha1.AWSFederatedAccountStateFailed {
+		eventType = corev1.EventTypeWarning
+	}
+	r.recorder.Event(afaa, eventType, string(ctype), message)
+	SetStatuswithCondition(afaa, message, ctype, state)
+}
+
 func (r *AWSFederatedAccountAccessReconciler) addFinalizer(reqLogger logr.Logger, awsFederatedAccountAccess *awsv1alpha1.AWSFederatedAccountAccess) error {
 	reqLogger.Info("Adding Finalizer for the AccountClaim")
 	awsFederatedAccountAccess.SetFinalizers(append(awsFederatedAccountAccess.GetFinalizers(), controllerutils.Finalizer))
@@ -761,6 +773,7 @@ func getPolicyNameWithUID(awsCustomPolicyname string, crPolicyName string, uidLa
 // SetupWithManager sets up the controller with the Manager.
 func (r *AWSFederatedAccountAccessReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	r.awsClientBuilder = &awsclient.Builder{}
+	r.recorder = mgr.GetEventRecorderFor(controllerName)
 	maxReconciles, err := controllerutils.GetControllerMaxReconciles(controllerName)
 	if err != nil {
 		log.Error(err, "missing max reconciles for controller", "controller", controllerName)
@@ -8,8 +8,10 @@ import (
 	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/iam"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
@@ -38,6 +40,7 @@ type AWSFederatedRoleReconciler struct {
 	client.Client
 	Scheme           *runtime.Scheme
 	awsClientBuilder awsclient.IBuilder
+	recorder         record.EventRecorder
 }
 
 //+kubebuilder:rbac:groups=aws.managed.openshift.io,resources=awsfederatedroles,verbs=get;list;watch;create;update;patch;delete
@@ -136,6 +139,7 @@ func (r *AWSFederatedRoleReconciler) Reconcile(ctx context.Context, request ctrl
 			"NoAWSCustomPolicyOrAWSManagedPolicies",
 			"AWSCustomPolicy and/or AWSManagedPolicies do not exist",
 			utils.UpdateConditionNever)
+		r.recorder.Event(instance, corev1.EventTypeWarning, "NoAWSCustomPolicyOrAWSManagedPolicies", "AWSCustomPolicy and/or AWSManagedPolicies do not exist")
 		err = r.Client.Status().Update(context.TODO(), instance)
 		if err != nil {
 			log.Error(err, "Error updating conditions")
@@ -165,6 +169,7 @@ func (r *AWSFederatedRoleReconciler) Reconcile(ctx context.Context, request ctrl
 					"InvalidCustomerPolicy",
 					"Custom Policy is malformed",
 					utils.UpdateConditionNever)
+				r.recorder.Eventf(instance, corev1.EventTypeWarning, "InvalidCustomerPolicy", "Custom policy %s is malformed: %v", instance.Spec.AWSCustomPolicy.Name, aerr.Message())
 				err = r.Client.Status().Update(context.TODO(), instance)
 				if err != nil {
 					log.Error(err, "Error updating conditions")
@@ -212,6 +217,7 @@ func (r *AWSFederatedRoleReconciler) Reconcile(ctx context.Context, request ctrl
 				"InvalidManagedPolicy",
 				"Managed policy does not exist",
 				utils.UpdateConditionNever)
+			r.recorder.Eventf(instance, corev1.EventTypeWarning, "InvalidManagedPolicy", "Managed policy %s does not exist", policy)
 			err = r.Client.Status().Update(context.TODO(), instance)
 			if err != nil {
 				log.Error(err, "Error updating conditions")
@@ -232,6 +238,7 @@ func (r *AWSFederatedRoleReconciler) Reconcile(ctx context.Context, request ctrl
 		"AllPoliciesValid",
 		"All managed and custom policies are validated",
 		utils.UpdateConditionNever)
+	r.recorder.Event(instance, corev1.EventTypeNormal, "AllPoliciesValid", "All managed and custom policies are validated")
 	err = r.Client.Status().Update(context.TODO(), instance)
 	if err != nil {
 		log.Error(err, "Error updating conditions")
@@ -297,6 +304,7 @@ func policyInSlice(policy string, policyList []string) bool {
 // SetupWithManager sets up the controller with the Manager.
 func (r *AWSFederatedRoleReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	r.awsClientBuilder = &awsclient.Builder{}
+	r.recorder = mgr.GetEventRecorderFor(controllerName)
 	maxReconciles, err := utils.GetControllerMaxReconciles(controllerName)
 	if err != nil {
 		log.Error(err, "missing max reconciles for controller", "controller", controllerName)
@@ -0,0 +1,176 @@
+package regioncatalog
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	awsv1alpha1 "github.com/ravitri/aws-account-operator/api/v1alpha1"
+	"github.com/ravitri/aws-account-operator/config"
+	"github.com/ravitri/aws-account-operator/pkg/awsclient"
+	"github.com/ravitri/aws-account-operator/pkg/utils"
+)
+
+const (
+	controllerName = "regioncatalog"
+	// amiVerificationInterval is how often the AMIs of a catalog are verified again, as AMIs can be
+	// deregistered at any time
+	amiVerificationInterval = 24 * time.Hour
+)
+
+var (
+	log           = logf.Log.WithName("controller_regioncatalog")
+	awsSecretName = "aws-account-operator-credentials" //  #nosec G101 -- This is a false positive
+)
+
+// RegionCatalogReconciler reconciles a RegionCatalog object
+type RegionCatalogReconciler struct {
+	client.Client
+	Scheme           *runtime.Scheme
+	awsClientBuilder awsclient.IBuilder
+	recorder         record.EventRecorder
+}
+
+//+kubebuilder:rbac:groups=aws.managed.openshift.io,resources=regioncatalogs,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=aws.managed.openshift.io,resources=regioncatalogs/status,verbs=get;update;patch
+
+// Reconcile verifies that the AMIs of a RegionCatalog exist, when its spec changed or once a day,
+// and records the outcome in its status
+func (r *RegionCatalogReconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
+	reqLogger := log.WithValues("Controller", controllerName, "Request.Name", request.Name)
+
+	catalog := &awsv1alpha1.RegionCatalog{}
+	err := r.Client.Get(context.TODO(), request.NamespacedName, catalog)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	if catalog.Status.ObservedGeneration == catalog.Generation && catalog.Status.LastVerificationTime != nil {
+		sinceVerification := time.Since(catalog.Status.LastVerificationTime.Time)
+		if sinceVerification < amiVerificationInterval {
+			return reconcile.Result{RequeueAfter: amiVerificationInterval - sinceVerification}, nil
+		}
+	}
+
+	reqLogger.Info("verifying the AMIs of the region catalog")
+	catalog.Status.AMIs = r.verifyAMIs(reqLogger, catalog)
+	now := metav1.Now()
+	catalog.Status.LastVerificationTime = &now
+	catalog.Status.ObservedGeneration = catalog.Generation
+	err = r.Client.Status().Update(context.TODO(), catalog)
+	if err != nil {
+		reqLogger.Error(err, "failed updating the region catalog status")
+		return reconcile.Result{}, err
+	}
+	return reconcile.Result{RequeueAfter: amiVerificationInterval}, nil
+}
+
+// verifyAMIs returns the outcome of the verification of every AMI of the catalog. Regions outside of
+// the partition of the operator can't be verified with its credentials and are skipped.
+func (r *RegionCatalogReconciler) verifyAMIs(reqLogger logr.Logger, catalog *awsv1alpha1.RegionCatalog) []awsv1alpha1.CatalogAMIStatus {
+	statuses := []awsv1alpha1.CatalogAMIStatus{}
+	for _, region := range catalog.Spec.Regions {
+		if region.GetPartition() != config.GetPartition() {
+			reqLogger.Info("skipping the verification of a region outside of the operator partition", "region", region.Name, "partition", region.GetPartition())
+			continue
+		}
+
+		awsClient, err := r.awsClientBuilder.GetClient(controllerName, r.Client, awsclient.NewAwsClientInput{
+			SecretName: awsSecretName,
+			NameSpace:  awsv1alpha1.AccountCrNamespace,
+			AwsRegion:  region.Name,
+		})
+		for _, ami := range region.AMIs {
+			status := awsv1alpha1.CatalogAMIStatus{Region: region.Name, Architecture: ami.Architecture, ID: ami.ID}
+			// An AMI that can't be verified keeps the outcome of its last verification
+			if previous := catalog.GetAMIStatus(region.Name, ami.ID); previous != nil {
+				status.Exists = previous.Exists
+				status.LastVerifiedTime = previous.LastVerifiedTime
+			}
+			if err != nil {
+				reqLogger.Error(err, "failed building an AWS client", "region", region.Name)
+				status.Message = fmt.Sprintf("Unable to connect to region %s: %s", region.Name, err)
+			} else {
+				verifyAMI(reqLogger, awsClient, &status)
+			}
+			if status.LastVerifiedTime != nil && !status.Exists {
+				r.recorder.Eventf(catalog, corev1.EventTypeWarning, utils.EventReasonAMINotFound, "AMI %s of region %s: %s", ami.ID, region.Name, status.Message)
+			}
+			statuses = append(statuses, status)
+		}
+	}
+	return statuses
+}
+
+// verifyAMI records in status whether the AMI exists and is available
+func verifyAMI(reqLogger logr.Logger, awsClient awsclient.Client, status *awsv1alpha1.CatalogAMIStatus) {
+	output, err := awsClient.DescribeImages(&ec2.DescribeImagesInput{
+		ImageIds: aws.StringSlice([]string{status.ID}),
+	})
+	if err != nil {
+		if aerr, ok := err.(awserr.Error); ok {
+			switch aerr.Code() {
+			case "InvalidAMIID.NotFound", "InvalidAMIID.Unavailable", "InvalidAMIID.Malformed":
+				now := metav1.Now()
+				status.Exists = false
+				status.LastVerifiedTime = &now
+				status.Message = aerr.Message()
+				return
+			}
+		}
+		reqLogger.Error(err, "failed describing AMI", "region", status.Region, "ami", status.ID)
+		status.Message = fmt.Sprintf("Unable to describe AMI: %s", err)
+		return
+	}
+
+	now := metav1.Now()
+	status.LastVerifiedTime = &now
+	status.Exists = false
+	status.Message = "AMI not found"
+	for _, image := range output.Images {
+		if aws.StringValue(image.ImageId) != status.ID {
+			continue
+		}
+		state := aws.StringValue(image.State)
+		status.Exists = state == ec2.ImageStateAvailable
+		status.Message = ""
+		if !status.Exists {
+			status.Message = fmt.Sprintf("AMI is %s", state)
+		}
+	}
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *RegionCatalogReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	r.awsClientBuilder = &awsclient.Builder{}
+	r.recorder = mgr.GetEventRecorderFor(controllerName)
+	maxReconciles, err := utils.GetControllerMaxReconciles(controllerName)
+	if err != nil {
+		log.Error(err, "missing max reconciles for controller", "controller", controllerName)
+	}
+
+	rwm := utils.NewReconcilerWithMetrics(r, controllerName)
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&awsv1alpha1.RegionCatalog{}).
+		WithOptions(controller.Options{
+			MaxConcurrentReconciles: maxReconciles,
+		}).Complete(rwm)
+}
@@ -0,0 +1,114 @@
+package regioncatalog
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	apis "github.com/ravitri/aws-account-operator/api"
+	awsv1alpha1 "github.com/ravitri/aws-account-operator/api/v1alpha1"
+	"github.com/ravitri/aws-account-operator/pkg/awsclient/mock"
+	"github.com/ravitri/aws-account-operator/pkg/testutils"
+)
+
+func newTestCatalog() *awsv1alpha1.RegionCatalog {
+	return &awsv1alpha1.RegionCatalog{
+		ObjectMeta: metav1.ObjectMeta{Name: "default", Generation: 1},
+		Spec: awsv1alpha1.RegionCatalogSpec{
+			Regions: []awsv1alpha1.CatalogRegion{
+				{
+					Name:         "us-east-1",
+					InstanceType: "t2.micro",
+					AMIs: []awsv1alpha1.ArchitectureAMI{
+						{Architecture: awsv1alpha1.ArchitectureX8664, ID: "ami-000db10762d0c4c05"},
+						{Architecture: awsv1alpha1.ArchitectureArm64, ID: "ami-0123456789abcdef0"},
+					},
+				},
+				{
+					Name:         "us-gov-west-1",
+					Partition:    awsv1alpha1.PartitionAWSUSGov,
+					InstanceType: "t2.micro",
+					AMIs:         []awsv1alpha1.ArchitectureAMI{{Architecture: awsv1alpha1.ArchitectureX8664, ID: "ami-0fedcba9876543210"}},
+				},
+			},
+		},
+	}
+}
+
+func TestReconcileVerifiesAMIs(t *testing.T) {
+	err := apis.AddToScheme(scheme.Scheme)
+	assert.NoError(t, err)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	builder := &mock.Builder{MockController: ctrl}
+	mockAWSClient := mock.GetMockClient(builder)
+
+	// Regions outside of the partition of the operator aren't verified
+	mockAWSClient.EXPECT().DescribeImages(&ec2.DescribeImagesInput{
+		ImageIds: aws.StringSlice([]string{"ami-000db10762d0c4c05"}),
+	}).Return(&ec2.DescribeImagesOutput{
+		Images: []*ec2.Image{{ImageId: aws.String("ami-000db10762d0c4c05"), State: aws.String(ec2.ImageStateAvailable)}},
+	}, nil)
+	mockAWSClient.EXPECT().DescribeImages(&ec2.DescribeImagesInput{
+		ImageIds: aws.StringSlice([]string{"ami-0123456789abcdef0"}),
+	}).Return(nil, awserr.New("InvalidAMIID.NotFound", "The image id '[ami-0123456789abcdef0]' does not exist", nil))
+
+	catalog := newTestCatalog()
+	r := &RegionCatalogReconciler{
+		Client:           fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(catalog).Build(),
+		Scheme:           scheme.Scheme,
+		awsClientBuilder: builder,
+		recorder:         &record.FakeRecorder{},
+	}
+	request := reconcile.Request{NamespacedName: types.NamespacedName{Name: catalog.Name}}
+	result, err := r.Reconcile(context.TODO(), request)
+	assert.NoError(t, err)
+	assert.Equal(t, amiVerificationInterval, result.RequeueAfter)
+
+	updated := &awsv1alpha1.RegionCatalog{}
+	err = r.Client.Get(context.TODO(), request.NamespacedName, updated)
+	assert.NoError(t, err)
+	assert.NotNil(t, updated.Status.LastVerificationTime)
+	assert.Equal(t, updated.Generation, updated.Status.ObservedGeneration)
+	assert.Len(t, updated.Status.AMIs, 2)
+	assert.True(t, updated.Status.AMIs[0].Exists)
+	assert.False(t, updated.Status.AMIs[1].Exists)
+	assert.NotNil(t, updated.Status.AMIs[1].LastVerifiedTime)
+
+	// The AMIs aren't verified again until the next interval
+	result, err = r.Reconcile(context.TODO(), request)
+	assert.NoError(t, err)
+	assert.True(t, result.RequeueAfter > 0 && result.RequeueAfter <= amiVerificationInterval)
+}
+
+func TestVerifyAMIKeepsLastOutcomeOnErrors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockAWSClient := mock.NewMockClient(ctrl)
+	mockAWSClient.EXPECT().DescribeImages(gomock.Any()).Return(nil, awserr.New("RequestLimitExceeded", "Request limit exceeded", nil))
+
+	lastVerified := metav1.NewTime(time.Now().Add(-24 * time.Hour))
+	status := &awsv1alpha1.CatalogAMIStatus{
+		Region:           "us-east-1",
+		ID:               "ami-000db10762d0c4c05",
+		Exists:           true,
+		LastVerifiedTime: &lastVerified,
+	}
+	verifyAMI(testutils.NewTestLogger().Logger(), mockAWSClient, status)
+	assert.True(t, status.Exists)
+	assert.Equal(t, &lastVerified, status.LastVerifiedTime)
+	assert.Contains(t, status.Message, "RequestLimitExceeded")
+}
@@ -10,7 +10,9 @@ import (
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/organizations"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
@@ -38,6 +40,7 @@ type AccountValidationReconciler struct {
 	Client           client.Client
 	Scheme           *runtime.Scheme
 	awsClientBuilder awsclient.IBuilder
+	recorder         record.EventRecorder
 }
 
 type ValidationError int64
@@ -56,11 +59,12 @@ type AccountValidationError struct {
 	Err  error
 }
 
-func NewAccountValidationReconciler(client client.Client, scheme *runtime.Scheme, awsClientBuilder awsclient.IBuilder) *AccountValidationReconciler {
+func NewAccountValidationReconciler(client client.Client, scheme *runtime.Scheme, awsClientBuilder awsclient.IBuilder, recorder record.EventRecorder) *AccountValidationReconciler {
 	return &AccountValidationReconciler{
 		Client:           client,
 		Scheme:           scheme,
 		awsClientBuilder: awsClientBuilder,
+		recorder:         recorder,
 	}
 }
 
@@ -333,6 +337,7 @@ func (r *AccountValidationReconciler) Reconcile(ctx context.Context, request ctr
 
 	err = r.ValidateAccountOU(awsClient, account, cm.Data["root"])
 	if err != nil {
+		r.recorder.Event(&account, corev1.EventTypeWarning, utils.EventReasonValidationFailed, err.Error())
 		// Decide who we will requeue now
 		validationError, ok := err.(*AccountValidationError)
 		if ok && validationError.Type == AccountMoveFailed {
@@ -350,6 +355,7 @@ func (r *AccountValidationReconciler) Reconcile(ctx context.Context, request ctr
 		} else {
 			err = ValidateAccountTags(awsClient, aws.String(account.Spec.AwsAccountID), shardName, accountTagEnabled)
 			if err != nil {
+				r.recorder.Event(&account, corev1.EventTypeWarning, utils.EventReasonValidationFailed, err.Error())
 				validationError, ok := err.(*AccountValidationError)
 				if ok && (validationError.Type == MissingTag || validationError.Type == IncorrectOwnerTag) {
 					log.Error(validationError, validationError.Err.Error())
@@ -364,6 +370,7 @@ func (r *AccountValidationReconciler) Reconcile(ctx context.Context, request ctr
 // SetupWithManager sets up the controller with the Manager.
 func (r *AccountValidationReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	r.awsClientBuilder = &awsclient.Builder{}
+	r.recorder = mgr.GetEventRecorderFor(controllerName)
 	maxReconciles, err := utils.GetControllerMaxReconciles(controllerName)
 	if err != nil {
 		log.Error(err, "missing max reconciles for controller", "controller", controllerName)
@@ -19,6 +19,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -629,6 +630,7 @@ func TestValidateAccount_Reconcile(t *testing.T) {
 				Client:           tt.fields.Client,
 				Scheme:           tt.fields.scheme,
 				awsClientBuilder: tt.fields.awsClientBuilder,
+				recorder:         &record.FakeRecorder{},
 			}
 			got, err := r.Reconcile(context.TODO(), tt.args.request)
 			if (err != nil) != tt.wantErr {
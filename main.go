@@ -40,6 +40,7 @@ import (
 	"github.com/ravitri/aws-account-operator/controllers/accountpool"
 	"github.com/ravitri/aws-account-operator/controllers/awsfederatedaccountaccess"
 	"github.com/ravitri/aws-account-operator/controllers/awsfederatedrole"
+	"github.com/ravitri/aws-account-operator/controllers/regioncatalog"
 	"github.com/ravitri/aws-account-operator/controllers/validation"
 	"github.com/ravitri/aws-account-operator/pkg/awsclient"
 	"github.com/ravitri/aws-account-operator/pkg/localmetrics"
@@ -182,6 +183,13 @@ func main() {
 		setupLog.Error(err, "unable to create controller", "controller", "Account")
 		os.Exit(1)
 	}
+	if err = (&regioncatalog.RegionCatalogReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "RegionCatalog")
+		os.Exit(1)
+	}
 	if err = (&validation.AccountValidationReconciler{
 		Client: mgr.GetClient(),
 		Scheme: mgr.GetScheme(),
@@ -190,6 +198,16 @@ func main() {
 		os.Exit(1)
 	}
 
+	// The webhook server needs a serving certificate, which isn't available when running locally
+	if utils.DetectDevMode != utils.DevModeLocal && os.Getenv("ENABLE_WEBHOOKS") != "false" {
+		if err = setupWebhooks(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhooks")
+			os.Exit(1)
+		}
+	} else {
+		setupLog.Info("skipping validating webhooks")
+	}
+
 	//+kubebuilder:scaffold:builder
 
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
@@ -301,3 +319,26 @@ func initOperatorConfigMapVars(kubeClient client.Client) {
 		return
 	}
 }
+
+// setupWebhooks registers the validating webhooks of every CRD with the manager's webhook server
+func setupWebhooks(mgr ctrl.Manager) error {
+	if err := (&awsv1alpha1.AccountClaim{}).SetupWebhookWithManager(mgr); err != nil {
+		return fmt.Errorf("AccountClaim: %w", err)
+	}
+	if err := (&awsv1alpha1.Account{}).SetupWebhookWithManager(mgr); err != nil {
+		return fmt.Errorf("Account: %w", err)
+	}
+	if err := (&awsv1alpha1.AccountPool{}).SetupWebhookWithManager(mgr); err != nil {
+		return fmt.Errorf("AccountPool: %w", err)
+	}
+	if err := (&awsv1alpha1.AWSFederatedRole{}).SetupWebhookWithManager(mgr); err != nil {
+		return fmt.Errorf("AWSFederatedRole: %w", err)
+	}
+	if err := (&awsv1alpha1.AWSFederatedAccountAccess{}).SetupWebhookWithManager(mgr); err != nil {
+		return fmt.Errorf("AWSFederatedAccountAccess: %w", err)
+	}
+	if err := (&awsv1alpha1.RegionCatalog{}).SetupWebhookWithManager(mgr); err != nil {
+		return fmt.Errorf("RegionCatalog: %w", err)
+	}
+	return nil
+}
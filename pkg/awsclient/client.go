@@ -32,6 +32,10 @@ import (
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	"github.com/aws/aws-sdk-go/service/elb"
+	"github.com/aws/aws-sdk-go/service/elb/elbiface"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/aws/aws-sdk-go/service/elbv2/elbv2iface"
 	"github.com/aws/aws-sdk-go/service/iam"
 	"github.com/aws/aws-sdk-go/service/iam/iamiface"
 	"github.com/aws/aws-sdk-go/service/organizations"
@@ -70,11 +74,35 @@ type Client interface {
 	DescribeVpcEndpointServiceConfigurations(input *ec2.DescribeVpcEndpointServiceConfigurationsInput) (*ec2.DescribeVpcEndpointServiceConfigurationsOutput, error)
 	DeleteVpcEndpointServiceConfigurations(*ec2.DeleteVpcEndpointServiceConfigurationsInput) (*ec2.DeleteVpcEndpointServiceConfigurationsOutput, error)
 	DescribeVpcs(*ec2.DescribeVpcsInput) (*ec2.DescribeVpcsOutput, error)
+	DescribeImages(*ec2.DescribeImagesInput) (*ec2.DescribeImagesOutput, error)
 	CreateVpc(*ec2.CreateVpcInput) (*ec2.CreateVpcOutput, error)
 	DeleteVpc(*ec2.DeleteVpcInput) (*ec2.DeleteVpcOutput, error)
 	DescribeSubnets(*ec2.DescribeSubnetsInput) (*ec2.DescribeSubnetsOutput, error)
 	CreateSubnet(*ec2.CreateSubnetInput) (*ec2.CreateSubnetOutput, error)
 	DeleteSubnet(*ec2.DeleteSubnetInput) (*ec2.DeleteSubnetOutput, error)
+	DescribeNatGateways(*ec2.DescribeNatGatewaysInput) (*ec2.DescribeNatGatewaysOutput, error)
+	DeleteNatGateway(*ec2.DeleteNatGatewayInput) (*ec2.DeleteNatGatewayOutput, error)
+	DescribeAddresses(*ec2.DescribeAddressesInput) (*ec2.DescribeAddressesOutput, error)
+	ReleaseAddress(*ec2.ReleaseAddressInput) (*ec2.ReleaseAddressOutput, error)
+	DescribeSecurityGroups(*ec2.DescribeSecurityGroupsInput) (*ec2.DescribeSecurityGroupsOutput, error)
+	RevokeSecurityGroupIngress(*ec2.RevokeSecurityGroupIngressInput) (*ec2.RevokeSecurityGroupIngressOutput, error)
+	RevokeSecurityGroupEgress(*ec2.RevokeSecurityGroupEgressInput) (*ec2.RevokeSecurityGroupEgressOutput, error)
+	DeleteSecurityGroup(*ec2.DeleteSecurityGroupInput) (*ec2.DeleteSecurityGroupOutput, error)
+	DescribeInternetGateways(*ec2.DescribeInternetGatewaysInput) (*ec2.DescribeInternetGatewaysOutput, error)
+	DetachInternetGateway(*ec2.DetachInternetGatewayInput) (*ec2.DetachInternetGatewayOutput, error)
+	DeleteInternetGateway(*ec2.DeleteInternetGatewayInput) (*ec2.DeleteInternetGatewayOutput, error)
+	DescribeRouteTables(*ec2.DescribeRouteTablesInput) (*ec2.DescribeRouteTablesOutput, error)
+	DeleteRouteTable(*ec2.DeleteRouteTableInput) (*ec2.DeleteRouteTableOutput, error)
+	DescribeVpcEndpoints(*ec2.DescribeVpcEndpointsInput) (*ec2.DescribeVpcEndpointsOutput, error)
+	DeleteVpcEndpoints(*ec2.DeleteVpcEndpointsInput) (*ec2.DeleteVpcEndpointsOutput, error)
+	DescribeNetworkInterfaces(*ec2.DescribeNetworkInterfacesInput) (*ec2.DescribeNetworkInterfacesOutput, error)
+	DeleteNetworkInterface(*ec2.DeleteNetworkInterfaceInput) (*ec2.DeleteNetworkInterfaceOutput, error)
+
+	//ELB
+	DescribeLoadBalancers(*elb.DescribeLoadBalancersInput) (*elb.DescribeLoadBalancersOutput, error)
+	DeleteLoadBalancer(*elb.DeleteLoadBalancerInput) (*elb.DeleteLoadBalancerOutput, error)
+	DescribeLoadBalancersV2(*elbv2.DescribeLoadBalancersInput) (*elbv2.DescribeLoadBalancersOutput, error)
+	DeleteLoadBalancerV2(*elbv2.DeleteLoadBalancerInput) (*elbv2.DeleteLoadBalancerOutput, error)
 
 	//IAM
 	CreateAccessKey(*iam.CreateAccessKeyInput) (*iam.CreateAccessKeyOutput, error)
@@ -115,6 +143,8 @@ type Client interface {
 	UntagResource(input *organizations.UntagResourceInput) (*organizations.UntagResourceOutput, error)
 	ListParents(*organizations.ListParentsInput) (*organizations.ListParentsOutput, error)
 	ListTagsForResource(input *organizations.ListTagsForResourceInput) (*organizations.ListTagsForResourceOutput, error)
+	CloseAccount(*CloseAccountInput) (*CloseAccountOutput, error)
+	RemoveAccountFromOrganization(*organizations.RemoveAccountFromOrganizationInput) (*organizations.RemoveAccountFromOrganizationOutput, error)
 
 	//sts
 	AssumeRole(*sts.AssumeRoleInput) (*sts.AssumeRoleOutput, error)
@@ -124,6 +154,7 @@ type Client interface {
 	//Support
 	CreateCase(*support.CreateCaseInput) (*support.CreateCaseOutput, error)
 	DescribeCases(*support.DescribeCasesInput) (*support.DescribeCasesOutput, error)
+	AddCommunicationToCase(*support.AddCommunicationToCaseInput) (*support.AddCommunicationToCaseOutput, error)
 
 	// S3
 	ListBuckets(*s3.ListBucketsInput) (*s3.ListBucketsOutput, error)
@@ -142,10 +173,13 @@ type Client interface {
 	RequestServiceQuotaIncrease(*servicequotas.RequestServiceQuotaIncreaseInput) (*servicequotas.RequestServiceQuotaIncreaseOutput, error)
 	ListRequestedServiceQuotaChangeHistory(*servicequotas.ListRequestedServiceQuotaChangeHistoryInput) (*servicequotas.ListRequestedServiceQuotaChangeHistoryOutput, error)
 	ListRequestedServiceQuotaChangeHistoryByQuota(*servicequotas.ListRequestedServiceQuotaChangeHistoryByQuotaInput) (*servicequotas.ListRequestedServiceQuotaChangeHistoryByQuotaOutput, error)
+	GetRequestedServiceQuotaChange(*servicequotas.GetRequestedServiceQuotaChangeInput) (*servicequotas.GetRequestedServiceQuotaChangeOutput, error)
 }
 
 type awsClient struct {
 	ec2Client           ec2iface.EC2API
+	elbClient           elbiface.ELBAPI
+	elbv2Client         elbv2iface.ELBV2API
 	iamClient           iamiface.IAMAPI
 	orgClient           organizationsiface.OrganizationsAPI
 	stsClient           stsiface.STSAPI
@@ -213,6 +247,10 @@ func (c *awsClient) DescribeVpcs(input *ec2.DescribeVpcsInput) (*ec2.DescribeVpc
 	return c.ec2Client.DescribeVpcs(input)
 }
 
+func (c *awsClient) DescribeImages(input *ec2.DescribeImagesInput) (*ec2.DescribeImagesOutput, error) {
+	return c.ec2Client.DescribeImages(input)
+}
+
 func (c *awsClient) CreateVpc(input *ec2.CreateVpcInput) (*ec2.CreateVpcOutput, error) {
 	return c.ec2Client.CreateVpc(input)
 }
@@ -233,6 +271,90 @@ func (c *awsClient) DeleteSubnet(input *ec2.DeleteSubnetInput) (*ec2.DeleteSubne
 	return c.ec2Client.DeleteSubnet(input)
 }
 
+func (c *awsClient) DescribeNatGateways(input *ec2.DescribeNatGatewaysInput) (*ec2.DescribeNatGatewaysOutput, error) {
+	return c.ec2Client.DescribeNatGateways(input)
+}
+
+func (c *awsClient) DeleteNatGateway(input *ec2.DeleteNatGatewayInput) (*ec2.DeleteNatGatewayOutput, error) {
+	return c.ec2Client.DeleteNatGateway(input)
+}
+
+func (c *awsClient) DescribeAddresses(input *ec2.DescribeAddressesInput) (*ec2.DescribeAddressesOutput, error) {
+	return c.ec2Client.DescribeAddresses(input)
+}
+
+func (c *awsClient) ReleaseAddress(input *ec2.ReleaseAddressInput) (*ec2.ReleaseAddressOutput, error) {
+	return c.ec2Client.ReleaseAddress(input)
+}
+
+func (c *awsClient) DescribeSecurityGroups(input *ec2.DescribeSecurityGroupsInput) (*ec2.DescribeSecurityGroupsOutput, error) {
+	return c.ec2Client.DescribeSecurityGroups(input)
+}
+
+func (c *awsClient) RevokeSecurityGroupIngress(input *ec2.RevokeSecurityGroupIngressInput) (*ec2.RevokeSecurityGroupIngressOutput, error) {
+	return c.ec2Client.RevokeSecurityGroupIngress(input)
+}
+
+func (c *awsClient) RevokeSecurityGroupEgress(input *ec2.RevokeSecurityGroupEgressInput) (*ec2.RevokeSecurityGroupEgressOutput, error) {
+	return c.ec2Client.RevokeSecurityGroupEgress(input)
+}
+
+func (c *awsClient) DeleteSecurityGroup(input *ec2.DeleteSecurityGroupInput) (*ec2.DeleteSecurityGroupOutput, error) {
+	return c.ec2Client.DeleteSecurityGroup(input)
+}
+
+func (c *awsClient) DescribeInternetGateways(input *ec2.DescribeInternetGatewaysInput) (*ec2.DescribeInternetGatewaysOutput, error) {
+	return c.ec2Client.DescribeInternetGateways(input)
+}
+
+func (c *awsClient) DetachInternetGateway(input *ec2.DetachInternetGatewayInput) (*ec2.DetachInternetGatewayOutput, error) {
+	return c.ec2Client.DetachInternetGateway(input)
+}
+
+func (c *awsClient) DeleteInternetGateway(input *ec2.DeleteInternetGatewayInput) (*ec2.DeleteInternetGatewayOutput, error) {
+	return c.ec2Client.DeleteInternetGateway(input)
+}
+
+func (c *awsClient) DescribeRouteTables(input *ec2.DescribeRouteTablesInput) (*ec2.DescribeRouteTablesOutput, error) {
+	return c.ec2Client.DescribeRouteTables(input)
+}
+
+func (c *awsClient) DeleteRouteTable(input *ec2.DeleteRouteTableInput) (*ec2.DeleteRouteTableOutput, error) {
+	return c.ec2Client.DeleteRouteTable(input)
+}
+
+func (c *awsClient) DescribeVpcEndpoints(input *ec2.DescribeVpcEndpointsInput) (*ec2.DescribeVpcEndpointsOutput, error) {
+	return c.ec2Client.DescribeVpcEndpoints(input)
+}
+
+func (c *awsClient) DeleteVpcEndpoints(input *ec2.DeleteVpcEndpointsInput) (*ec2.DeleteVpcEndpointsOutput, error) {
+	return c.ec2Client.DeleteVpcEndpoints(input)
+}
+
+func (c *awsClient) DescribeNetworkInterfaces(input *ec2.DescribeNetworkInterfacesInput) (*ec2.DescribeNetworkInterfacesOutput, error) {
+	return c.ec2Client.DescribeNetworkInterfaces(input)
+}
+
+func (c *awsClient) DeleteNetworkInterface(input *ec2.DeleteNetworkInterfaceInput) (*ec2.DeleteNetworkInterfaceOutput, error) {
+	return c.ec2Client.DeleteNetworkInterface(input)
+}
+
+func (c *awsClient) DescribeLoadBalancers(input *elb.DescribeLoadBalancersInput) (*elb.DescribeLoadBalancersOutput, error) {
+	return c.elbClient.DescribeLoadBalancers(input)
+}
+
+func (c *awsClient) DeleteLoadBalancer(input *elb.DeleteLoadBalancerInput) (*elb.DeleteLoadBalancerOutput, error) {
+	return c.elbClient.DeleteLoadBalancer(input)
+}
+
+func (c *awsClient) DescribeLoadBalancersV2(input *elbv2.DescribeLoadBalancersInput) (*elbv2.DescribeLoadBalancersOutput, error) {
+	return c.elbv2Client.DescribeLoadBalancers(input)
+}
+
+func (c *awsClient) DeleteLoadBalancerV2(input *elbv2.DeleteLoadBalancerInput) (*elbv2.DeleteLoadBalancerOutput, error) {
+	return c.elbv2Client.DeleteLoadBalancer(input)
+}
+
 func (c *awsClient) CreateAccessKey(input *iam.CreateAccessKeyInput) (*iam.CreateAccessKeyOutput, error) {
 	return c.iamClient.CreateAccessKey(input)
 }
@@ -376,6 +498,10 @@ func (c *awsClient) ListTagsForResource(input *organizations.ListTagsForResource
 	return c.orgClient.ListTagsForResource(input)
 }
 
+func (c *awsClient) RemoveAccountFromOrganization(input *organizations.RemoveAccountFromOrganizationInput) (*organizations.RemoveAccountFromOrganizationOutput, error) {
+	return c.orgClient.RemoveAccountFromOrganization(input)
+}
+
 func (c *awsClient) AssumeRole(input *sts.AssumeRoleInput) (*sts.AssumeRoleOutput, error) {
 	return c.stsClient.AssumeRole(input)
 }
@@ -388,6 +514,10 @@ func (c *awsClient) DescribeCases(input *support.DescribeCasesInput) (*support.D
 	return c.supportClient.DescribeCases(input)
 }
 
+func (c *awsClient) AddCommunicationToCase(input *support.AddCommunicationToCaseInput) (*support.AddCommunicationToCaseOutput, error) {
+	return c.supportClient.AddCommunicationToCase(input)
+}
+
 func (c *awsClient) GetCallerIdentity(input *sts.GetCallerIdentityInput) (*sts.GetCallerIdentityOutput, error) {
 	return c.stsClient.GetCallerIdentity(input)
 }
@@ -454,6 +584,10 @@ func (c *awsClient) ListRequestedServiceQuotaChangeHistoryByQuota(input *service
 	return c.serviceQuotasClient.ListRequestedServiceQuotaChangeHistoryByQuota(input)
 }
 
+func (c *awsClient) GetRequestedServiceQuotaChange(input *servicequotas.GetRequestedServiceQuotaChangeInput) (*servicequotas.GetRequestedServiceQuotaChangeOutput, error) {
+	return c.serviceQuotasClient.GetRequestedServiceQuotaChange(input)
+}
+
 // NewClient creates our client wrapper object for the actual AWS clients we use.
 // If controllerName is nonempty, metrics are collected timing and counting each AWS request.
 func newClient(controllerName, awsAccessID, awsAccessSecret, token, region string) (Client, error) {
@@ -512,6 +646,8 @@ func newClient(controllerName, awsAccessID, awsAccessSecret, token, region strin
 	return &awsClient{
 		iamClient:           iam.New(s),
 		ec2Client:           ec2.New(ec2Sess),
+		elbClient:           elb.New(s),
+		elbv2Client:         elbv2.New(s),
 		orgClient:           organizations.New(s),
 		route53client:       route53.New(s),
 		s3Client:            s3.New(s),
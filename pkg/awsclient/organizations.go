@@ -0,0 +1,46 @@
+package awsclient
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/private/protocol"
+	"github.com/aws/aws-sdk-go/private/protocol/jsonrpc"
+	"github.com/aws/aws-sdk-go/service/organizations"
+)
+
+// opCloseAccount is the name of the Organizations API closing a member account
+const opCloseAccount = "CloseAccount"
+
+// CloseAccountInput is the input of the Organizations CloseAccount API.
+// The aws-sdk-go version in use predates that API, so the request is built here.
+type CloseAccountInput struct {
+	_ struct{} `type:"structure"`
+
+	// AccountId is the ID of the member account to close
+	AccountId *string `type:"string" required:"true"`
+}
+
+// CloseAccountOutput is the empty output of the Organizations CloseAccount API
+type CloseAccountOutput struct {
+	_ struct{} `type:"structure"`
+}
+
+// CloseAccount closes a member account of the organization. AWS keeps closed accounts
+// SUSPENDED for 90 days before they are permanently closed.
+func (c *awsClient) CloseAccount(input *CloseAccountInput) (*CloseAccountOutput, error) {
+	svc, ok := c.orgClient.(*organizations.Organizations)
+	if !ok {
+		return nil, fmt.Errorf("%s needs an Organizations service client", opCloseAccount)
+	}
+
+	op := &request.Operation{
+		Name:       opCloseAccount,
+		HTTPMethod: "POST",
+		HTTPPath:   "/",
+	}
+	output := &CloseAccountOutput{}
+	req := svc.NewRequest(op, input, output)
+	req.Handlers.Unmarshal.Swap(jsonrpc.UnmarshalHandler.Name, protocol.UnmarshalDiscardBodyHandler)
+	return output, req.Send()
+}
@@ -19,6 +19,8 @@ import (
 	"net/http"
 	neturl "net/url"
 	"strings"
+	"sync"
+	"time"
 
 	awsv1alpha1 "github.com/ravitri/aws-account-operator/api/v1alpha1"
 
@@ -31,6 +33,8 @@ import (
 
 const (
 	operatorName = "aws-account-operator"
+	// claimHistoryRetention is how long the times of the claims that became Ready are kept
+	claimHistoryRetention = 24 * time.Hour
 )
 
 var (
@@ -51,6 +55,8 @@ type MetricsCollector struct {
 	awsLimitDelta                   *prometheus.GaugeVec
 	availableOSDAccounts            *prometheus.GaugeVec
 	accountsProgressing             *prometheus.GaugeVec
+	supportCases                    *prometheus.GaugeVec
+	supportCaseMaxAge               *prometheus.GaugeVec
 	accountReadyDuration            prometheus.Histogram
 	ccsAccountReadyDuration         prometheus.Histogram
 	accountClaimReadyDuration       prometheus.Histogram
@@ -61,6 +67,10 @@ type MetricsCollector struct {
 	accountReuseCleanupFailureCount prometheus.Counter
 	reconcileDuration               *prometheus.HistogramVec
 	apiCallDuration                 *prometheus.HistogramVec
+
+	// claimHistory holds the times non-CCS accountClaims became Ready, for demand pool sizing
+	claimHistoryMutex sync.Mutex
+	claimHistory      []time.Time
 }
 
 // NewMetricsCollector creates a new instance of a Prometheus metrics collector
@@ -120,6 +130,18 @@ func NewMetricsCollector(store cache.Cache) *MetricsCollector {
 			ConstLabels: prometheus.Labels{"name": operatorName},
 		}, []string{"namespace", "pool_name"}),
 
+		supportCases: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name:        "aws_account_operator_support_cases",
+			Help:        "Unresolved Enterprise Support cases grouped by case status",
+			ConstLabels: prometheus.Labels{"name": operatorName},
+		}, []string{"status"}),
+
+		supportCaseMaxAge: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name:        "aws_account_operator_support_case_max_age_seconds",
+			Help:        "Age of the oldest unresolved Enterprise Support case grouped by case status",
+			ConstLabels: prometheus.Labels{"name": operatorName},
+		}, []string{"status"}),
+
 		accountReadyDuration: prometheus.NewHistogram(prometheus.HistogramOpts{
 			Name:        "aws_account_operator_account_ready_duration_seconds",
 			Help:        "The duration for account cr to get ready",
@@ -199,6 +221,8 @@ func (c *MetricsCollector) Describe(ch chan<- *prometheus.Desc) {
 	c.awsLimitDelta.Describe(ch)
 	c.availableOSDAccounts.Describe(ch)
 	c.accountsProgressing.Describe(ch)
+	c.supportCases.Describe(ch)
+	c.supportCaseMaxAge.Describe(ch)
 	c.accountPoolSize.Describe(ch)
 	c.accountPoolSize.Describe(ch)
 	c.accountReuseAvailable.Describe(ch)
@@ -225,6 +249,8 @@ func (c *MetricsCollector) Collect(ch chan<- prometheus.Metric) {
 	c.awsLimitDelta.Collect(ch)
 	c.availableOSDAccounts.Collect(ch)
 	c.accountsProgressing.Collect(ch)
+	c.supportCases.Collect(ch)
+	c.supportCaseMaxAge.Collect(ch)
 	c.accountReuseAvailable.Collect(ch)
 	c.accountReadyDuration.Collect(ch)
 	c.ccsAccountReadyDuration.Collect(ch)
@@ -249,6 +275,8 @@ func (c *MetricsCollector) collect() {
 	c.availableOSDAccounts.Reset()
 	c.accountsProgressing.Reset()
 	c.accountReuseAvailable.Reset()
+	c.supportCases.Reset()
+	c.supportCaseMaxAge.Reset()
 
 	ctx := context.TODO()
 	var (
@@ -274,6 +302,7 @@ func (c *MetricsCollector) collect() {
 		return
 	}
 
+	supportCases := map[string]*awsv1alpha1.AccountSupportCase{}
 	for _, account := range accounts.Items {
 		if account.Status.Claimed {
 			claimed = "true"
@@ -297,7 +326,13 @@ func (c *MetricsCollector) collect() {
 		} else {
 			c.accounts.WithLabelValues(claimed, reused, account.Status.State).Inc()
 		}
+
+		// The accounts of a batch share their support case
+		if account.Status.SupportCase != nil {
+			supportCases[account.Status.SupportCaseID] = account.Status.SupportCase
+		}
 	}
+	c.collectSupportCases(supportCases)
 
 	for _, accountClaim := range accountClaims.Items {
 		c.accountClaims.WithLabelValues(string(accountClaim.Status.State)).Inc()
@@ -311,6 +346,27 @@ func (c *MetricsCollector) collect() {
 	}
 }
 
+// collectSupportCases counts the unresolved support cases, by case ID, and the age of the oldest
+// case of each status
+func (c *MetricsCollector) collectSupportCases(supportCases map[string]*awsv1alpha1.AccountSupportCase) {
+	maxCaseAges := map[string]time.Duration{}
+	for _, supportCase := range supportCases {
+		if supportCase.Status == "" || supportCase.Status == "resolved" {
+			continue
+		}
+		c.supportCases.WithLabelValues(supportCase.Status).Inc()
+		if supportCase.CreationTime == nil {
+			continue
+		}
+		if age := time.Since(supportCase.CreationTime.Time); age > maxCaseAges[supportCase.Status] {
+			maxCaseAges[supportCase.Status] = age
+		}
+	}
+	for status, age := range maxCaseAges {
+		c.supportCaseMaxAge.WithLabelValues(status).Set(age.Seconds())
+	}
+}
+
 // SetTotalAWSAccounts sets the metric watching the total number of AWS accounts known by the operator
 func (c *MetricsCollector) SetTotalAWSAccounts(total int) {
 	c.awsAccounts.Set(float64(total))
@@ -331,7 +387,38 @@ func (c *MetricsCollector) SetAccountClaimReadyDuration(ccs bool, duration float
 		c.ccsAccountClaimReadyDuration.Observe(duration)
 	} else {
 		c.accountClaimReadyDuration.Observe(duration)
+		c.recordClaim(time.Now())
+	}
+}
+
+// recordClaim adds a claim that became Ready at t to the claim history, dropping the expired ones
+func (c *MetricsCollector) recordClaim(t time.Time) {
+	c.claimHistoryMutex.Lock()
+	defer c.claimHistoryMutex.Unlock()
+
+	cutoff := t.Add(-claimHistoryRetention)
+	kept := c.claimHistory[:0]
+	for _, claimTime := range c.claimHistory {
+		if claimTime.After(cutoff) {
+			kept = append(kept, claimTime)
+		}
+	}
+	c.claimHistory = append(kept, t)
+}
+
+// AccountClaimsReadySince returns the number of non-CCS accountClaims that became Ready since the given time.
+// Claims are only kept for a day.
+func (c *MetricsCollector) AccountClaimsReadySince(since time.Time) int {
+	c.claimHistoryMutex.Lock()
+	defer c.claimHistoryMutex.Unlock()
+
+	count := 0
+	for _, claimTime := range c.claimHistory {
+		if claimTime.After(since) {
+			count++
+		}
 	}
+	return count
 }
 
 // SetAccountClaimPendingDuration sets the metric describing the time an accountClaim spends into the Pending state
@@ -4,9 +4,14 @@ import (
 	"fmt"
 	neturl "net/url"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	awsv1alpha1 "github.com/ravitri/aws-account-operator/api/v1alpha1"
 )
 
 func TestPathParse(t *testing.T) {
@@ -130,3 +135,36 @@ func TestReconcileErrorParse(t *testing.T) {
 		})
 	}
 }
+
+func TestAccountClaimsReadySince(t *testing.T) {
+	now := time.Now()
+	c := &MetricsCollector{}
+	c.recordClaim(now.Add(-30 * time.Hour))
+	c.recordClaim(now.Add(-2 * time.Hour))
+	c.recordClaim(now.Add(-30 * time.Minute))
+	c.recordClaim(now)
+
+	assert.Equal(t, 2, c.AccountClaimsReadySince(now.Add(-time.Hour)))
+	assert.Equal(t, 3, c.AccountClaimsReadySince(now.Add(-48*time.Hour)), "claims older than a day are dropped")
+	assert.Len(t, c.claimHistory, 3)
+}
+
+func TestCollectSupportCases(t *testing.T) {
+	c := NewMetricsCollector(nil)
+	created := func(ago time.Duration) *metav1.Time {
+		t := metav1.NewTime(time.Now().Add(-ago))
+		return &t
+	}
+
+	c.collectSupportCases(map[string]*awsv1alpha1.AccountSupportCase{
+		"case-1": {Status: "resolved", CreationTime: created(72 * time.Hour)},
+		"case-2": {Status: "unassigned", CreationTime: created(2 * time.Hour)},
+		"case-3": {Status: "unassigned", CreationTime: created(5 * time.Hour)},
+		"case-4": {Status: "pending-customer-action", CreationTime: created(time.Hour)},
+	})
+
+	assert.Equal(t, float64(2), testutil.ToFloat64(c.supportCases.WithLabelValues("unassigned")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(c.supportCases.WithLabelValues("pending-customer-action")))
+	assert.Equal(t, 2, testutil.CollectAndCount(c.supportCases), "resolved cases are not counted")
+	assert.InDelta(t, (5 * time.Hour).Seconds(), testutil.ToFloat64(c.supportCaseMaxAge.WithLabelValues("unassigned")), 60)
+}
@@ -26,6 +26,7 @@ func InitControllerMaxReconciles(kubeClient client.Client) []error {
 		"accountvalidation",
 		"awsfederatedaccountaccess",
 		"awsfederatedrole",
+		"regioncatalog",
 	}
 	controllerErrors := []error{}
 	cm, err := GetOperatorConfigMap(kubeClient)
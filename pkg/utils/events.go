@@ -0,0 +1,74 @@
+package utils
+
+// Reasons of the Kubernetes Events recorded by the controllers.
+// Events for state transitions use the condition type, or the condition reason, as their reason.
+const (
+	// EventReasonAccountCreated is recorded when the AWS account is created in the organization
+	EventReasonAccountCreated = "AWSAccountCreated"
+	// EventReasonAccountCreationFailed is recorded when AWS refuses to create the account
+	EventReasonAccountCreationFailed = "AWSAccountCreationFailed"
+	// EventReasonAssumeRoleFailed is recorded when the operator can't assume a role in the account
+	EventReasonAssumeRoleFailed = "AssumeRoleFailed"
+	// EventReasonSupportCaseCreated is recorded when a support case is opened for the account
+	EventReasonSupportCaseCreated = "SupportCaseCreated"
+	// EventReasonSupportCaseFailed is recorded when a support case can't be opened or checked
+	EventReasonSupportCaseFailed = "SupportCaseFailed"
+	// EventReasonSupportCaseResolved is recorded when the support case of the account is resolved
+	EventReasonSupportCaseResolved = "SupportCaseResolved"
+	// EventReasonSupportCaseStatusChanged is recorded when the status of the support case of the account changes
+	EventReasonSupportCaseStatusChanged = "SupportCaseStatusChanged"
+	// EventReasonSupportCaseFollowedUp is recorded when a follow-up communication is added to a stalled support case
+	EventReasonSupportCaseFollowedUp = "SupportCaseFollowedUp"
+	// EventReasonRegionInitialized is recorded when a region has been initialized
+	EventReasonRegionInitialized = "RegionInitialized"
+	// EventReasonRegionInitializationFailed is recorded when a region could not be initialized
+	EventReasonRegionInitializationFailed = "RegionInitializationFailed"
+	// EventReasonQuotaIncreaseRequested is recorded when a service quota increase is requested
+	EventReasonQuotaIncreaseRequested = "QuotaIncreaseRequested"
+	// EventReasonQuotaIncreaseFailed is recorded when a service quota can't be checked or its increase requested
+	EventReasonQuotaIncreaseFailed = "QuotaIncreaseFailed"
+	// EventReasonQuotaIncreaseGranted is recorded when a service quota increase request is approved
+	EventReasonQuotaIncreaseGranted = "QuotaIncreaseGranted"
+	// EventReasonQuotaIncreaseDenied is recorded when a service quota increase request is denied
+	EventReasonQuotaIncreaseDenied = "QuotaIncreaseDenied"
+	// EventReasonCredentialsRotated is recorded when the IAM user credentials are rotated
+	EventReasonCredentialsRotated = "CredentialsRotated"
+	// EventReasonCredentialRotationFailed is recorded when the IAM user credentials could not be rotated
+	EventReasonCredentialRotationFailed = "CredentialRotationFailed"
+
+	// EventReasonClaimed is recorded when an account is assigned to a claim
+	EventReasonClaimed = "Claimed"
+	// EventReasonClaimFailed is recorded when a claim can't be satisfied
+	EventReasonClaimFailed = "ClaimFailed"
+	// EventReasonClaimQuotaExceeded is recorded when the legal entity of a pending claim holds as many accounts as it can
+	EventReasonClaimQuotaExceeded = "ClaimQuotaExceeded"
+	// EventReasonClaimTimedOut is recorded when a claim fails after waiting for an account for too long
+	EventReasonClaimTimedOut = "ClaimTimedOut"
+	// EventReasonInvalidClaim is recorded when a claim is missing required values
+	EventReasonInvalidClaim = "InvalidAccountClaim"
+	// EventReasonMovedToOU is recorded when an account is moved to its organizational unit
+	EventReasonMovedToOU = "MovedToOU"
+	// EventReasonReuseCleanupSucceeded is recorded when the account has been emptied for reuse
+	EventReasonReuseCleanupSucceeded = "ReuseCleanupSucceeded"
+	// EventReasonReuseCleanupFailed is recorded when the account could not be emptied for reuse
+	EventReasonReuseCleanupFailed = "ReuseCleanupFailed"
+	// EventReasonReuseCleanupPending is recorded when resources of the account wait on asynchronous deletions
+	EventReasonReuseCleanupPending = "ReuseCleanupPending"
+	// EventReasonReuseCleanupDryRun is recorded when a dry run listed the resources left in the account
+	EventReasonReuseCleanupDryRun = "ReuseCleanupDryRun"
+
+	// EventReasonPoolAccountCreated is recorded on the pool when it creates an Account
+	EventReasonPoolAccountCreated = "AccountCreated"
+	// EventReasonAccountRetired is recorded when a surplus account of a pool is parked or closed
+	EventReasonAccountRetired = "AccountRetired"
+	// EventReasonAccountClosed is recorded when the AWS account of a failed or deleted Account is closed
+	EventReasonAccountClosed = "AccountClosed"
+	// EventReasonAccountClosureFailed is recorded when a step of the AWS account closure fails
+	EventReasonAccountClosureFailed = "AccountClosureFailed"
+
+	// EventReasonValidationFailed is recorded when an account fails validation
+	EventReasonValidationFailed = "ValidationFailed"
+
+	// EventReasonAMINotFound is recorded on a region catalog when one of its AMIs doesn't exist
+	EventReasonAMINotFound = "AMINotFound"
+)